@@ -0,0 +1,178 @@
+package meraki
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// DefaultAdaptiveBackoffSuccesses is the default number of consecutive successful requests
+// after which an adaptive backoff baseline is shrunk, see AdaptiveBackoff.
+const DefaultAdaptiveBackoffSuccesses int = 100
+
+const (
+	adaptiveBackoffMinScale float64 = 0.5
+	adaptiveBackoffMaxScale float64 = 8
+)
+
+// DefaultAdaptiveRateSuccesses is the default number of consecutive successful requests
+// after which an adaptive request rate is raised, see AdaptiveRateLimit.
+const DefaultAdaptiveRateSuccesses int = 50
+
+const (
+	adaptiveRateMinFactor float64 = 0.1
+	adaptiveRateStep      float64 = 0.1
+	// Rate limited responses within this window after a decrease belong to the same burst
+	adaptiveRateWindow time.Duration = time.Second
+)
+
+// adaptiveBackoff scales the minimum backoff delay based on the recent health of the API.
+// It is shared by all copies of a client.
+type adaptiveBackoff struct {
+	mutex sync.Mutex
+	// Consecutive successful requests needed to halve the scale
+	threshold int
+	// Consecutive successful requests since the last retry or scale change
+	successes int
+	// Factor applied to the minimum backoff delay
+	scale float64
+}
+
+// AdaptiveBackoff makes the backoff baseline adapt to long lived workloads. Every retry doubles
+// the minimum delay between two retries, up to 8 times BackoffMinDelay, and every n consecutive
+// successful requests halve it again, down to half of BackoffMinDelay. The maximum delay is not
+// affected. A value of 0 uses DefaultAdaptiveBackoffSuccesses, e.g.
+//
+//	client, _ := NewClient("abc123", AdaptiveBackoff(0))
+func AdaptiveBackoff(n int) func(*Client) {
+	return func(client *Client) {
+		if n <= 0 {
+			n = DefaultAdaptiveBackoffSuccesses
+		}
+		client.adaptive = &adaptiveBackoff{threshold: n, scale: 1}
+	}
+}
+
+// backoffScale returns the factor applied to the minimum backoff delay.
+func (client *Client) backoffScale() float64 {
+	if client.adaptive == nil {
+		return 1
+	}
+	client.adaptive.mutex.Lock()
+	defer client.adaptive.mutex.Unlock()
+	return client.adaptive.scale
+}
+
+// adaptiveRetry lengthens the backoff baseline after a failed attempt.
+func (client *Client) adaptiveRetry() {
+	if client.adaptive == nil {
+		return
+	}
+	a := client.adaptive
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.successes = 0
+	if a.scale < adaptiveBackoffMaxScale {
+		a.scale = min(a.scale*2, adaptiveBackoffMaxScale)
+		client.logf("[DEBUG] Adaptive backoff scale increased to %v", a.scale)
+	}
+}
+
+// adaptiveSuccess shrinks the backoff baseline after sustained successful requests.
+func (client *Client) adaptiveSuccess() {
+	if client.adaptive == nil {
+		return
+	}
+	a := client.adaptive
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.successes++
+	if a.successes >= a.threshold && a.scale > adaptiveBackoffMinScale {
+		a.successes = 0
+		a.scale = max(a.scale/2, adaptiveBackoffMinScale)
+		client.logf("[DEBUG] Adaptive backoff scale decreased to %v", a.scale)
+	}
+}
+
+// adaptiveRate scales the effective request rate based on the observed rate limited responses.
+// It is shared by all copies of a client.
+type adaptiveRate struct {
+	mutex sync.Mutex
+	// Consecutive successful requests needed to raise the factor
+	threshold int
+	// Consecutive successful requests since the last rate limited response or factor change
+	successes int
+	// Fraction of the configured request rate in effect
+	factor float64
+	// Time of the last decrease of the factor
+	decreased time.Time
+}
+
+// AdaptiveRateLimit makes the effective request rate adapt to other API consumers sharing the
+// rate limit budget of an organization. A burst of rate limited (429) responses halves the
+// effective rate, down to a tenth of RequestPerSecond, and every n consecutive successful
+// requests raise it again by a tenth of RequestPerSecond, up to RequestPerSecond. Rate limited
+// responses within a second of a decrease count as the same burst. A value of 0 uses
+// DefaultAdaptiveRateSuccesses, e.g.
+//
+//	client, _ := NewClient("abc123", AdaptiveRateLimit(0))
+func AdaptiveRateLimit(n int) func(*Client) {
+	return func(client *Client) {
+		if n <= 0 {
+			n = DefaultAdaptiveRateSuccesses
+		}
+		client.adaptiveRate = &adaptiveRate{threshold: n, factor: 1}
+	}
+}
+
+// rateFactor returns the fraction of the configured request rate in effect.
+func (client *Client) rateFactor() float64 {
+	if client.adaptiveRate == nil {
+		return 1
+	}
+	client.adaptiveRate.mutex.Lock()
+	defer client.adaptiveRate.mutex.Unlock()
+	return client.adaptiveRate.factor
+}
+
+// adaptiveCost returns the rate limiter tokens taken by a request, which are increased to
+// lower the effective request rate.
+func (client *Client) adaptiveCost(cost int64) int64 {
+	factor := client.rateFactor()
+	if factor >= 1 {
+		return cost
+	}
+	return int64(math.Ceil(float64(cost) / factor))
+}
+
+// adaptiveRateLimited lowers the effective request rate after a rate limited response.
+func (client *Client) adaptiveRateLimited() {
+	if client.adaptiveRate == nil {
+		return
+	}
+	a := client.adaptiveRate
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.successes = 0
+	if a.factor > adaptiveRateMinFactor && time.Since(a.decreased) >= adaptiveRateWindow {
+		a.factor = max(a.factor/2, adaptiveRateMinFactor)
+		a.decreased = time.Now()
+		client.logf("[DEBUG] Adaptive request rate decreased to %v", a.factor)
+	}
+}
+
+// adaptiveRateSuccess raises the effective request rate after sustained successful requests.
+func (client *Client) adaptiveRateSuccess() {
+	if client.adaptiveRate == nil {
+		return
+	}
+	a := client.adaptiveRate
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.successes++
+	if a.successes >= a.threshold && a.factor < 1 {
+		a.successes = 0
+		a.factor = min(math.Round((a.factor+adaptiveRateStep)*100)/100, 1)
+		client.logf("[DEBUG] Adaptive request rate increased to %v", a.factor)
+	}
+}
@@ -0,0 +1,76 @@
+package meraki
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestAdaptiveBackoff tests the AdaptiveBackoff modifier.
+func TestAdaptiveBackoff(t *testing.T) {
+	defer gock.Off()
+	client, _ := NewClient("abc123", MaxRetries(2), BackoffMinDelay(0), BackoffMaxDelay(0), AdaptiveBackoff(2))
+	gock.InterceptClient(client.HttpClient)
+	assert.Equal(t, 1.0, client.Stats().BackoffScale)
+
+	gock.New(client.BaseUrl).Get("/url").Times(2).Reply(503)
+	gock.New(client.BaseUrl).Get("/url").Reply(200).BodyString(`{}`)
+	_, err := client.Get("/url")
+	assert.NoError(t, err)
+	assert.Equal(t, 4.0, client.Stats().BackoffScale)
+
+	// Consecutive successes shrink the baseline
+	gock.New(client.BaseUrl).Get("/url").Times(7).Reply(200).BodyString(`{}`)
+	for i := 0; i < 7; i++ {
+		client.Get("/url")
+	}
+	assert.Equal(t, 0.5, client.Stats().BackoffScale)
+
+	// The baseline is capped
+	for i := 0; i < 10; i++ {
+		client.adaptiveRetry()
+	}
+	assert.Equal(t, 8.0, client.Stats().BackoffScale)
+
+	// Disabled by default
+	client = testClient()
+	client.adaptiveRetry()
+	assert.Equal(t, 1.0, client.Stats().BackoffScale)
+}
+
+// TestAdaptiveRateLimit tests the AdaptiveRateLimit modifier.
+func TestAdaptiveRateLimit(t *testing.T) {
+	defer gock.Off()
+	client, _ := NewClient("abc123", MaxRetries(0), BackoffMinDelay(0), BackoffMaxDelay(0), AdaptiveRateLimit(2))
+	gock.InterceptClient(client.HttpClient)
+	assert.Equal(t, 1.0, client.Stats().RateFactor)
+
+	// A burst of rate limited responses halves the rate once
+	gock.New(client.BaseUrl).Get("/url").Times(3).Reply(429).SetHeader("Retry-After", "0.01")
+	gock.New(client.BaseUrl).Get("/url").Reply(200).BodyString(`{}`)
+	_, err := client.Get("/url")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, client.Stats().RateFactor)
+	assert.Equal(t, int64(4), client.adaptiveCost(2))
+
+	// Consecutive successes raise the rate
+	gock.New(client.BaseUrl).Get("/url").Reply(200).BodyString(`{}`)
+	client.Get("/url")
+	assert.Equal(t, 0.6, client.Stats().RateFactor)
+
+	// The rate is capped
+	for i := 0; i < 10; i++ {
+		client.adaptiveRate.decreased = time.Time{}
+		client.adaptiveRateLimited()
+	}
+	assert.Equal(t, 0.1, client.Stats().RateFactor)
+	assert.Equal(t, int64(10), client.adaptiveCost(1))
+
+	// Disabled by default
+	client = testClient()
+	client.adaptiveRateLimited()
+	assert.Equal(t, 1.0, client.Stats().RateFactor)
+	assert.Equal(t, int64(1), client.adaptiveCost(1))
+}
@@ -0,0 +1,201 @@
+package meraki
+
+import (
+	"context"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// AlertEventType is the type of an AlertEvent.
+type AlertEventType string
+
+const (
+	// AlertRaised is emitted for a new active alert
+	AlertRaised AlertEventType = "raised"
+	// AlertCleared is emitted for an alert which is no longer active
+	AlertCleared AlertEventType = "cleared"
+)
+
+// Alert is an active alert of an organization or network.
+type Alert struct {
+	// ID is the ID of the alert
+	ID string
+	// Type is the type of the alert, e.g. "Unreachable device"
+	Type string
+	// Category is the category of the alert, e.g. "Connectivity"
+	Category string
+	// Severity is the severity of the alert, e.g. "critical"
+	Severity string
+	// Title is the title of the alert, if available
+	Title string
+	// NetworkID is the ID of the affected network, if available
+	NetworkID string
+	// Raw is the alert object as returned by the API
+	Raw gjson.Result
+}
+
+// AlertEvent is emitted by an AlertWatcher when an alert is raised or cleared.
+type AlertEvent struct {
+	// Type is AlertRaised or AlertCleared
+	Type AlertEventType
+	// Alert is the alert, or the last known one if cleared
+	Alert Alert
+	// Time is the time of the poll which detected the change
+	Time time.Time
+}
+
+// AlertWatcher polls the active alerts of an organization or network and emits an event for
+// every new or cleared alert, as an alternative to webhooks where inbound HTTP is not possible.
+// Use meraki.NewOrgAlertWatcher or meraki.NewNetworkAlertWatcher to initiate a watcher.
+type AlertWatcher struct {
+	// Client is the client used for requests
+	Client *Client
+	// Path is the polled endpoint
+	Path string
+	// Mods are the request modifiers of the polls
+	Mods []func(*Req)
+	// Interval between two polls
+	Interval time.Duration
+	// Maximum interval between two polls after failures
+	MaxBackoff time.Duration
+	// EmitInitial emits an event for every active alert on the first poll
+	EmitInitial bool
+	// OnError is called when a poll fails
+	OnError func(err error)
+	// Last known active alerts by ID
+	state map[string]Alert
+}
+
+// NewOrgAlertWatcher creates a new watcher of the active assurance alerts of an organization.
+// Pass modifiers in to modify the behavior of the watcher, e.g.
+//
+//	watcher := NewOrgAlertWatcher(&client, "123456", AlertInterval(30*time.Second))
+//	watcher.Watch(ctx, func(event AlertEvent) {
+//		log.Printf("%s: %s (%s)", event.Type, event.Alert.Type, event.Alert.Severity)
+//	})
+func NewOrgAlertWatcher(client *Client, orgID string, mods ...func(*AlertWatcher)) *AlertWatcher {
+	return newAlertWatcher(client, "/organizations/"+orgID+"/assurance/alerts", []func(*Req){Query("active", "true")}, mods...)
+}
+
+// NewNetworkAlertWatcher creates a new watcher of the health alerts of a network, see NewOrgAlertWatcher.
+func NewNetworkAlertWatcher(client *Client, networkID string, mods ...func(*AlertWatcher)) *AlertWatcher {
+	return newAlertWatcher(client, "/networks/"+networkID+"/health/alerts", nil, mods...)
+}
+
+func newAlertWatcher(client *Client, path string, reqMods []func(*Req), mods ...func(*AlertWatcher)) *AlertWatcher {
+	watcher := AlertWatcher{
+		Client:     client,
+		Path:       path,
+		Mods:       reqMods,
+		Interval:   DefaultWatchInterval,
+		MaxBackoff: DefaultWatchMaxBackoff,
+	}
+	for _, mod := range mods {
+		mod(&watcher)
+	}
+	return &watcher
+}
+
+// AlertInterval modifies the interval between two polls. Default value is 60 seconds.
+func AlertInterval(x time.Duration) func(*AlertWatcher) {
+	return func(watcher *AlertWatcher) {
+		watcher.Interval = x
+	}
+}
+
+// AlertMaxBackoff modifies the maximum interval between two polls after failures. Default value is 15 minutes.
+func AlertMaxBackoff(x time.Duration) func(*AlertWatcher) {
+	return func(watcher *AlertWatcher) {
+		watcher.MaxBackoff = x
+	}
+}
+
+// AlertEmitInitial emits an event for every active alert on the first poll.
+func AlertEmitInitial(watcher *AlertWatcher) {
+	watcher.EmitInitial = true
+}
+
+// Poll fetches the active alerts once and returns the alerts raised and cleared since the last poll.
+func (watcher *AlertWatcher) Poll() ([]AlertEvent, error) {
+	return watcher.PollContext(context.Background())
+}
+
+// PollContext is like Poll and cancels the request when ctx is done.
+func (watcher *AlertWatcher) PollContext(ctx context.Context) ([]AlertEvent, error) {
+	res, err := watcher.Client.GetContext(ctx, watcher.Path, watcher.Mods...)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	initial := watcher.state == nil
+	state := make(map[string]Alert)
+	events := make([]AlertEvent, 0)
+	for _, a := range res.Array() {
+		alert := newAlert(a)
+		if alert.ID == "" || a.Get("resolvedAt").String() != "" || a.Get("dismissedAt").String() != "" {
+			continue
+		}
+		state[alert.ID] = alert
+		if _, known := watcher.state[alert.ID]; known || (initial && !watcher.EmitInitial) {
+			continue
+		}
+		events = append(events, AlertEvent{Type: AlertRaised, Alert: alert, Time: now})
+	}
+	for id, old := range watcher.state {
+		if _, ok := state[id]; !ok {
+			events = append(events, AlertEvent{Type: AlertCleared, Alert: old, Time: now})
+		}
+	}
+	watcher.state = state
+	return events, nil
+}
+
+// Watch polls the active alerts until ctx is cancelled and calls fn for every event.
+// Failed polls are retried with exponential backoff. The requests are bound to ctx and
+// panics of fn and OnError are recovered, see HookError. It returns the context error.
+func (watcher *AlertWatcher) Watch(ctx context.Context, fn func(AlertEvent)) error {
+	failures := 0
+	for {
+		delay := watcher.Interval
+		events, err := watcher.PollContext(ctx)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err != nil {
+			failures++
+			watcher.Client.logf("[ERROR] Alert poll failed: %s, failures: %v", err, failures)
+			if watcher.OnError != nil {
+				watcher.Client.runHook("OnError", func() { watcher.OnError(err) })
+			}
+			delay = watchBackoff(watcher.Interval, watcher.MaxBackoff, failures)
+		} else {
+			failures = 0
+		}
+		for _, event := range events {
+			watcher.Client.runHook("Watch", func() { fn(event) })
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// newAlert creates an Alert from an organization assurance alert or a network health alert.
+func newAlert(a gjson.Result) Alert {
+	alert := Alert{
+		ID:        a.Get("id").String(),
+		Type:      a.Get("type").String(),
+		Category:  a.Get("categoryType").String(),
+		Severity:  a.Get("severity").String(),
+		Title:     a.Get("title").String(),
+		NetworkID: a.Get("network.id").String(),
+		Raw:       a,
+	}
+	if alert.Category == "" {
+		alert.Category = a.Get("category").String()
+	}
+	return alert
+}
@@ -0,0 +1,95 @@
+package meraki
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestAlertWatcherPoll tests the AlertWatcher::Poll method.
+func TestAlertWatcherPoll(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+	watcher := NewOrgAlertWatcher(&client, "123")
+
+	gock.New(client.BaseUrl).Get("/organizations/123/assurance/alerts").MatchParam("active", "true").
+		Reply(200).
+		BodyString(`[{"id":"1","type":"Unreachable device","categoryType":"connectivity","severity":"critical","network":{"id":"N_1"}}]`)
+	gock.New(client.BaseUrl).Get("/organizations/123/assurance/alerts").MatchParam("active", "true").
+		Reply(200).
+		BodyString(`[{"id":"1","resolvedAt":"2024-01-01T00:00:00Z"},{"id":"2","type":"High CPU","severity":"warning"}]`)
+
+	events, err := watcher.Poll()
+	assert.NoError(t, err)
+	assert.Len(t, events, 0)
+
+	events, err = watcher.Poll()
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, AlertRaised, events[0].Type)
+	assert.Equal(t, "High CPU", events[0].Alert.Type)
+	assert.Equal(t, AlertCleared, events[1].Type)
+	assert.Equal(t, Alert{ID: "1", Type: "Unreachable device", Category: "connectivity", Severity: "critical", NetworkID: "N_1"}, withoutRaw(events[1].Alert))
+	assert.True(t, gock.IsDone())
+}
+
+// TestAlertWatcherWatch tests the AlertWatcher::Watch method.
+func TestAlertWatcherWatch(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+	watcher := NewNetworkAlertWatcher(&client, "N_1", AlertEmitInitial, AlertInterval(time.Millisecond))
+
+	gock.New(client.BaseUrl).Get("/networks/N_1/health/alerts").
+		Persist().
+		Reply(200).
+		BodyString(`[{"id":"1","category":"Connectivity","type":"Unreachable device","severity":"critical"}]`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var events []AlertEvent
+	err := watcher.Watch(ctx, func(event AlertEvent) {
+		events = append(events, event)
+		cancel()
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, events, 1)
+	assert.Equal(t, "Connectivity", events[0].Alert.Category)
+}
+
+// TestAlertWatcherWatchContext tests that AlertWatcher::Watch binds requests to the context and recovers panics of callbacks.
+func TestAlertWatcherWatchContext(t *testing.T) {
+	var count atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch count.Add(1) {
+		case 1:
+			w.WriteHeader(500)
+		case 2:
+			w.Write([]byte(`[{"id":"1","category":"Connectivity"}]`))
+		default:
+			<-r.Context().Done()
+		}
+	}))
+	defer server.Close()
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), Transport(server.Client().Transport))
+	watcher := NewNetworkAlertWatcher(&client, "N_1", AlertEmitInitial, AlertInterval(time.Millisecond), AlertMaxBackoff(time.Millisecond))
+	watcher.OnError = func(err error) { panic("OnError") }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := watcher.Watch(ctx, func(event AlertEvent) { panic("fn") })
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, int64(3), count.Load())
+	assert.Equal(t, int64(2), client.Stats().HookErrors)
+}
+
+func withoutRaw(alert Alert) Alert {
+	alert.Raw = Alert{}.Raw
+	return alert
+}
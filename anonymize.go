@@ -0,0 +1,287 @@
+package meraki
+
+import (
+	"container/list"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	anonSerialRegexp = regexp.MustCompile(`\b[A-Z0-9]{4}-[A-Z0-9]{4}-[A-Z0-9]{4}\b`)
+	anonMACRegexp    = regexp.MustCompile(`\b[0-9a-fA-F]{2}(?:[:-][0-9a-fA-F]{2}){5}\b`)
+	anonIPv4Regexp   = regexp.MustCompile(`\b(?:[0-9]{1,3}\.){3}[0-9]{1,3}\b`)
+	anonIPv6Regexp   = regexp.MustCompile(`\b(?:[0-9a-fA-F]{1,4}::?){1,7}[0-9a-fA-F]{1,4}\b`)
+)
+
+// Anonymizer masks MAC addresses, IP addresses, serial numbers and organization names in
+// logs and debug artifacts, so they can be shared without leaking network identifiers.
+// Every value is replaced by a stable pseudonym derived from a keyed hash, so the same
+// value is always masked the same way and log lines remain correlatable, e.g.
+// Q2XX-AB12-CD34 becomes ANON-3F2A-91C0 and 00:18:0a:12:34:56 becomes 02:5d:e1:07:aa:4b.
+// Use meraki.NewAnonymizer to initiate an anonymizer.
+type Anonymizer struct {
+	key        []byte
+	mutex      sync.RWMutex
+	names      map[string]bool
+	pseudonyms map[string]*list.Element
+	order      *list.List
+}
+
+// anonMaxPseudonyms is the number of most recently used MAC and IP pseudonyms recognized by an
+// anonymizer, which bounds its memory for long-lived clients.
+const anonMaxPseudonyms = 10000
+
+// NewAnonymizer creates a new anonymizer with a secret key. An empty key creates a random key,
+// i.e. the pseudonyms are only stable within the running process.
+func NewAnonymizer(key string) *Anonymizer {
+	a := &Anonymizer{key: []byte(key), names: make(map[string]bool), pseudonyms: make(map[string]*list.Element), order: list.New()}
+	if key == "" {
+		a.key = make([]byte, 32)
+		rand.Read(a.key)
+	}
+	return a
+}
+
+// anonNameRegexp matches a pseudonym of a name at the start of a string.
+var anonNameRegexp = regexp.MustCompile(`^name-[0-9a-f]{8}\b`)
+
+// anonMinNameLength is the minimum number of characters of masked names, shorter names are
+// too likely to be part of unrelated log output, e.g. "IT" or "Lab".
+const anonMinNameLength = 4
+
+// AddNames adds names to be masked, e.g. organization or network names. Organization names
+// are added automatically by clients using the anonymizer, see Anonymize. Names are masked as
+// whole words only, not as part of other words or as JSON object keys, and names shorter than
+// four characters are ignored.
+func (a *Anonymizer) AddNames(names ...string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if utf8.RuneCountInString(name) >= anonMinNameLength {
+			a.names[name] = true
+		}
+	}
+}
+
+// String returns s with all identifiers masked. Pseudonyms are not masked again, so masking
+// already masked text, e.g. the log of a masked capture, does not change it.
+func (a *Anonymizer) String(s string) string {
+	a.mutex.RLock()
+	names := make([]string, 0, len(a.names))
+	for name := range a.names {
+		names = append(names, name)
+	}
+	a.mutex.RUnlock()
+	s = a.maskNames(s, names)
+	s = anonSerialRegexp.ReplaceAllStringFunc(s, func(serial string) string {
+		if strings.HasPrefix(serial, "ANON-") {
+			return serial
+		}
+		h := strings.ToUpper(a.hash(serial))
+		return "ANON-" + h[:4] + "-" + h[4:8]
+	})
+	s = anonMACRegexp.ReplaceAllStringFunc(s, func(mac string) string {
+		if a.isPseudonym(mac) {
+			return mac
+		}
+		h := a.hash(strings.ToLower(strings.ReplaceAll(mac, "-", ":")))
+		return a.pseudonym(fmt.Sprintf("02:%s:%s:%s:%s:%s", h[0:2], h[2:4], h[4:6], h[6:8], h[8:10]))
+	})
+	s = anonIPv6Regexp.ReplaceAllStringFunc(s, func(ip string) string {
+		if net.ParseIP(ip) == nil || a.isPseudonym(ip) {
+			return ip
+		}
+		h := a.hash(ip)
+		return a.pseudonym("fd00::" + h[0:4] + ":" + h[4:8])
+	})
+	s = anonIPv4Regexp.ReplaceAllStringFunc(s, func(ip string) string {
+		if net.ParseIP(ip) == nil || a.isPseudonym(ip) {
+			return ip
+		}
+		h, _ := hex.DecodeString(a.hash(ip)[:6])
+		return a.pseudonym(fmt.Sprintf("10.%d.%d.%d", h[0], h[1], h[2]))
+	})
+	return s
+}
+
+// pseudonym records a generated pseudonym of a MAC or IP address and returns it. Unlike the
+// pseudonyms of names and serials, these have the format of real addresses, e.g. 10.x.x.x, so
+// they are recognized by value instead of by format. Only the anonMaxPseudonyms most recently
+// used pseudonyms are kept.
+func (a *Anonymizer) pseudonym(p string) string {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if e, ok := a.pseudonyms[p]; ok {
+		a.order.MoveToFront(e)
+		return p
+	}
+	a.pseudonyms[p] = a.order.PushFront(p)
+	if a.order.Len() > anonMaxPseudonyms {
+		oldest := a.order.Back()
+		a.order.Remove(oldest)
+		delete(a.pseudonyms, oldest.Value.(string))
+	}
+	return p
+}
+
+// isPseudonym reports whether a MAC or IP address is a recently used pseudonym generated by the anonymizer.
+func (a *Anonymizer) isPseudonym(s string) bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	e, ok := a.pseudonyms[s]
+	if ok {
+		a.order.MoveToFront(e)
+	}
+	return ok
+}
+
+// maskNames replaces all names which occur as whole words in a single pass and skips existing
+// pseudonyms, so these are not masked again. Longer names are matched first, so names containing other names are
+// masked as a whole.
+func (a *Anonymizer) maskNames(s string, names []string) string {
+	if len(names) == 0 {
+		return s
+	}
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	var b strings.Builder
+	last := 0
+	for i := 0; i < len(s); {
+		name := ""
+		if i == 0 || !isWordRune(lastRune(s[:i])) {
+			if n := anonNameRegexp.FindString(s[i:]); n != "" {
+				i += len(n)
+				continue
+			}
+			for _, n := range names {
+				if strings.HasPrefix(s[i:], n) && isNameEnd(s[i+len(n):], n) {
+					name = n
+					break
+				}
+			}
+		}
+		if name == "" {
+			_, size := utf8.DecodeRuneInString(s[i:])
+			i += size
+			continue
+		}
+		b.WriteString(s[last:i])
+		b.WriteString("name-" + a.hash(name)[:8])
+		i += len(name)
+		last = i
+	}
+	if last == 0 {
+		return s
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// isNameEnd reports whether a name is followed by a word boundary and is not a JSON object key.
+func isNameEnd(rest, name string) bool {
+	if rest != "" && isWordRune(lastRune(name)) {
+		if r, _ := utf8.DecodeRuneInString(rest); isWordRune(r) {
+			return false
+		}
+	}
+	return !strings.HasPrefix(rest, `":`)
+}
+
+func lastRune(s string) rune {
+	r, _ := utf8.DecodeLastRuneInString(s)
+	return r
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// Writer returns a writer masking all identifiers of the data written to w. The data is
+// masked per write, e.g. per log line or per JSON line of a capture, e.g.
+//
+//	WriteCapture(anonymizer.Writer(f), requests)
+func (a *Anonymizer) Writer(w io.Writer) io.Writer {
+	return &anonymizingWriter{anonymizer: a, w: w}
+}
+
+// hash returns the hex encoded keyed hash of a value.
+func (a *Anonymizer) hash(value string) string {
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type anonymizingWriter struct {
+	anonymizer *Anonymizer
+	w          io.Writer
+}
+
+// Write implements the io.Writer interface. It reports len(p) written if the masked data
+// was written completely.
+func (w *anonymizingWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(w.w, w.anonymizer.String(string(p))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Anonymize masks identifiers in all log output of the library using an anonymizer, e.g.
+//
+//	client, _ := NewClient("abc123", Anonymize(NewAnonymizer("secret")))
+//
+// The messages of the client are masked before they are written to the standard logger, as
+// well as the attributes of the structured log records, see Slog. The output of the standard
+// logger is not changed for other packages. The names of organizations returned by the API
+// are added to the anonymizer automatically.
+func Anonymize(a *Anonymizer) func(*Client) {
+	return func(client *Client) {
+		client.Anonymizer = a
+	}
+}
+
+// logf logs a message using the standard logger, masking identifiers if the client uses an anonymizer.
+func (client *Client) logf(format string, v ...interface{}) {
+	client.output(fmt.Sprintf(format, v...))
+}
+
+// logln logs the operands using the standard logger like log.Println, masking identifiers if
+// the client uses an anonymizer.
+func (client *Client) logln(v ...interface{}) {
+	client.output(fmt.Sprintln(v...))
+}
+
+// output writes a log message, client may be nil for messages of token providers used without a client.
+func (client *Client) output(s string) {
+	if client != nil && client.Anonymizer != nil {
+		s = client.Anonymizer.String(s)
+	}
+	log.Output(3, s)
+}
+
+// learnNames adds the names of organizations of a response to the anonymizer.
+func (client *Client) learnNames(req Req, res Res) {
+	if client.Anonymizer == nil || req.HttpReq.Method != "GET" {
+		return
+	}
+	path := client.relPath(req.HttpReq.URL)
+	switch {
+	case matchPathPattern("/organizations", path):
+		for _, org := range res.Array() {
+			client.Anonymizer.AddNames(org.Get("name").String())
+		}
+	case matchPathPattern("/organizations/*", path):
+		client.Anonymizer.AddNames(res.Get("name").String())
+	}
+}
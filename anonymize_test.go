@@ -0,0 +1,106 @@
+package meraki
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestAnonymizer tests the Anonymizer::String method.
+func TestAnonymizer(t *testing.T) {
+	a := NewAnonymizer("secret")
+	a.AddNames("ACME", "ACME Corp", " ")
+
+	s := "Device Q2XX-AB12-CD34 (00:18:0a:12:34:56) at 192.168.1.10 and 2001:db8::1 of ACME Corp at 12:30:00"
+	masked := a.String(s)
+	for _, id := range []string{"Q2XX-AB12-CD34", "00:18:0a:12:34:56", "192.168.1.10", "2001:db8::1", "ACME"} {
+		assert.NotContains(t, masked, id)
+	}
+	assert.Contains(t, masked, "12:30:00")
+	assert.Regexp(t, `Device ANON-[0-9A-F]{4}-[0-9A-F]{4} \(02(:[0-9a-f]{2}){5}\) at 10\.\d+\.\d+\.\d+ and fd00::`, masked)
+
+	// Pseudonyms are stable and independent of the MAC notation
+	assert.Equal(t, masked, a.String(s))
+	assert.Equal(t, a.String("00:18:0a:12:34:56"), a.String("00-18-0A-12-34-56"))
+	assert.NotEqual(t, masked, NewAnonymizer("other").String(s))
+
+	// Pseudonyms are not masked again
+	assert.Equal(t, masked, a.String(masked))
+	assert.Regexp(t, `^fd00::[0-9a-f]{4}:[0-9a-f]{4}$`, a.String("2001:db8::1:2"))
+
+	// The number of recognized pseudonyms is bounded
+	for i := 0; i <= anonMaxPseudonyms; i++ {
+		a.String(fmt.Sprintf("172.%d.%d.1", i/256, i%256))
+	}
+	assert.Len(t, a.pseudonyms, anonMaxPseudonyms)
+	assert.Equal(t, anonMaxPseudonyms, a.order.Len())
+
+	var buf bytes.Buffer
+	WriteCapture(a.Writer(&buf), []ReplayRequest{{Method: "GET", Path: "/devices/Q2XX-AB12-CD34"}})
+	assert.NotContains(t, buf.String(), "Q2XX-AB12-CD34")
+}
+
+// TestAnonymizerNames tests masking names on word boundaries.
+func TestAnonymizerNames(t *testing.T) {
+	a := NewAnonymizer("secret")
+	a.AddNames("Lab", "Labs", "name", "ACME")
+	pseudonym := a.String("Labs")
+	assert.Regexp(t, `^name-[0-9a-f]{8}$`, pseudonym)
+
+	// Short names are ignored, names are only masked as whole words and JSON values
+	assert.Equal(t, "Label for Lab at https://lab.example.com/Labsite", a.String("Label for Lab at https://lab.example.com/Labsite"))
+	assert.Equal(t, `{"Labs":"`+pseudonym+`","name":"`+a.String("name")+`"}`, a.String(`{"Labs":"Labs","name":"name"}`))
+	assert.Equal(t, "Org "+pseudonym+", ACMEs", a.String("Org Labs, ACMEs"))
+
+	// Pseudonyms are not masked again
+	assert.Equal(t, pseudonym, a.String(pseudonym))
+}
+
+// TestAnonymize tests the Anonymize modifier.
+func TestAnonymize(t *testing.T) {
+	defer gock.Off()
+	defer log.SetOutput(os.Stderr)
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	client := testClient()
+	a := NewAnonymizer("")
+	Anonymize(a)(&client)
+	Anonymize(a)(&client)
+
+	gock.New(client.BaseUrl).Get("/organizations").Reply(200).BodyString(`[{"id":"123","name":"ACME"}]`)
+	gock.New(client.BaseUrl).Get("/devices/Q2XX-AB12-CD34").Reply(200).BodyString(`{"mac":"00:18:0a:12:34:56","lanIp":"192.168.1.10"}`)
+	_, err := client.Get("/organizations")
+	assert.NoError(t, err)
+	_, err = client.Get("/devices/Q2XX-AB12-CD34")
+	assert.NoError(t, err)
+
+	logs := buf.String()
+	assert.NotEmpty(t, logs)
+	for _, id := range []string{"ACME", "Q2XX-AB12-CD34", "00:18:0a:12:34:56", "192.168.1.10"} {
+		assert.NotContains(t, logs, id)
+	}
+
+	// The output of the standard logger is not changed for other packages
+	assert.Equal(t, &buf, log.Writer())
+	buf.Reset()
+	log.Printf("Q2XX-AB12-CD34")
+	assert.Contains(t, buf.String(), "Q2XX-AB12-CD34")
+
+	// Errors of structured log records are masked
+	var records bytes.Buffer
+	Slog(slog.New(slog.NewJSONHandler(&records, nil)))(&client)
+	client.MaxRetries = 0
+	gock.New(client.BaseUrl).Get("/devices/Q2XX-AB12-CD34").ReplyError(errors.New("dial tcp 192.168.1.10:443: connection refused"))
+	_, err = client.Get("/devices/Q2XX-AB12-CD34")
+	assert.Error(t, err)
+	assert.Contains(t, records.String(), `"error":`)
+	assert.NotContains(t, records.String(), "192.168.1.10")
+	assert.NotContains(t, records.String(), "Q2XX-AB12-CD34")
+}
@@ -0,0 +1,168 @@
+package meraki
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditRecord is a structured record of a write operation.
+type AuditRecord struct {
+	// Time is the start time of the operation
+	Time time.Time
+	// Actor identifies who made the operation, see AuditActor
+	Actor string
+	// Method is the HTTP method, e.g. PUT
+	Method string
+	// Path is the API path relative to the base URL, e.g. /networks/N_123
+	Path string
+	// StatusCode is the status code of the last response, 0 if no response was received
+	StatusCode int
+	// Duration is the duration of the operation including retries
+	Duration time.Duration
+	// Err is the error of the operation, nil if it succeeded
+	Err error
+	// Labels are the labels of the request context, see WithLabels
+	Labels map[string]string
+}
+
+// AuditSink receives a record of every write operation (DELETE, POST, PUT) of a client.
+// Audit is called synchronously after the operation completed and must be safe for concurrent use.
+type AuditSink interface {
+	Audit(record AuditRecord)
+}
+
+// Audit registers a sink receiving a record of every write operation, e.g.
+//
+//	w, _ := syslog.Dial("udp", "siem.example.com:514", syslog.LOG_INFO, "go-meraki")
+//	client, _ := NewClient("abc123", Audit(NewWriterAuditSink(w, CEFFormat)))
+func Audit(sink AuditSink) func(*Client) {
+	return func(client *Client) {
+		client.AuditSink = sink
+	}
+}
+
+// AuditActor modifies the actor of audit records, e.g. the name of the automation or user.
+// Default value is the masked API token, e.g. ****c123.
+func AuditActor(x string) func(*Client) {
+	return func(client *Client) {
+		client.AuditActor = x
+	}
+}
+
+// audit sends a record of a write operation to the audit sink.
+func (client *Client) audit(req Req, start time.Time, statusCode int, err error) {
+	if client.AuditSink == nil || req.HttpReq.Method == "GET" {
+		return
+	}
+	actor := client.AuditActor
+	if actor == "" {
+		actor = maskToken(client.currentToken())
+	}
+	record := AuditRecord{
+		Time:       start,
+		Actor:      actor,
+		Method:     req.HttpReq.Method,
+		Path:       client.relPath(req.HttpReq.URL),
+		StatusCode: statusCode,
+		Duration:   time.Since(start),
+		Err:        err,
+		Labels:     req.Labels(),
+	}
+	client.runHook("AuditSink", func() { client.AuditSink.Audit(record) })
+}
+
+// maskToken returns the last 4 characters of a token prefixed with ****.
+func maskToken(token string) string {
+	if len(token) <= 4 {
+		return "****"
+	}
+	return "****" + token[len(token)-4:]
+}
+
+// WriterAuditSink is an AuditSink writing formatted audit records to an io.Writer, one record per line.
+type WriterAuditSink struct {
+	// Writer is the destination of the records, e.g. a *syslog.Writer or a file
+	Writer io.Writer
+	// Format formats a record, e.g. SyslogFormat or CEFFormat
+	Format func(AuditRecord) string
+	mutex  sync.Mutex
+}
+
+// NewWriterAuditSink creates an AuditSink writing records formatted by format to w.
+func NewWriterAuditSink(w io.Writer, format func(AuditRecord) string) *WriterAuditSink {
+	return &WriterAuditSink{Writer: w, Format: format}
+}
+
+// Audit implements the AuditSink interface.
+func (sink *WriterAuditSink) Audit(record AuditRecord) {
+	sink.mutex.Lock()
+	defer sink.mutex.Unlock()
+	fmt.Fprintln(sink.Writer, sink.Format(record))
+}
+
+// SyslogFormat formats an audit record as RFC 5424 syslog message with facility log audit
+// and severity informational, or warning for failed operations.
+func SyslogFormat(record AuditRecord) string {
+	severity := 6
+	if record.Err != nil {
+		severity = 4
+	}
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	sd := fmt.Sprintf(`[meraki@32473 actor="%s" method="%s" path="%s" status="%d" duration="%d"]`,
+		escapeSD(record.Actor), record.Method, escapeSD(record.Path), record.StatusCode, record.Duration.Milliseconds())
+	msg := fmt.Sprintf("%s %s %s", record.Method, record.Path, auditOutcome(record))
+	return fmt.Sprintf("<%d>1 %s %s go-meraki %d audit %s %s",
+		13*8+severity, record.Time.UTC().Format(time.RFC3339Nano), hostname, os.Getpid(), sd, msg)
+}
+
+// CEFFormat formats an audit record in ArcSight Common Event Format (CEF) with severity 3,
+// or 7 for failed operations.
+func CEFFormat(record AuditRecord) string {
+	severity := 3
+	if record.Err != nil {
+		severity = 7
+	}
+	ext := []string{
+		"rt=" + fmt.Sprint(record.Time.UnixMilli()),
+		"suser=" + escapeCEFExtension(record.Actor),
+		"requestMethod=" + record.Method,
+		"request=" + escapeCEFExtension(record.Path),
+		"outcome=" + auditOutcome(record),
+		"cn1Label=statusCode",
+		"cn1=" + fmt.Sprint(record.StatusCode),
+	}
+	if record.Err != nil {
+		ext = append(ext, "msg="+escapeCEFExtension(record.Err.Error()))
+	}
+	return fmt.Sprintf("CEF:0|netascode|go-meraki|1|%s|%s|%d|%s",
+		escapeCEFHeader(record.Method), escapeCEFHeader("Meraki API "+record.Method+" "+record.Path), severity, strings.Join(ext, " "))
+}
+
+func auditOutcome(record AuditRecord) string {
+	if record.Err != nil {
+		return "failure"
+	}
+	return "success"
+}
+
+// escapeSD escapes a RFC 5424 structured data parameter value.
+func escapeSD(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(s)
+}
+
+// escapeCEFHeader escapes a CEF header field.
+func escapeCEFHeader(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ").Replace(s)
+}
+
+// escapeCEFExtension escapes a CEF extension value.
+func escapeCEFExtension(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`).Replace(s)
+}
@@ -0,0 +1,57 @@
+package meraki
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestAudit tests the Audit modifier.
+func TestAudit(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+	var buf bytes.Buffer
+	Audit(NewWriterAuditSink(&buf, CEFFormat))(&client)
+
+	gock.New(client.BaseUrl).Get("/networks/N_1").Reply(200).BodyString(`{}`)
+	gock.New(client.BaseUrl).Put("/networks/N_1").Reply(200).BodyString(`{}`)
+	gock.New(client.BaseUrl).Delete("/networks/N_1").Reply(400).BodyString(`{"errors":["Invalid"]}`)
+	client.Get("/networks/N_1")
+	client.Put("/networks/N_1", `{}`)
+	client.Delete("/networks/N_1")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "CEF:0|netascode|go-meraki|1|PUT|Meraki API PUT /networks/N_1|3|")
+	assert.Contains(t, lines[0], "suser=****c123")
+	assert.Contains(t, lines[0], "outcome=success cn1Label=statusCode cn1=200")
+	assert.Contains(t, lines[1], "|DELETE|")
+	assert.Contains(t, lines[1], "outcome=failure cn1Label=statusCode cn1=400 msg=")
+}
+
+// TestSyslogFormat tests the SyslogFormat function.
+func TestSyslogFormat(t *testing.T) {
+	record := AuditRecord{
+		Time:       time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Actor:      `bot "a"`,
+		Method:     "PUT",
+		Path:       "/networks/N_1",
+		StatusCode: 200,
+		Duration:   1500 * time.Millisecond,
+	}
+	msg := SyslogFormat(record)
+	assert.True(t, strings.HasPrefix(msg, "<110>1 2024-01-02T03:04:05Z "))
+	assert.Contains(t, msg, ` go-meraki `)
+	assert.Contains(t, msg, `[meraki@32473 actor="bot \"a\"" method="PUT" path="/networks/N_1" status="200" duration="1500"] PUT /networks/N_1 success`)
+}
+
+// TestCEFFormat tests the escaping of the CEFFormat function.
+func TestCEFFormat(t *testing.T) {
+	msg := CEFFormat(AuditRecord{Actor: "a=b", Method: "POST", Path: "/a|b"})
+	assert.Contains(t, msg, `|Meraki API POST /a\|b|`)
+	assert.Contains(t, msg, `suser=a\=b`)
+}
@@ -0,0 +1,63 @@
+package meraki
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// Binary decodes a base64 encoded binary field, e.g. a floor plan image or a QR code,
+// and returns its content and content type. Data URLs like "data:image/png;base64,..."
+// are supported, otherwise the content type is detected from the content, e.g.
+//
+//	data, contentType, err := res.Binary("image")
+func (res Res) Binary(path string) ([]byte, string, error) {
+	field := res.Get(path)
+	if field.Type != gjson.String {
+		return nil, "", fmt.Errorf("binary field '%s' not found", path)
+	}
+	s := strings.TrimSpace(field.Str)
+	contentType := ""
+	if strings.HasPrefix(s, "data:") {
+		header, data, found := strings.Cut(s[len("data:"):], ",")
+		if !found || !strings.HasSuffix(header, ";base64") {
+			return nil, "", fmt.Errorf("binary field '%s' is not a base64 data URL", path)
+		}
+		contentType = strings.TrimSuffix(header, ";base64")
+		s = data
+	}
+	data, err := decodeBase64(s)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode binary field '%s': %w", path, err)
+	}
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+	return data, contentType, nil
+}
+
+// WriteBinary decodes a base64 encoded binary field into w and returns its content type, see Binary.
+func (res Res) WriteBinary(path string, w io.Writer) (string, error) {
+	data, contentType, err := res.Binary(path)
+	if err != nil {
+		return "", err
+	}
+	_, err = w.Write(data)
+	return contentType, err
+}
+
+// decodeBase64 decodes standard or URL-safe base64, with or without padding.
+func decodeBase64(s string) ([]byte, error) {
+	var err error
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		var data []byte
+		if data, err = encoding.DecodeString(s); err == nil {
+			return data, nil
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,42 @@
+package meraki
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestResBinary tests the Res::Binary and Res::WriteBinary methods.
+func TestResBinary(t *testing.T) {
+	png := "\x89PNG\r\n\x1a\n"
+	res := Body{}.
+		Set("image", "iVBORw0KGgo=").
+		Set("raw", "iVBORw0KGgo").
+		Set("url", "data:image/svg+xml;base64,PHN2Zy8+").
+		Set("invalid", "!").
+		Set("number", 1).
+		Res()
+
+	data, contentType, err := res.Binary("image")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(png), data)
+	assert.Equal(t, "image/png", contentType)
+
+	data, _, err = res.Binary("raw")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte(png), data)
+
+	var buf bytes.Buffer
+	contentType, err = res.WriteBinary("url", &buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "image/svg+xml", contentType)
+	assert.Equal(t, "<svg/>", buf.String())
+
+	_, _, err = res.Binary("invalid")
+	assert.Error(t, err)
+	_, _, err = res.Binary("number")
+	assert.Error(t, err)
+	_, _, err = res.Binary("missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,96 @@
+package meraki
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// ItemError is the error of a single item of a bulk operation.
+type ItemError struct {
+	// ID identifies the item, e.g. a serial number
+	ID string
+	// Errors are the error messages of the item
+	Errors []string
+}
+
+// Error implements the error interface.
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("%s: %s", e.ID, strings.Join(e.Errors, ", "))
+}
+
+// BulkResult is the result of a bulk operation split into succeeded and failed items.
+type BulkResult struct {
+	// Succeeded are the items which succeeded
+	Succeeded []gjson.Result
+	// Failed are the errors of failed items
+	Failed []*ItemError
+}
+
+// Err returns the errors of all failed items joined together or nil if all items succeeded.
+func (result BulkResult) Err() error {
+	errs := make([]error, 0, len(result.Failed))
+	for _, e := range result.Failed {
+		errs = append(errs, e)
+	}
+	return errors.Join(errs...)
+}
+
+// BulkResult splits a bulk response with partial failures into succeeded and failed items.
+// The succeeded items are the elements of the array at successPath and the failed items are the
+// elements of the array at errorsPath, which are identified by idKey and have their messages
+// in either an "errors" array or an "error" or "message" string, e.g.
+//
+//	result := res.BulkResult("serials", "errors", "serial")
+func (res Res) BulkResult(successPath, errorsPath, idKey string) BulkResult {
+	result := BulkResult{
+		Succeeded: make([]gjson.Result, 0),
+		Failed:    make([]*ItemError, 0),
+	}
+	result.Succeeded = append(result.Succeeded, res.Get(successPath).Array()...)
+	for _, item := range res.Get(errorsPath).Array() {
+		e := &ItemError{ID: item.Get(idKey).String(), Errors: make([]string, 0)}
+		for _, msg := range item.Get("errors").Array() {
+			e.Errors = append(e.Errors, msg.String())
+		}
+		for _, key := range []string{"error", "message"} {
+			if msg := item.Get(key); msg.Type == gjson.String {
+				e.Errors = append(e.Errors, msg.Str)
+			}
+		}
+		result.Failed = append(result.Failed, e)
+	}
+	return result
+}
+
+// ClaimResult splits the response of a device claim into claimed serials and per serial errors.
+func (res Res) ClaimResult() BulkResult {
+	return res.BulkResult("serials", "errors", "serial")
+}
+
+// ActionBatchResult splits an action batch into succeeded and failed actions, identified by
+// their resource. Action batches are executed atomically, therefore either all actions of a
+// completed batch succeeded or all actions of a failed batch failed with the batch errors.
+// Actions of batches still in progress are neither succeeded nor failed.
+func (res Res) ActionBatchResult() BulkResult {
+	result := BulkResult{
+		Succeeded: make([]gjson.Result, 0),
+		Failed:    make([]*ItemError, 0),
+	}
+	actions := res.Get("actions").Array()
+	switch {
+	case res.Get("status.failed").Bool():
+		messages := make([]string, 0)
+		for _, msg := range res.Get("status.errors").Array() {
+			messages = append(messages, msg.String())
+		}
+		for _, action := range actions {
+			result.Failed = append(result.Failed, &ItemError{ID: action.Get("resource").String(), Errors: messages})
+		}
+	case res.Get("status.completed").Bool():
+		result.Succeeded = append(result.Succeeded, actions...)
+	}
+	return result
+}
@@ -0,0 +1,39 @@
+package meraki
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestResClaimResult tests the Res::ClaimResult and Res::BulkResult methods.
+func TestResClaimResult(t *testing.T) {
+	res := Body{Str: `{"serials":["Q-1"],"errors":[{"serial":"Q-2","errors":["Device already claimed"]},{"serial":"Q-3","message":"Invalid serial"}]}`}.Res()
+	result := res.ClaimResult()
+	assert.Len(t, result.Succeeded, 1)
+	assert.Equal(t, "Q-1", result.Succeeded[0].String())
+	assert.Equal(t, []*ItemError{
+		{ID: "Q-2", Errors: []string{"Device already claimed"}},
+		{ID: "Q-3", Errors: []string{"Invalid serial"}},
+	}, result.Failed)
+	assert.EqualError(t, result.Err(), "Q-2: Device already claimed\nQ-3: Invalid serial")
+
+	result = Body{Str: `{"serials":["Q-1"]}`}.Res().ClaimResult()
+	assert.NoError(t, result.Err())
+}
+
+// TestResActionBatchResult tests the Res::ActionBatchResult method.
+func TestResActionBatchResult(t *testing.T) {
+	actions := `"actions":[{"resource":"/devices/Q-1","operation":"update"},{"resource":"/devices/Q-2","operation":"update"}]`
+	result := Body{Str: `{"status":{"completed":true,"failed":false,"errors":[]},` + actions + `}`}.Res().ActionBatchResult()
+	assert.Len(t, result.Succeeded, 2)
+	assert.Len(t, result.Failed, 0)
+
+	result = Body{Str: `{"status":{"completed":false,"failed":true,"errors":["Invalid name"]},` + actions + `}`}.Res().ActionBatchResult()
+	assert.Len(t, result.Succeeded, 0)
+	assert.Equal(t, &ItemError{ID: "/devices/Q-2", Errors: []string{"Invalid name"}}, result.Failed[1])
+
+	result = Body{Str: `{"status":{"completed":false,"failed":false,"errors":[]},` + actions + `}`}.Res().ActionBatchResult()
+	assert.Len(t, result.Succeeded, 0)
+	assert.Len(t, result.Failed, 0)
+}
@@ -0,0 +1,116 @@
+package meraki
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const DefaultCircuitBreakerCooldown time.Duration = 30 * time.Second
+
+// ErrCircuitOpen is the error of requests rejected by an open circuit breaker.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// Circuit breaker states, see Stats.CircuitState.
+const (
+	CircuitClosed   string = "closed"
+	CircuitOpen     string = "open"
+	CircuitHalfOpen string = "half-open"
+)
+
+// circuitBreaker is the state of the circuit breaker. It is shared by all copies of a client.
+type circuitBreaker struct {
+	mutex    sync.Mutex
+	failures int
+	state    string
+	since    time.Time
+}
+
+// CircuitBreaker enables a circuit breaker, which opens after threshold consecutive requests
+// failed with a connection error or a 5xx status code after all retries, e.g.
+//
+//	client, _ := NewClient("abc123", CircuitBreaker(5, time.Minute))
+//
+// While the circuit is open, requests fail fast with ErrCircuitOpen instead of burning through
+// retries and backoff delays. After the cool-down a single request probes the API, while other
+// requests keep failing fast. If the probe succeeds the circuit closes, otherwise it opens again.
+// A cool-down of 0 uses DefaultCircuitBreakerCooldown.
+func CircuitBreaker(threshold int, cooldown time.Duration) func(*Client) {
+	return func(client *Client) {
+		if cooldown <= 0 {
+			cooldown = DefaultCircuitBreakerCooldown
+		}
+		client.CircuitBreakerThreshold = threshold
+		client.CircuitBreakerCooldown = cooldown
+		if client.breaker == nil {
+			client.breaker = &circuitBreaker{state: CircuitClosed}
+		}
+	}
+}
+
+// allowRequest returns ErrCircuitOpen if the circuit breaker rejects a request.
+func (client *Client) allowRequest() error {
+	b := client.breaker
+	if b == nil || client.CircuitBreakerThreshold <= 0 {
+		return nil
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	switch b.state {
+	case CircuitOpen:
+		if time.Since(b.since) < client.CircuitBreakerCooldown {
+			return ErrCircuitOpen
+		}
+		client.logf("[INFO] Circuit breaker half-open, probing API")
+		b.state = CircuitHalfOpen
+	case CircuitHalfOpen:
+		return ErrCircuitOpen
+	}
+	return nil
+}
+
+// reportRequest records the outcome of a request and opens the circuit breaker after
+// CircuitBreakerThreshold consecutive failures or a failed probe.
+func (client *Client) reportRequest(req Req, statusCode int, err error) {
+	b := client.breaker
+	if b == nil || client.CircuitBreakerThreshold <= 0 {
+		return
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	// Requests canceled by the caller neither indicate an outage nor a recovery, a canceled
+	// probe releases the probe slot for the next request
+	if req.HttpReq.Context().Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		if b.state == CircuitHalfOpen {
+			b.state = CircuitOpen
+		}
+		return
+	}
+	failed := err != nil && (statusCode == 0 || statusCode >= 500)
+	if !failed {
+		if b.state != CircuitClosed {
+			client.logf("[INFO] Circuit breaker closed")
+		}
+		b.state = CircuitClosed
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == CircuitHalfOpen || b.failures >= client.CircuitBreakerThreshold {
+		client.logf("[ERROR] Circuit breaker open for %v after %d consecutive failures", client.CircuitBreakerCooldown, b.failures)
+		b.state = CircuitOpen
+		b.since = time.Now()
+	}
+}
+
+// circuitState returns the state of the circuit breaker or an empty string if it is disabled.
+func (client *Client) circuitState() string {
+	b := client.breaker
+	if b == nil {
+		return ""
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.state
+}
@@ -0,0 +1,57 @@
+package meraki
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestCircuitBreaker tests the CircuitBreaker modifier.
+func TestCircuitBreaker(t *testing.T) {
+	defer gock.Off()
+	client, _ := NewClient("abc123", MaxRetries(0), CircuitBreaker(2, 50*time.Millisecond))
+	gock.InterceptClient(client.HttpClient)
+	assert.Equal(t, CircuitClosed, client.Stats().CircuitState)
+
+	// 4xx responses do not open the circuit
+	gock.New(client.BaseUrl).Get("/url").Reply(503)
+	gock.New(client.BaseUrl).Get("/url").Reply(404)
+	gock.New(client.BaseUrl).Get("/url").Reply(503)
+	gock.New(client.BaseUrl).Get("/url").ReplyError(errors.New("fail"))
+	for i := 0; i < 4; i++ {
+		client.Get("/url")
+	}
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, CircuitOpen, client.Stats().CircuitState)
+
+	// Fail fast
+	_, err := client.Get("/url")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	// Failed probe opens the circuit again
+	time.Sleep(60 * time.Millisecond)
+	gock.New(client.BaseUrl).Get("/url").Reply(500)
+	_, err = client.Get("/url")
+	assert.ErrorContains(t, err, "StatusCode 500")
+	_, err = client.Get("/url")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	// Canceled probe releases the probe slot without closing the circuit
+	time.Sleep(60 * time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.GetContext(ctx, "/url")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, CircuitOpen, client.Stats().CircuitState)
+
+	// Successful probe closes the circuit
+	gock.New(client.BaseUrl).Get("/url").Reply(200)
+	_, err = client.Get("/url")
+	assert.NoError(t, err)
+	assert.Equal(t, CircuitClosed, client.Stats().CircuitState)
+	assert.True(t, gock.IsDone())
+}
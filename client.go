@@ -3,14 +3,17 @@ package meraki
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"math"
 	"math/rand"
 	"net/http"
 	"net/http/cookiejar"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -26,20 +29,48 @@ const DefaultBackoffMinDelay int = 2
 const DefaultBackoffMaxDelay int = 60
 const DefaultBackoffDelayFactor float64 = 3
 
+// DefaultTransientErrors is the default list of error message patterns of 4xx responses,
+// which are known to be transient, e.g. shortly after an organization has been created.
+var DefaultTransientErrors = []string{
+	"organization not found",
+	"not yet available",
+	"please try again",
+	"temporarily unavailable",
+}
+
 // Client is an HTTP Meraki client.
 // Use meraki.NewClient to initiate a client.
 // This will ensure proper cookie handling and processing of modifiers.
 //
 // Requests are protected from concurrent writing (concurrent DELETE/POST/PUT),
 // across all API paths. Any GET requests, or requests from different clients
-// are not protected against concurrent writing.
+// are not protected against concurrent writing. Use SerializeWrites to allow
+// concurrent writes and SerializeWritesPerNetwork to serialize writes per network.
 type Client struct {
 	// HttpClient is the *http.Client used for API requests
 	HttpClient *http.Client
 	// BaseUrl is the Meraki Dashboard Base API Url, default is https://api.meraki.com/api/v1
 	BaseUrl string
-	// ApiToken is the current API token
+	// MirrorUrl is a base URL GET requests are mirrored to, empty if disabled
+	MirrorUrl string
+	// MirrorAuth sends the authentication headers with mirrored requests, default is false
+	MirrorAuth bool
+	// FailoverUrls are base URLs used in order if the connection to BaseUrl fails
+	FailoverUrls []string
+	// RedirectHosts are hosts the authentication headers are sent to on redirects besides Dashboard API shards
+	RedirectHosts []string
+	// Number of consecutive connection failures triggering a failover
+	FailoverThreshold int
+	// Time after which BaseUrl is probed again after a failover
+	FailbackInterval time.Duration
+	// ApiToken is the API token, use SetToken to change it while requests are in flight
 	ApiToken string
+	// TokenProvider provides the tokens used instead of ApiToken, nil if disabled
+	TokenProvider TokenProvider
+	// AuthScheme is the authentication scheme, AuthSchemeBearer or AuthSchemeApiKey
+	AuthScheme string
+	// ApiKeys are additional API keys of the key pool
+	ApiKeys []string
 	// UserAgent is the HTTP User-Agent string
 	UserAgent string
 	// Maximum number of requests per second
@@ -52,10 +83,124 @@ type Client struct {
 	BackoffMaxDelay int
 	// Backoff delay factor
 	BackoffDelayFactor float64
+	// Maximum time PutAndVerify waits for a change to become visible
+	VerifyTimeout time.Duration
+	// Retry POST requests failed with a 5xx status code or a connection error
+	RetryWrites bool
+	// Maximum number of retries of rate limited (429) requests, UnlimitedRetries if unlimited
+	MaxRateLimitRetries int
+	// HedgeDelay is the delay after which a hedged GET request is sent, 0 if disabled
+	HedgeDelay time.Duration
+	// Number of consecutive failed requests opening the circuit breaker, 0 if disabled
+	CircuitBreakerThreshold int
+	// Time after which an open circuit breaker probes the API again
+	CircuitBreakerCooldown time.Duration
+	// MaxElapsedTime is the maximum total time of a request including retries, 0 if unlimited
+	MaxElapsedTime time.Duration
+	// RetryStatusCodes are the retried status codes besides 429, nil retries all 5xx status codes
+	RetryStatusCodes []int
+	// RetryPolicy decides about retries instead of the exponential backoff, nil if disabled
+	RetryPolicy RetryPolicy
+	// Path patterns of POST requests retried even if RetryWrites is disabled
+	IdempotentPaths []string
+	// Error message patterns of 4xx responses to be retried
+	TransientErrors []string
+	// RequestCosts are the rate limiter costs of expensive endpoints
+	RequestCosts []RequestCost
+	// LogWarnings enables logging of Warning response headers
+	LogWarnings bool
+	// LogPayload is the default of Req.LogPayload for requests of this client
+	LogPayload bool
+	// Compression requests gzip compressed responses
+	Compression bool
+	// CompressRequests compresses request bodies with gzip
+	CompressRequests bool
+	// PreserveNumbers decodes numbers as json.Number in Res.Unmarshal
+	PreserveNumbers bool
+	// DefaultQuery holds query parameters added to every GET request
+	DefaultQuery url.Values
+	// DefaultReqMods are request modifiers applied to every request before the per request modifiers
+	DefaultReqMods []func(*Req)
+	// AuditSink receives a record of every write operation, nil if disabled
+	AuditSink AuditSink
+	// AuditActor is the actor of audit records, default is the masked API token
+	AuditActor string
+	// Recorder records every request in the capture format, nil if disabled
+	Recorder *Recorder
+	// OnRequest are the hooks called before every request attempt
+	OnRequest []func(req *http.Request)
+	// OnResponse are the hooks called after every request attempt
+	OnResponse []func(info ResponseInfo)
+	// Metrics receives request, retry and rate limiter measurements, nil if disabled
+	Metrics MetricsRecorder
+	// Tracer creates spans per API call and request attempt, nil if disabled
+	Tracer Tracer
+	// OnRetry is called before waiting to retry a failed request
+	OnRetry func(attempt int, delay time.Duration, reason error)
+	// OnRetriesExhausted is called when a request failed after all retries
+	OnRetriesExhausted func(req Req, retries RetryStats, err error)
+	// Logger receives a structured log record of every request attempt, nil if disabled
+	Logger *slog.Logger
+	// Anonymizer masks identifiers in log output, nil if disabled
+	Anonymizer *Anonymizer
+	// ValidateIDs validates the IDs of request paths before sending requests
+	ValidateIDs bool
+	// LatencyThresholds are the latency thresholds of path patterns, see SlowRequestThreshold
+	LatencyThresholds []LatencyThreshold
+	// KeyFailureThreshold is the number of consecutive 401 or 403 responses after which a key of the key pool is unhealthy
+	KeyFailureThreshold int
+	// OnKeyUnhealthy is called when a key of the key pool is considered unhealthy
+	OnKeyUnhealthy func(maskedKey string, statusCode int)
+	// OnSlowRequest is called when a request exceeded its latency threshold
+	OnSlowRequest func(req Req, duration time.Duration)
+	// OnHookError is called when a hook or callback panicked
+	OnHookError func(err error)
 	// Rate limiter bucket
 	RateLimiterBucket *ratelimit.Bucket
+	// Maximum number of requests of a burst, 0 if equal to the request rate, see Burst
+	burst int64
+	// Whether the rate limiter bucket is shared with other clients, see SharedRateLimiter
+	sharedRateLimiter bool
+	// SerializeWrites enables the write lock of DELETE/POST/PUT requests, default is true
+	SerializeWrites bool
 	// Mutex to synchronize write operations
 	mutex *sync.Mutex
+	// Write locks per network, organization or device, nil if writes are serialized across all paths
+	writeLocks *writeLocks
+	// Counters exposed by Stats
+	stats *clientStats
+	// Request latencies per path pattern, nil if disabled
+	latency *latencyTracker
+	// Errors of modifiers returned by NewClient
+	errs []error
+	// Adaptive backoff baseline, nil if disabled
+	adaptive *adaptiveBackoff
+	// Adaptive effective request rate, nil if disabled
+	adaptiveRate *adaptiveRate
+	// LRU cache of identity style lookups, nil if disabled
+	lookupCache *lookupCache
+	// LRU cache of all GET responses with a TTL, nil if disabled
+	responseCache *lookupCache
+	// Semaphore limiting the number of mirrored requests in flight
+	mirrorSlots chan struct{}
+	// Semaphore limiting the number of concurrent connections, nil if unlimited
+	connLimiter chan struct{}
+	// State of the base URL failover, nil if not configured
+	failover *failoverState
+	// Dialer configuration of the transport, nil if not configured
+	dial *dialConfig
+	// Cache of GET responses with ETag header, nil if disabled
+	etagCache *etagCache
+	// Shard of the base URL learned from redirects, nil if disabled
+	shard *shardState
+	// Identical GET requests in progress, nil if not configured
+	flights *flightGroup
+	// State of the circuit breaker, nil if not configured
+	breaker *circuitBreaker
+	// Pool of API keys, nil if only ApiToken is used
+	keyPool *keyPool
+	// API token set by SetToken, shared by all copies of the client
+	token *tokenState
 }
 
 // NewClient creates a new Meraki HTTP client.
@@ -65,30 +210,53 @@ type Client struct {
 func NewClient(token string, mods ...func(*Client)) (Client, error) {
 	cookieJar, _ := cookiejar.New(nil)
 	httpClient := http.Client{
-		Timeout: 60 * time.Second,
-		Jar:     cookieJar,
+		Timeout:       60 * time.Second,
+		Jar:           cookieJar,
+		CheckRedirect: redirectPolicy(nil),
 	}
 
 	client := Client{
-		HttpClient:         &httpClient,
-		BaseUrl:            "https://api.meraki.com/api/v1",
-		ApiToken:           token,
-		UserAgent:          "go-meraki netascode",
-		MaxRetries:         DefaultMaxRetries,
-		BackoffMinDelay:    DefaultBackoffMinDelay,
-		BackoffMaxDelay:    DefaultBackoffMaxDelay,
-		BackoffDelayFactor: DefaultBackoffDelayFactor,
-		RateLimiterBucket:  ratelimit.NewBucketWithQuantum(time.Second, int64(10), int64(10)),
-		mutex:              &sync.Mutex{},
+		HttpClient:          &httpClient,
+		BaseUrl:             BaseUrlGlobal,
+		FailoverThreshold:   DefaultFailoverThreshold,
+		FailbackInterval:    DefaultFailbackInterval,
+		ApiToken:            token,
+		AuthScheme:          AuthSchemeBearer,
+		UserAgent:           "go-meraki netascode",
+		MaxRetries:          DefaultMaxRetries,
+		MaxRateLimitRetries: DefaultMaxRetries,
+		BackoffMinDelay:     DefaultBackoffMinDelay,
+		BackoffMaxDelay:     DefaultBackoffMaxDelay,
+		BackoffDelayFactor:  DefaultBackoffDelayFactor,
+		VerifyTimeout:       DefaultVerifyTimeout,
+		RetryWrites:         true,
+		IdempotentPaths:     DefaultIdempotentPaths,
+		TransientErrors:     DefaultTransientErrors,
+		LogWarnings:         true,
+		LogPayload:          true,
+		Compression:         true,
+		SerializeWrites:     true,
+		RateLimiterBucket:   newBucket(10, 0),
+		mutex:               &sync.Mutex{},
+		stats:               &clientStats{},
+		token:               &tokenState{},
+		mirrorSlots:         make(chan struct{}, maxMirrorRequests),
 	}
 
 	for _, mod := range mods {
 		mod(&client)
 	}
-	return client, nil
+	client.keyPool = client.newKeyPool()
+	return client, errors.Join(client.errs...)
+}
+
+// fail records an error of a modifier, which is returned by NewClient.
+func (client *Client) fail(err error) {
+	client.logf("[ERROR] %s", err)
+	client.errs = append(client.errs, err)
 }
 
-// BaseUrl modifies the API base URL. Default value is 'https://api.meraki.com/api/v1'.
+// BaseUrl modifies the API base URL. Default value is 'https://api.meraki.com/api/v1', see Region.
 func BaseUrl(x string) func(*Client) {
 	return func(client *Client) {
 		client.BaseUrl = x
@@ -105,18 +273,118 @@ func UserAgent(x string) func(*Client) {
 // RequestPerSecond modifies the maximum number of requests per second. Default value is 10.
 func RequestPerSecond(x int) func(*Client) {
 	return func(client *Client) {
-		client.RateLimiterBucket = ratelimit.NewBucketWithQuantum(time.Second, int64(x), int64(x))
+		client.RateLimiterBucket = newBucket(int64(x), client.burst)
+		client.sharedRateLimiter = false
+	}
+}
+
+// Burst modifies the maximum number of requests sent at once after the client was idle, which
+// is RequestPerSecond by default. The long-term rate remains RequestPerSecond, e.g. to allow
+// bursts of 20 requests at Meraki's limit of 10 requests per second:
+//
+//	client, _ := NewClient("abc123", RequestPerSecond(10), Burst(20))
+//
+// Burst can not be combined with SharedRateLimiter, create the shared bucket with
+// ratelimit.NewBucketWithRate instead.
+func Burst(x int) func(*Client) {
+	return func(client *Client) {
+		if x < 1 {
+			client.fail(fmt.Errorf("invalid burst %d, must be at least 1", x))
+			return
+		}
+		if client.sharedRateLimiter {
+			client.fail(errors.New("burst can not be combined with a shared rate limiter"))
+			return
+		}
+		client.burst = int64(x)
+		client.RateLimiterBucket = newBucket(int64(math.Round(client.RateLimiterBucket.Rate())), client.burst)
+	}
+}
+
+// newBucket creates a rate limiter bucket with a rate of rps requests per second and a capacity
+// of burst requests, or rps requests if burst is 0.
+func newBucket(rps, burst int64) *ratelimit.Bucket {
+	if burst == 0 || burst == rps {
+		return ratelimit.NewBucketWithQuantum(time.Second, rps, rps)
+	}
+	// Refill continuously, otherwise a quantum of rps tokens exceeds smaller capacities
+	return ratelimit.NewBucketWithRate(float64(rps), burst)
+}
+
+// SerializeWrites modifies whether DELETE/POST/PUT requests of the client are sent one at a time
+// across all API paths. Default value is true. Disabling it allows concurrent writes from
+// multiple goroutines, see SerializeWritesPerNetwork to allow concurrent writes to independent
+// networks only.
+func SerializeWrites(x bool) func(*Client) {
+	return func(client *Client) {
+		client.SerializeWrites = x
+	}
+}
+
+// NewRateLimiter creates a rate limiter bucket allowing x requests per second, which can be
+// shared by multiple clients, see SharedRateLimiter.
+func NewRateLimiter(x int) *ratelimit.Bucket {
+	return ratelimit.NewBucketWithQuantum(time.Second, int64(x), int64(x))
+}
+
+// SharedRateLimiter uses an existing rate limiter bucket instead of a bucket per client, so
+// several clients targeting the same organization, e.g. with different API keys or OAuth
+// scopes, respect a combined request rate, e.g.
+//
+//	limiter := NewRateLimiter(10)
+//	reader, _ := NewClient("abc123", SharedRateLimiter(limiter))
+//	writer, _ := NewClient("def456", SharedRateLimiter(limiter))
+//
+// It replaces the bucket of RequestPerSecond and Burst. The additional keys of ApiKeys get buckets of
+// their own with the same rate.
+func SharedRateLimiter(bucket *ratelimit.Bucket) func(*Client) {
+	return func(client *Client) {
+		client.RateLimiterBucket = bucket
+		client.sharedRateLimiter = true
 	}
 }
 
-// RequestTimeout modifies the HTTP request timeout from the default of 60 seconds.
+// RequestTimeout modifies the total HTTP request timeout in seconds from the default of 60 seconds,
+// including connecting and reading the response body. A value of 0 disables the total timeout,
+// see DialTimeout, TLSHandshakeTimeout and ResponseHeaderTimeout for limiting individual phases.
 func RequestTimeout(x time.Duration) func(*Client) {
 	return func(client *Client) {
 		client.HttpClient.Timeout = x * time.Second
 	}
 }
 
-// MaxRetries modifies the maximum number of retries from the default of 3.
+// Transport replaces the transport of the HTTP client, keeping its cookie jar and timeout,
+// e.g. to wrap it for caching, instrumentation or corporate middleware:
+//
+//	client, _ := NewClient("abc123", Transport(otelhttp.NewTransport(http.DefaultTransport)))
+//
+// The dial and timeout modifiers like IPv4Only or DialTimeout only apply to an *http.Transport,
+// therefore they must be passed before Transport to configure the default transport, which can
+// then be wrapped by a function of the current transport, see WrapTransport.
+func Transport(rt http.RoundTripper) func(*Client) {
+	return func(client *Client) {
+		client.HttpClient.Transport = rt
+		client.dial = nil
+	}
+}
+
+// WrapTransport wraps the current transport of the HTTP client, e.g.
+//
+//	client, _ := NewClient("abc123", IPv4Only(), WrapTransport(func(rt http.RoundTripper) http.RoundTripper {
+//		return otelhttp.NewTransport(rt)
+//	}))
+func WrapTransport(fn func(http.RoundTripper) http.RoundTripper) func(*Client) {
+	return func(client *Client) {
+		rt := client.HttpClient.Transport
+		if rt == nil {
+			rt = http.DefaultTransport
+		}
+		client.HttpClient.Transport = fn(rt)
+	}
+}
+
+// MaxRetries modifies the maximum number of retries from the default of 3. Retries of rate limited
+// (429) requests are limited by MaxRateLimitRetries instead.
 func MaxRetries(x int) func(*Client) {
 	return func(client *Client) {
 		client.MaxRetries = x
@@ -144,12 +412,87 @@ func BackoffDelayFactor(x float64) func(*Client) {
 	}
 }
 
+// TransientErrors modifies the error message patterns of 4xx responses to be retried.
+// Patterns are matched case-insensitively as substrings of the error messages.
+// Default value is DefaultTransientErrors, pass no patterns to disable.
+func TransientErrors(patterns ...string) func(*Client) {
+	return func(client *Client) {
+		client.TransientErrors = patterns
+	}
+}
+
+// LogWarnings enables or disables logging of Warning response headers. Default value is true.
+// Warnings are available as Res.Warnings regardless of this setting.
+func LogWarnings(x bool) func(*Client) {
+	return func(client *Client) {
+		client.LogWarnings = x
+	}
+}
+
+// NoPayloadLogging disables logging of payloads for all requests of the client, e.g.
+//
+//	client, _ := NewClient("abc123", NoPayloadLogging())
+//
+// Individual requests can enable it again with PayloadLogging(true).
+func NoPayloadLogging() func(*Client) {
+	return func(client *Client) {
+		client.LogPayload = false
+	}
+}
+
+// PreserveNumbers makes Res.Unmarshal of responses decode numbers into interface{} values
+// as json.Number instead of float64, see UseNumber. This prevents large IDs and counters
+// from being corrupted in read-modify-write flows using generic maps.
+func PreserveNumbers() func(*Client) {
+	return func(client *Client) {
+		client.PreserveNumbers = true
+	}
+}
+
+// DefaultQuery adds a query parameter to every GET request, e.g.
+//
+//	client, _ := NewClient("abc123", DefaultQuery("perPage", "1000"))
+//
+// Parameters already present in the request path or set by request modifiers take precedence.
+func DefaultQuery(key, value string) func(*Client) {
+	return func(client *Client) {
+		if client.DefaultQuery == nil {
+			client.DefaultQuery = url.Values{}
+		}
+		client.DefaultQuery.Set(key, value)
+	}
+}
+
+// DefaultReqMods adds request modifiers applied to every request, e.g.
+//
+//	client, _ := NewClient("abc123", DefaultReqMods(NoLogPayload, Header("X-Tenant", "a")))
+//
+// They are applied before the modifiers passed to individual requests, which can override them.
+func DefaultReqMods(mods ...func(*Req)) func(*Client) {
+	return func(client *Client) {
+		client.DefaultReqMods = append(client.DefaultReqMods, mods...)
+	}
+}
+
 // NewReq creates a new Req request for this client.
 func (client Client) NewReq(method, uri string, body io.Reader, mods ...func(*Req)) Req {
 	httpReq, _ := http.NewRequest(method, client.BaseUrl+uri, body)
 	req := Req{
 		HttpReq:    httpReq,
-		LogPayload: true,
+		LogPayload: client.LogPayload,
+		Compress:   client.CompressRequests,
+	}
+	if method == "GET" && len(client.DefaultQuery) > 0 {
+		q := httpReq.URL.Query()
+		for k, v := range client.DefaultQuery {
+			if !q.Has(k) {
+				q[k] = v
+			}
+		}
+		httpReq.URL.RawQuery = q.Encode()
+	}
+	for _, mod := range client.DefaultReqMods {
+		mod(&req)
 	}
 	for _, mod := range mods {
 		mod(&req)
@@ -157,7 +500,7 @@ func (client Client) NewReq(method, uri string, body io.Reader, mods ...func(*Re
 	return req
 }
 
-func logJson(body []byte) error {
+func (client *Client) logJson(body []byte) error {
 	if len(body) == 0 {
 		return nil
 	}
@@ -165,7 +508,7 @@ func logJson(body []byte) error {
 	var pretty []byte
 	if body[0] == '{' {
 		m := make(map[string]interface{})
-		err = json.Unmarshal(body, &m)
+		err = Res{Result: gjson.ParseBytes(body)}.Unmarshal(&m, UseNumber)
 		if err != nil {
 			return err
 		}
@@ -176,7 +519,7 @@ func logJson(body []byte) error {
 	}
 	if body[0] == '[' {
 		a := make([]interface{}, 0)
-		err = json.Unmarshal(body, &a)
+		err = Res{Result: gjson.ParseBytes(body)}.Unmarshal(&a, UseNumber)
 		if err != nil {
 			return err
 		}
@@ -186,7 +529,7 @@ func logJson(body []byte) error {
 		}
 	}
 	for _, l := range strings.Split(string(pretty), "\n") {
-		log.Println(l)
+		client.logln(l)
 	}
 	return nil
 }
@@ -197,128 +540,308 @@ func logJson(body []byte) error {
 //	req := client.NewReq("GET", "/organizations", nil)
 //	res, _ := client.Do(req)
 func (client *Client) Do(req Req) (Res, error) {
-	// add token
-	req.HttpReq.Header.Add("Authorization", "Bearer "+client.ApiToken)
+	if err := client.validatePath(req); err != nil {
+		return Res{}, err
+	}
+	client.record(req)
+	if res, ok := client.lookup(req); ok {
+		client.logf("[DEBUG] HTTP Request served from lookup cache: %s, %s", req.HttpReq.Method, req.HttpReq.URL)
+		return res, nil
+	}
+	if res, ok := client.lookupResponse(req); ok {
+		client.logf("[DEBUG] HTTP Request served from response cache: %s, %s", req.HttpReq.Method, req.HttpReq.URL)
+		return res, nil
+	}
+	endTrace := client.startTrace(req)
+	if err := client.allowRequest(); err != nil {
+		client.stats.failures.Add(1)
+		endTrace(0, err)
+		return Res{}, err
+	}
+	start := time.Now()
+	res, statusCode, err := client.doShared(req)
+	endTrace(statusCode, err)
+	client.reportRequest(req, statusCode, err)
+	client.observeLatency(req, time.Since(start))
+	client.audit(req, start, statusCode, err)
+	client.mirror(req)
+	client.storeLookup(req, res, err)
+	client.storeResponse(req, res, err)
+	client.storeETag(req, res, err)
+	if err != nil {
+		client.stats.failures.Add(1)
+	} else {
+		client.adaptiveSuccess()
+		client.adaptiveRateSuccess()
+	}
+	return res, err
+}
+
+// do implements Do and returns the status code of the last response.
+func (client *Client) do(req Req) (Res, int, error) {
 	req.HttpReq.Header.Add("User-Agent", client.UserAgent)
 	req.HttpReq.Header.Add("Content-Type", "application/json")
 	req.HttpReq.Header.Add("Accept", "application/json")
+	client.acceptEncoding(req.HttpReq)
 	// retain the request body across multiple attempts
 	var body []byte
 	if req.HttpReq.Body != nil {
 		body, _ = io.ReadAll(req.HttpReq.Body)
 	}
+	payload := body
+	if req.Compress && len(body) > 0 {
+		payload = gzipBody(body)
+		req.HttpReq.Header.Set("Content-Encoding", "gzip")
+		req.HttpReq.ContentLength = int64(len(payload))
+	}
 
 	var res Res
+	var statusCode int
+	retries := RetryStats{}
+	cost := client.requestCost(req)
+	origUrl := req.HttpReq.URL
+	if client.failover != nil || client.shard != nil {
+		defer func(u *url.URL, host string) { req.HttpReq.URL, req.HttpReq.Host = u, host }(req.HttpReq.URL, req.HttpReq.Host)
+	}
 
+	httpClient := client.HttpClient
+	if req.Timeout > 0 {
+		c := *client.HttpClient
+		c.Timeout = req.Timeout
+		httpClient = &c
+	}
+	cached, conditional := client.conditional(req)
+	ctx := req.HttpReq.Context()
+	deadline := client.retryDeadline()
+	tokenRefreshed := false
+	rateLimited := false
+	// attempts repeated with a refreshed access token or another API key, which are not retries
+	reattempts := 0
 	for attempts := 0; ; attempts++ {
-		client.RateLimiterBucket.Wait(1) // Block until rate limit token available
-
-		if req.HttpReq.Method != "GET" {
-			client.mutex.Lock()
+		if err := ctx.Err(); err != nil {
+			return res, statusCode, err
+		}
+		retried := attempts - reattempts
+		token, bucket := client.apiKey()
+		client.stats.waiting.Add(1)
+		wait, err := client.waitRateLimit(ctx, bucket, cost) // Block until rate limit tokens available
+		client.stats.waiting.Add(-1)
+		if err != nil {
+			return res, statusCode, err
+		}
+		token, err = client.accessToken(ctx, token)
+		if err != nil {
+			return res, statusCode, err
 		}
+		// add token
+		client.authenticate(req.HttpReq, token)
 
-		req.HttpReq.Body = io.NopCloser(bytes.NewBuffer(body))
+		lock := client.lockWrite(req)
+
+		req.HttpReq.Body = io.NopCloser(bytes.NewBuffer(payload))
+		// allow sending the body again on 307 and 308 redirects
+		req.HttpReq.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(payload)), nil
+		}
+		baseUrl := client.activeBaseUrl()
+		targetUrl := client.shardUrl(baseUrl)
+		if client.failover != nil || client.shard != nil {
+			req.HttpReq.URL = client.rebase(origUrl, targetUrl)
+			req.HttpReq.Host = req.HttpReq.URL.Host
+		}
+		client.startAttempt(ctx, req, attempts)
+		client.beforeAttempt(req)
 		if req.LogPayload {
-			log.Println("REQUEST --------------------------")
-			log.Printf("%s %s\n", req.HttpReq.Method, req.HttpReq.URL)
+			client.logln("REQUEST --------------------------")
+			client.logf("%s %s%s\n", req.HttpReq.Method, req.HttpReq.URL, req.labelString())
 			for k, v := range req.HttpReq.Header {
-				if k != "Authorization" {
-					log.Printf("%s: %s\n", k, v)
+				if k != "Authorization" && k != http.CanonicalHeaderKey(AuthSchemeApiKey) {
+					client.logf("%s: %s\n", k, v)
 				} else {
-					log.Println("Authorization: ****")
+					client.logf("%s: ****\n", k)
 				}
 			}
-			log.Println("--------------------------")
+			client.logln("--------------------------")
 
-			err := logJson(body)
+			err := client.logJson(body)
 			if err != nil {
-				log.Printf("failed to log json request: %s\n", err.Error())
+				client.logf("failed to log json request: %s\n", err.Error())
 			}
 
 		} else {
-			log.Printf("[DEBUG] HTTP Request: %s, %s", req.HttpReq.Method, req.HttpReq.URL)
+			client.logf("[DEBUG] HTTP Request: %s, %s%s", req.HttpReq.Method, req.HttpReq.URL, req.labelString())
 		}
 
-		httpRes, err := client.HttpClient.Do(req.HttpReq)
-		if req.HttpReq.Method != "GET" {
-			client.mutex.Unlock()
+		client.acquireConn()
+		client.stats.requests.Add(1)
+		client.stats.inFlight.Add(1)
+		attemptStart := time.Now()
+		httpRes, err := client.send(httpClient, req, bucket, cost, !rateLimited)
+		client.stats.inFlight.Add(-1)
+		client.reportConn(ctx, baseUrl, err)
+		client.reportShard(req.HttpReq, baseUrl, targetUrl, httpRes, err)
+		if lock != nil {
+			lock.Unlock()
 		}
 		if err != nil {
-			if ok := client.Backoff(attempts); !ok {
-				log.Printf("[ERROR] HTTP Connection error occured: %+v", err)
-				log.Printf("[DEBUG] Exit from Do method")
-				return Res{}, err
+			client.releaseConn()
+			client.afterAttempt(req, attempts, nil, time.Since(attemptStart), wait, err)
+			if ok := client.retry(ctx, req, retried, retries, deadline, nil, err, RetryNetwork, err); !ok {
+				if err := ctx.Err(); err != nil {
+					return Res{}, 0, err
+				}
+				client.logf("[ERROR] HTTP Connection error occured: %+v", err)
+				client.logf("[DEBUG] Exit from Do method")
+				client.retriesExhausted(req, RetryNetwork, retries, err)
+				return Res{}, 0, err
 			} else {
-				log.Printf("[ERROR] HTTP Connection failed: %s, retries: %v", err, attempts)
+				client.logf("[ERROR] HTTP Connection failed: %s, retries: %v", err, retried)
+				client.countRetry(req, &retries, RetryNetwork)
 				continue
 			}
 		}
 
 		defer httpRes.Body.Close()
-		bodyBytes, err := io.ReadAll(httpRes.Body)
+		bodyBytes, err := readBody(httpRes)
+		client.releaseConn()
 		if err != nil {
-			if ok := client.Backoff(attempts); !ok {
-				log.Printf("[ERROR] Cannot decode response body: %+v", err)
-				log.Printf("[DEBUG] Exit from Do method")
-				return Res{}, err
+			client.afterAttempt(req, attempts, httpRes, time.Since(attemptStart), wait, err)
+			if ok := client.retry(ctx, req, retried, retries, deadline, httpRes, err, RetryNetwork, err); !ok {
+				if err := ctx.Err(); err != nil {
+					return Res{}, 0, err
+				}
+				client.logf("[ERROR] Cannot decode response body: %+v", err)
+				client.logf("[DEBUG] Exit from Do method")
+				client.retriesExhausted(req, RetryNetwork, retries, err)
+				return Res{}, 0, err
 			} else {
-				log.Printf("[ERROR] Cannot decode response body: %s, retries: %v", err, attempts)
+				client.logf("[ERROR] Cannot decode response body: %s, retries: %v", err, retried)
+				client.countRetry(req, &retries, RetryNetwork)
 				continue
 			}
 		}
-		res = Res{Result: gjson.ParseBytes(bodyBytes), Header: httpRes.Header}
+		// allow retry policies to read the response body
+		httpRes.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+		res = Res{Result: gjson.ParseBytes(bodyBytes), Header: httpRes.Header, Warnings: httpRes.Header.Values("Warning"), useNumber: client.PreserveNumbers}
+		client.learnNames(req, res)
+		client.afterAttempt(req, attempts, httpRes, time.Since(attemptStart), wait, nil)
+		if client.LogWarnings {
+			for _, warning := range res.Warnings {
+				client.logf("[WARNING] HTTP Response warning: %s %s: %s", req.HttpReq.Method, req.HttpReq.URL, warning)
+			}
+		}
 		if req.LogPayload {
-			log.Printf("RESPONSE %d --------------------------\n", httpRes.StatusCode)
-			err := logJson([]byte(res.Raw))
-			log.Println("--------------------------")
+			client.logf("RESPONSE %d --------------------------\n", httpRes.StatusCode)
+			err := client.logJson([]byte(res.Raw))
+			client.logln("--------------------------")
 			if err != nil {
-				log.Printf("failed to log json response: %s\n", err.Error())
+				client.logf("failed to log json response: %s\n", err.Error())
 			}
 		}
 
+		if httpRes.StatusCode == 429 {
+			client.adaptiveRateLimited()
+			rateLimited = true
+		}
+		if !tokenRefreshed && client.invalidateToken(httpRes.StatusCode) {
+			client.logf("[WARNING] HTTP Request failed: StatusCode %v, retrying with refreshed access token", httpRes.StatusCode)
+			tokenRefreshed = true
+			reattempts++
+			continue
+		}
+		if client.reportKey(token, httpRes.StatusCode, res) {
+			client.logf("[WARNING] HTTP Request failed with API key %s: StatusCode %v, retrying with next key", maskToken(token), httpRes.StatusCode)
+			reattempts++
+			continue
+		}
+
+		if httpRes.StatusCode == 304 && conditional {
+			client.logf("[DEBUG] Exit from Do method")
+			res = client.notModified(req, cached)
+			statusCode = httpRes.StatusCode
+			break
+		}
 		if httpRes.StatusCode >= 200 && httpRes.StatusCode <= 299 {
-			log.Printf("[DEBUG] Exit from Do method")
+			client.logf("[DEBUG] Exit from Do method")
+			statusCode = httpRes.StatusCode
 			break
 		} else {
-			if ok := client.Backoff(attempts); !ok {
-				log.Printf("[ERROR] HTTP Request failed: StatusCode %v", httpRes.StatusCode)
-				log.Printf("[DEBUG] Exit from Do method")
-				return res, fmt.Errorf("HTTP Request failed: StatusCode %v", httpRes.StatusCode)
-			} else if httpRes.StatusCode == 429 {
-				retryAfter := httpRes.Header.Get("Retry-After")
-				retryAfterDuration := time.Duration(0)
-				if retryAfter == "0" {
-					retryAfterDuration = time.Second
-				} else if retryAfter != "" {
-					retryAfterDuration, _ = time.ParseDuration(retryAfter + "s")
-				} else {
-					retryAfterDuration = 15 * time.Second
+			cause := client.retryCause(httpRes.StatusCode, res)
+			msg := fmt.Sprintf("HTTP Request failed: StatusCode %v", httpRes.StatusCode)
+			if res.Get("errors").Exists() && len(res.Get("errors").Array()) > 0 {
+				msg += fmt.Sprintf(", JSON error: %s", res.Get("errors").String())
+			}
+			err := newApiError(req, httpRes.StatusCode, res, msg)
+			// Responses which are not retryable fail immediately without a backoff, unless a retry policy decides
+			if cause == "" && client.RetryPolicy == nil {
+				client.logf("[ERROR] HTTP Request failed: StatusCode %v", httpRes.StatusCode)
+				if res.Get("errors").Exists() && len(res.Get("errors").Array()) > 0 {
+					client.logf("[ERROR] JSON error: %s", res.Get("errors").String())
+				}
+				client.logf("[DEBUG] Exit from Do method")
+				return res, httpRes.StatusCode, err
+			}
+			if ok := client.retry(ctx, req, retried, retries, deadline, httpRes, nil, cause, err); !ok {
+				if err := ctx.Err(); err != nil {
+					return res, httpRes.StatusCode, err
 				}
-				log.Printf("[WARNING] HTTP Request rate limited, waiting %v seconds, Retries: %v", retryAfterDuration.Seconds(), attempts)
-				time.Sleep(retryAfterDuration)
+				client.logf("[ERROR] HTTP Request failed: StatusCode %v", httpRes.StatusCode)
+				client.logf("[DEBUG] Exit from Do method")
+				client.retriesExhausted(req, cause, retries, err)
+				return res, httpRes.StatusCode, err
+			} else if client.RetryPolicy != nil {
+				client.logf("[WARNING] HTTP Request failed: StatusCode %v, retried by retry policy, Retries: %v", httpRes.StatusCode, retried)
+				client.countRetry(req, &retries, cause)
 				continue
-			} else if httpRes.StatusCode >= 500 && httpRes.StatusCode <= 599 {
-				log.Printf("[ERROR] HTTP Request failed: StatusCode %v, Retries: %v", httpRes.StatusCode, attempts)
+			} else if httpRes.StatusCode == 429 {
+				client.logf("[WARNING] HTTP Request rate limited, Retries: %v", retried)
+				client.countRetry(req, &retries, RetryRateLimited)
+				continue
+			} else if cause == RetryServerError {
+				client.logf("[ERROR] HTTP Request failed: StatusCode %v, Retries: %v", httpRes.StatusCode, retried)
+				client.countRetry(req, &retries, RetryServerError)
+				continue
+			} else if cause == RetryTransient {
+				client.logf("[WARNING] HTTP Request failed with transient error: StatusCode %v, JSON error: %s, Retries: %v", httpRes.StatusCode, res.Get("errors").String(), retried)
+				client.countRetry(req, &retries, RetryTransient)
 				continue
-			} else {
-				log.Printf("[ERROR] HTTP Request failed: StatusCode %v", httpRes.StatusCode)
-				log.Printf("[DEBUG] Exit from Do method")
-				if res.Get("errors").Exists() && len(res.Get("errors").Array()) > 0 {
-					log.Printf("[ERROR] JSON error: %s", res.Get("errors").String())
-					return res, fmt.Errorf("HTTP Request failed: StatusCode %v, JSON error: %s", httpRes.StatusCode, res.Get("errors").String())
-				} else {
-					return res, fmt.Errorf("HTTP Request failed: StatusCode %v", httpRes.StatusCode)
-				}
 			}
 		}
 	}
 
 	// Return JSON error message if present
 	if res.Get("errors").Exists() && len(res.Get("errors").Array()) > 0 {
-		log.Printf("[ERROR] JSON error: %s", res.Get("errors").String())
-		return res, fmt.Errorf("JSON error: %s", res.Get("errors").String())
+		client.logf("[ERROR] JSON error: %s", res.Get("errors").String())
+		return res, statusCode, newApiError(req, statusCode, res, fmt.Sprintf("JSON error: %s", res.Get("errors").String()))
+	}
+	return res, statusCode, nil
+}
+
+// isTransientError reports whether a response contains an error message matching one of the TransientErrors patterns.
+func (client *Client) isTransientError(res Res) bool {
+	for _, e := range res.Get("errors").Array() {
+		msg := strings.ToLower(e.String())
+		for _, pattern := range client.TransientErrors {
+			if strings.Contains(msg, strings.ToLower(pattern)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// acquireConn blocks until a connection slot is available.
+func (client *Client) acquireConn() {
+	if client.connLimiter != nil {
+		client.connLimiter <- struct{}{}
+	}
+}
+
+// releaseConn releases a connection slot acquired with acquireConn.
+func (client *Client) releaseConn() {
+	if client.connLimiter != nil {
+		<-client.connLimiter
 	}
-	return res, nil
 }
 
 // Get makes a GET request and returns a GJSON result. It handles pagination transparently.
@@ -326,11 +849,18 @@ func (client *Client) Do(req Req) (Res, error) {
 func (client *Client) Get(path string, mods ...func(*Req)) (Res, error) {
 	r := ""
 	hasItems := false
+	progress := Progress{}
+	var warnings []string
+	pages := client.newPager(mods)
+	defer pages.close()
 	for {
-		response, err := client.get(path, mods...)
+		req, response, err := pages.fetch(path)
 		if err != nil {
 			return response, err
 		}
+		progress.update(response)
+		client.progress(req, progress)
+		warnings = append(warnings, response.Warnings...)
 
 		if response.Header.Get("Link") == "" {
 			return response, nil
@@ -338,7 +868,7 @@ func (client *Client) Get(path string, mods ...func(*Req)) (Res, error) {
 
 		if response.Get("items").Exists() {
 			hasItems = true
-			response = Res{Result: response.Get("items"), Header: response.Header}
+			response = Res{Result: response.Get("items"), Header: response.Header, Warnings: response.Warnings, useNumber: response.useNumber}
 		}
 
 		for _, item := range response.Array() {
@@ -349,31 +879,45 @@ func (client *Client) Get(path string, mods ...func(*Req)) (Res, error) {
 			}
 		}
 
-		links := strings.Split(response.Header.Get("Link"), ",")
-		foundNext := false
-		for _, link := range links {
-			if strings.Contains(link, "rel=\"next\"") {
-				foundNext = true
-				path = strings.Trim(strings.Split(strings.Split(link, ";")[0], "<")[1], ">")
-				s := strings.Split(path, client.BaseUrl)
-				if len(s) > 1 {
-					path = s[1]
-				} else {
-					return response, fmt.Errorf("Invalid 'next' URL received in 'Link' header: %s", path)
-				}
-			}
+		next, foundNext, err := client.nextPage(response.Header)
+		if err != nil {
+			return response, err
 		}
+		path = next
 
 		if !foundNext {
-			return Res{Result: gjson.Parse(gjson.Get(r, "response").Raw)}, nil
+			return Res{Result: gjson.Parse(gjson.Get(r, "response").Raw), Warnings: warnings, useNumber: client.PreserveNumbers}, nil
 		}
 	}
 }
 
-// get is like Get but without pagination.
-func (client *Client) get(path string, mods ...func(*Req)) (Res, error) {
-	req := client.NewReq("GET", path, nil, mods...)
-	return client.Do(req)
+// nextPage returns the path of the next page from the 'Link' header of a paginated response.
+func (client *Client) nextPage(header http.Header) (string, bool, error) {
+	return client.linkPage(header, "next")
+}
+
+// linkPage returns the path of a relation, e.g. next or prev, from the 'Link' header of a paginated response.
+func (client *Client) linkPage(header http.Header, rel string) (string, bool, error) {
+	for _, link := range strings.Split(header.Get("Link"), ",") {
+		if strings.Contains(link, "rel=\""+rel+"\"") {
+			path := strings.Trim(strings.Split(strings.Split(link, ";")[0], "<")[1], ">")
+			baseUrls := client.baseUrls()
+			if shard := client.Shard(); shard != "" {
+				baseUrls = append(baseUrls, shard)
+			}
+			for _, baseUrl := range baseUrls {
+				s := strings.Split(path, baseUrl)
+				if len(s) > 1 {
+					return s[1], true, nil
+				}
+			}
+			if p, ok := client.shardPath(path); ok {
+				return p, true, nil
+			}
+			return "", false, fmt.Errorf("Invalid '%s' URL received in 'Link' header: %s", rel, path)
+		}
+	}
+	return "", false, nil
 }
 
 // Delete makes a DELETE request.
@@ -396,26 +940,111 @@ func (client *Client) Put(path, data string, mods ...func(*Req)) (Res, error) {
 	return client.Do(req)
 }
 
+// GetContext is like Get, but cancels the request including all pages when ctx is done.
+func (client *Client) GetContext(ctx context.Context, path string, mods ...func(*Req)) (Res, error) {
+	return client.Get(path, withContext(ctx, mods)...)
+}
+
+// DeleteContext is like Delete, but cancels the request when ctx is done.
+func (client *Client) DeleteContext(ctx context.Context, path string, mods ...func(*Req)) (Res, error) {
+	return client.Delete(path, withContext(ctx, mods)...)
+}
+
+// PostContext is like Post, but cancels the request when ctx is done.
+func (client *Client) PostContext(ctx context.Context, path, data string, mods ...func(*Req)) (Res, error) {
+	return client.Post(path, data, withContext(ctx, mods)...)
+}
+
+// PutContext is like Put, but cancels the request when ctx is done.
+func (client *Client) PutContext(ctx context.Context, path, data string, mods ...func(*Req)) (Res, error) {
+	return client.Put(path, data, withContext(ctx, mods)...)
+}
+
+// DoContext is like Do, but cancels the request when ctx is done.
+func (client *Client) DoContext(ctx context.Context, req Req) (Res, error) {
+	req.HttpReq = req.HttpReq.WithContext(ctx)
+	return client.Do(req)
+}
+
+// withContext prepends the Context modifier to request modifiers.
+func withContext(ctx context.Context, mods []func(*Req)) []func(*Req) {
+	return append([]func(*Req){Context(ctx)}, mods...)
+}
+
+// sleep waits for a duration or until ctx is done and returns the context error in the latter case.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// waitRateLimit takes rate limiter tokens for a request attempt and blocks until they are
+// available. It returns the time spent waiting, which is accounted in the client stats.
+func (client *Client) waitRateLimit(ctx context.Context, bucket *ratelimit.Bucket, cost int64) (time.Duration, error) {
+	delay := bucket.Take(client.adaptiveCost(cost))
+	if delay <= 0 {
+		client.observeRateLimitWait(ctx, 0)
+		return 0, ctx.Err()
+	}
+	start := time.Now()
+	err := sleep(ctx, delay)
+	wait := time.Since(start)
+	client.stats.rateLimitWaits.Add(1)
+	client.stats.rateLimitWait.Add(int64(wait))
+	client.observeRateLimitWait(ctx, wait)
+	client.logf("[DEBUG] HTTP Request waited %v for rate limiter tokens", wait.Round(time.Millisecond))
+	return wait, err
+}
+
 // Backoff waits following an exponential backoff algorithm
 func (client *Client) Backoff(attempts int) bool {
-	log.Printf("[DEBUG] Beginning backoff method: attempt %v of %v", attempts, client.MaxRetries)
-	if attempts >= client.MaxRetries {
-		log.Printf("[DEBUG] Exit from backoff method with return value false")
+	return client.backoff(context.Background(), attempts)
+}
+
+// backoff implements Backoff and returns false without waiting further if ctx is done.
+func (client *Client) backoff(ctx context.Context, attempts int) bool {
+	backoffDuration, ok := client.backoffDelay(attempts, client.MaxRetries)
+	if !ok {
 		return false
 	}
+	client.logf("[TRACE] Starting sleeping for %v", backoffDuration.Round(time.Second))
+	if err := sleep(ctx, backoffDuration); err != nil {
+		client.logf("[DEBUG] Exit from backoff method with return value false: %s", err)
+		return false
+	}
+	client.logf("[DEBUG] Exit from backoff method with return value true")
+	return true
+}
+
+// backoffDelay returns the delay before a retry or false if the maximum number of retries is
+// reached, where a negative maximum is unlimited.
+func (client *Client) backoffDelay(attempts, maxRetries int) (time.Duration, bool) {
+	client.logf("[DEBUG] Beginning backoff method: attempt %v of %v", attempts, maxRetries)
+	if maxRetries >= 0 && attempts >= maxRetries {
+		client.logf("[DEBUG] Exit from backoff method with return value false")
+		return 0, false
+	}
 
 	minDelay := time.Duration(client.BackoffMinDelay) * time.Second
 	maxDelay := time.Duration(client.BackoffMaxDelay) * time.Second
 
-	min := float64(minDelay)
+	min := float64(minDelay) * client.backoffScale()
+	client.adaptiveRetry()
+	if min > float64(maxDelay) {
+		min = float64(maxDelay)
+	}
 	backoff := min * math.Pow(client.BackoffDelayFactor, float64(attempts))
 	if backoff > float64(maxDelay) {
 		backoff = float64(maxDelay)
 	}
 	backoff = (rand.Float64()/2+0.5)*(backoff-min) + min
-	backoffDuration := time.Duration(backoff)
-	log.Printf("[TRACE] Starting sleeping for %v", backoffDuration.Round(time.Second))
-	time.Sleep(backoffDuration)
-	log.Printf("[DEBUG] Exit from backoff method with return value true")
-	return true
+	return time.Duration(backoff), true
 }
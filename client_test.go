@@ -4,6 +4,9 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -200,3 +203,165 @@ func TestClientPut(t *testing.T) {
 	_, err = client.Put("/url", "{}")
 	assert.Error(t, err)
 }
+
+// TestClientTransientErrors tests the retry of transient 4xx errors.
+func TestClientTransientErrors(t *testing.T) {
+	defer gock.Off()
+	client, _ := NewClient("abc123", MaxRetries(1), BackoffMinDelay(0), BackoffMaxDelay(0))
+	gock.InterceptClient(client.HttpClient)
+
+	// Transient error is retried
+	gock.New(client.BaseUrl).Get("/url").Reply(404).BodyString(`{"errors":["Organization not found"]}`)
+	gock.New(client.BaseUrl).Get("/url").Reply(200)
+	_, err := client.Get("/url")
+	assert.NoError(t, err)
+
+	// Other errors are not retried
+	gock.New(client.BaseUrl).Get("/url").Reply(400).BodyString(`{"errors":["Invalid name"]}`)
+	gock.New(client.BaseUrl).Get("/url").Reply(200)
+	_, err = client.Get("/url")
+	assert.Error(t, err)
+	gock.Off()
+
+	// Disabled
+	client.TransientErrors = nil
+	gock.New(client.BaseUrl).Get("/url").Reply(404).BodyString(`{"errors":["Organization not found"]}`)
+	_, err = client.Get("/url")
+	assert.Error(t, err)
+}
+
+// TestDefaultQuery tests the DefaultQuery modifier.
+func TestDefaultQuery(t *testing.T) {
+	client, _ := NewClient("abc123", DefaultQuery("perPage", "1000"), DefaultQuery("a", "1"))
+	req := client.NewReq("GET", "/url", nil)
+	assert.Equal(t, "a=1&perPage=1000", req.HttpReq.URL.RawQuery)
+	req = client.NewReq("GET", "/url?perPage=10", nil, Query("a", "2"))
+	assert.Equal(t, "a=2&perPage=10", req.HttpReq.URL.RawQuery)
+	req = client.NewReq("POST", "/url", nil)
+	assert.Equal(t, "", req.HttpReq.URL.RawQuery)
+}
+
+// TestDefaultReqMods tests the DefaultReqMods modifier.
+func TestDefaultReqMods(t *testing.T) {
+	client, _ := NewClient("abc123", DefaultReqMods(NoLogPayload, Header("X-Tenant", "a")))
+	req := client.NewReq("GET", "/url", nil)
+	assert.False(t, req.LogPayload)
+	assert.Equal(t, "a", req.HttpReq.Header.Get("X-Tenant"))
+	req = client.NewReq("GET", "/url", nil, Header("X-Tenant", "b"))
+	assert.Equal(t, "b", req.HttpReq.Header.Get("X-Tenant"))
+}
+
+// TestNoPayloadLogging tests the NoPayloadLogging and PayloadLogging modifiers.
+func TestNoPayloadLogging(t *testing.T) {
+	client, _ := NewClient("abc123")
+	assert.True(t, client.NewReq("GET", "/url", nil).LogPayload)
+	client, _ = NewClient("abc123", NoPayloadLogging())
+	assert.False(t, client.NewReq("GET", "/url", nil).LogPayload)
+	assert.True(t, client.NewReq("GET", "/url", nil, PayloadLogging(true)).LogPayload)
+}
+
+type countingTransport struct {
+	rt    http.RoundTripper
+	count int
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.count++
+	return t.rt.RoundTrip(req)
+}
+
+// TestTransport tests the Transport and WrapTransport modifiers.
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	rt := &countingTransport{rt: http.DefaultTransport}
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), RequestTimeout(10), Transport(rt))
+	_, err := client.Get("/url")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, rt.count)
+	assert.NotNil(t, client.HttpClient.Jar)
+	assert.Equal(t, 10*time.Second, client.HttpClient.Timeout)
+
+	var wrapped *countingTransport
+	client, _ = NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), IPv4Only(), WrapTransport(func(rt http.RoundTripper) http.RoundTripper {
+		wrapped = &countingTransport{rt: rt}
+		return wrapped
+	}))
+	_, err = client.Get("/url")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, wrapped.count)
+	assert.Equal(t, "tcp4", client.dial.network)
+}
+
+// TestSharedRateLimiter tests the SharedRateLimiter modifier.
+func TestSharedRateLimiter(t *testing.T) {
+	defer gock.Off()
+	limiter := NewRateLimiter(5)
+	reader, _ := NewClient("abc123", MaxRetries(0), SharedRateLimiter(limiter))
+	writer, _ := NewClient("def456", MaxRetries(0), SharedRateLimiter(limiter))
+	gock.InterceptClient(reader.HttpClient)
+	gock.InterceptClient(writer.HttpClient)
+
+	gock.New(reader.BaseUrl).Get("/url").Times(2).Reply(200)
+	reader.Get("/url")
+	writer.Get("/url")
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, int64(3), reader.Stats().AvailableTokens)
+	assert.Equal(t, int64(3), writer.Stats().AvailableTokens)
+	assert.Equal(t, float64(5), writer.Stats().RequestPerSecond)
+}
+
+// TestBurst tests the Burst modifier.
+func TestBurst(t *testing.T) {
+	client, err := NewClient("abc123", Burst(20), RequestPerSecond(5))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20), client.RateLimiterBucket.Capacity())
+	assert.Equal(t, float64(5), client.RateLimiterBucket.Rate())
+	assert.Equal(t, int64(20), client.Stats().AvailableTokens)
+
+	client, _ = NewClient("abc123", RequestPerSecond(10), Burst(1), ApiKeys("def456"))
+	assert.Equal(t, int64(1), client.RateLimiterBucket.Capacity())
+	assert.Equal(t, float64(10), client.RateLimiterBucket.Rate())
+	assert.Equal(t, int64(1), client.keyPool.keys[1].bucket.Capacity())
+	assert.Equal(t, float64(10), client.keyPool.keys[1].bucket.Rate())
+
+	_, err = NewClient("abc123", Burst(0))
+	assert.Error(t, err)
+	_, err = NewClient("abc123", SharedRateLimiter(NewRateLimiter(10)), Burst(20))
+	assert.Error(t, err)
+}
+
+// TestSerializeWrites tests the SerializeWrites modifier.
+func TestSerializeWrites(t *testing.T) {
+	var active, peak atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := active.Add(1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		time.Sleep(20 * time.Millisecond)
+		active.Add(-1)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	writes := func(client Client) int64 {
+		peak.Store(0)
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				client.Post("/networks", `{}`)
+			}()
+		}
+		wg.Wait()
+		return peak.Load()
+	}
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), Transport(server.Client().Transport))
+	assert.Equal(t, int64(1), writes(client))
+	client, _ = NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), Transport(server.Client().Transport), SerializeWrites(false))
+	assert.Greater(t, writes(client), int64(1))
+}
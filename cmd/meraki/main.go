@@ -0,0 +1,126 @@
+// Command meraki is a small debugging CLI for the Meraki Dashboard API built on go-meraki.
+//
+// It runs arbitrary requests with the pagination, retry and log redaction behavior
+// of the library, e.g.
+//
+//	export MERAKI_DASHBOARD_API_KEY=abc123
+//	meraki GET /organizations
+//	meraki PUT /networks/N_123 '{"name":"New"}'
+//	meraki POST /organizations/123/networks @network.json
+//	meraki -v GET /organizations/123/devices
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/netascode/go-meraki"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("meraki", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: meraki [flags] METHOD PATH [BODY|@FILE|-]")
+		flags.PrintDefaults()
+	}
+	token := flags.String("token", os.Getenv("MERAKI_DASHBOARD_API_KEY"), "API token, defaults to $MERAKI_DASHBOARD_API_KEY")
+	baseUrl := flags.String("base-url", "https://api.meraki.com/api/v1", "API base URL")
+	retries := flags.Int("retries", meraki.DefaultMaxRetries, "maximum number of retries")
+	rps := flags.Int("rps", 10, "maximum number of requests per second")
+	timeout := flags.Int("timeout", 60, "request timeout in seconds")
+	output := flags.String("output", "json", "output format, json or yaml")
+	verbose := flags.Bool("v", false, "log requests and responses to stderr")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() < 2 || flags.NArg() > 3 {
+		flags.Usage()
+		return 2
+	}
+	if *token == "" {
+		fmt.Fprintln(stderr, "Error: no API token, use -token or $MERAKI_DASHBOARD_API_KEY")
+		return 2
+	}
+
+	log.SetOutput(io.Discard)
+	if *verbose {
+		log.SetOutput(stderr)
+	}
+
+	method, path := strings.ToUpper(flags.Arg(0)), flags.Arg(1)
+	body := ""
+	if flags.NArg() == 3 {
+		b, err := readBody(flags.Arg(2), stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %s\n", err)
+			return 1
+		}
+		body = b
+	}
+
+	client, _ := meraki.NewClient(*token,
+		meraki.BaseUrl(*baseUrl),
+		meraki.MaxRetries(*retries),
+		meraki.RequestPerSecond(*rps),
+		meraki.RequestTimeout(time.Duration(*timeout)),
+		meraki.UserAgent("go-meraki cli"),
+	)
+
+	var res meraki.Res
+	var err error
+	switch method {
+	case "GET":
+		res, err = client.Get(path)
+	case "DELETE":
+		res, err = client.Delete(path)
+	case "POST":
+		res, err = client.Post(path, body)
+	case "PUT":
+		res, err = client.Put(path, body)
+	default:
+		fmt.Fprintf(stderr, "Error: unsupported method %s\n", method)
+		return 2
+	}
+
+	if res.Exists() {
+		if *output == "yaml" {
+			out, yamlErr := res.ToYAML()
+			if yamlErr != nil {
+				fmt.Fprintf(stderr, "Error: %s\n", yamlErr)
+				return 1
+			}
+			fmt.Fprint(stdout, out)
+		} else {
+			fmt.Fprintln(stdout, res.Get("@pretty").String())
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+// readBody returns the request body from an argument, a file (@file) or stdin (-).
+func readBody(arg string, stdin io.Reader) (string, error) {
+	switch {
+	case arg == "-":
+		b, err := io.ReadAll(stdin)
+		return string(b), err
+	case strings.HasPrefix(arg, "@"):
+		b, err := os.ReadFile(arg[1:])
+		return string(b), err
+	default:
+		return arg, nil
+	}
+}
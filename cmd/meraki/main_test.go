@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRun tests the run function.
+func TestRun(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer abc123", r.Header.Get("Authorization"))
+		body, _ := io.ReadAll(r.Body)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /organizations":
+			w.Write([]byte(`[{"id":"1","name":"A"}]`))
+		case "PUT /networks/N_1":
+			w.Write(body)
+		default:
+			w.WriteHeader(404)
+			w.Write([]byte(`{"errors":["Not found"]}`))
+		}
+	}))
+	defer server.Close()
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-token", "abc123", "-base-url", server.URL, "get", "/organizations"}, nil, &stdout, &stderr)
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout.String(), `"name": "A"`)
+
+	stdout.Reset()
+	code = run([]string{"-token", "abc123", "-base-url", server.URL, "-output", "yaml", "PUT", "/networks/N_1", "-"}, strings.NewReader(`{"name":"B"}`), &stdout, &stderr)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "name: B\n", stdout.String())
+
+	stderr.Reset()
+	code = run([]string{"-token", "abc123", "-base-url", server.URL, "-retries", "0", "GET", "/missing"}, nil, &stdout, &stderr)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr.String(), "StatusCode 404")
+
+	code = run([]string{"GET"}, nil, &stdout, &stderr)
+	assert.Equal(t, 2, code)
+}
@@ -0,0 +1,86 @@
+package meraki
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// ConflictError is returned by CompareAndPut if the resource changed while the update was computed.
+type ConflictError struct {
+	// Path is the path of the resource
+	Path string
+	// Before is the resource the update was computed from
+	Before Res
+	// After is the current resource
+	After Res
+	// Changes are the differences between Before and After
+	Changes []DiffEntry
+}
+
+// Error implements the error interface.
+func (e *ConflictError) Error() string {
+	changes := make([]string, 0, len(e.Changes))
+	for _, c := range e.Changes {
+		changes = append(changes, c.Path)
+	}
+	return fmt.Sprintf("Conflict: %s changed concurrently (%s)", e.Path, strings.Join(changes, ", "))
+}
+
+// Hash returns a hash of the normalized result or of the given fields only, see Normalize.
+func (res Res) Hash(fields ...string) string {
+	sum := sha256.Sum256([]byte(Normalize(res.selectFields(fields...)).Raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// selectFields returns a document containing only the given fields, or the result itself if none are given.
+func (res Res) selectFields(fields ...string) Res {
+	if len(fields) == 0 {
+		return res
+	}
+	body := Body{}
+	for _, f := range fields {
+		if v := res.Get(f); v.Exists() {
+			body = body.SetRaw(f, v.Raw)
+		}
+	}
+	return body.Res()
+}
+
+// CompareAndPut performs an optimistic concurrency controlled update of a resource.
+// It fetches the resource and passes it to update, which returns the PUT body. Before
+// the PUT is made, the resource is fetched again and compared with the first version,
+// either as a whole or only the given fields. If it changed, a *ConflictError is
+// returned and no update is made, e.g.
+//
+//	res, err := client.CompareAndPut("/networks/N_123", nil, func(current Res) (string, error) {
+//		return current.Set("name", "New").BodyForPut(), nil
+//	})
+func (client *Client) CompareAndPut(path string, fields []string, update func(current Res) (string, error), mods ...func(*Req)) (Res, error) {
+	before, err := client.Get(path, append(mods, NoCache)...)
+	if err != nil {
+		return before, err
+	}
+	body, err := update(before)
+	if err != nil {
+		return Res{}, err
+	}
+
+	lock := client.writeLock(path)
+	lock.Lock()
+	defer lock.Unlock()
+	after, err := client.Get(path, append(mods, NoCache)...)
+	if err != nil {
+		return after, err
+	}
+	if before.Hash(fields...) != after.Hash(fields...) {
+		return after, &ConflictError{
+			Path:    path,
+			Before:  before,
+			After:   after,
+			Changes: Diff(before.selectFields(fields...), after.selectFields(fields...)),
+		}
+	}
+	return client.Put(path, body, append(mods, writeLocked)...)
+}
@@ -0,0 +1,51 @@
+package meraki
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestClientCompareAndPut tests the Client::CompareAndPut method.
+func TestClientCompareAndPut(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+	update := func(current Res) (string, error) {
+		return current.Set("name", "new").BodyForPut(), nil
+	}
+
+	// Unchanged
+	gock.New(client.BaseUrl).Get("/networks/N_1").Times(2).Reply(200).BodyString(`{"name":"old","tags":["a"]}`)
+	gock.New(client.BaseUrl).Put("/networks/N_1").JSON(`{"name":"new","tags":["a"]}`).Reply(200).BodyString(`{"name":"new"}`)
+	res, err := client.CompareAndPut("/networks/N_1", nil, update)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", res.Get("name").String())
+
+	// Conflict
+	gock.New(client.BaseUrl).Get("/networks/N_1").Reply(200).BodyString(`{"name":"old","tags":["a"]}`)
+	gock.New(client.BaseUrl).Get("/networks/N_1").Reply(200).BodyString(`{"name":"other","tags":["a"]}`)
+	_, err = client.CompareAndPut("/networks/N_1", nil, update)
+	var conflict *ConflictError
+	assert.True(t, errors.As(err, &conflict))
+	assert.Equal(t, "name", conflict.Changes[0].Path)
+
+	// Changes outside of the compared fields are ignored
+	gock.New(client.BaseUrl).Get("/networks/N_1").Reply(200).BodyString(`{"name":"old","tags":["a"]}`)
+	gock.New(client.BaseUrl).Get("/networks/N_1").Reply(200).BodyString(`{"name":"old","tags":["b"]}`)
+	gock.New(client.BaseUrl).Put("/networks/N_1").Reply(200)
+	_, err = client.CompareAndPut("/networks/N_1", []string{"name"}, update)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+// TestResHash tests the Res::Hash method.
+func TestResHash(t *testing.T) {
+	a := Body{Str: `{"name":"a","vlan":10}`}.Res()
+	b := Body{Str: `{"vlan":"10","name":"a"}`}.Res()
+	c := Body{Str: `{"vlan":20,"name":"a"}`}.Res()
+	assert.Equal(t, a.Hash(), b.Hash())
+	assert.NotEqual(t, a.Hash(), c.Hash())
+	assert.Equal(t, a.Hash("name"), c.Hash("name"))
+}
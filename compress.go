@@ -0,0 +1,68 @@
+package meraki
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Compression enables or disables gzip compression of responses. Default value is true.
+// Requests are sent with an Accept-Encoding: gzip header and compressed responses are
+// decompressed transparently, independent of the transport, see Transport. Disabled
+// compression requests uncompressed responses with Accept-Encoding: identity.
+func Compression(x bool) func(*Client) {
+	return func(client *Client) {
+		client.Compression = x
+	}
+}
+
+// CompressRequests compresses the bodies of all requests with gzip, e.g. for bulk changes of
+// hundreds of switch ports. Use CompressBody to compress the body of individual requests or
+// BodyCompression(false) to send individual bodies uncompressed.
+func CompressRequests() func(*Client) {
+	return func(client *Client) {
+		client.CompressRequests = true
+	}
+}
+
+// acceptEncoding sets the Accept-Encoding header of a request, unless it was set by a request modifier.
+func (client *Client) acceptEncoding(req *http.Request) {
+	if req.Header.Get("Accept-Encoding") != "" {
+		return
+	}
+	if client.Compression {
+		req.Header.Set("Accept-Encoding", "gzip")
+	} else {
+		req.Header.Set("Accept-Encoding", "identity")
+	}
+}
+
+// readBody reads the body of a response and decompresses gzip encoded bodies.
+func readBody(res *http.Response) ([]byte, error) {
+	if !strings.EqualFold(res.Header.Get("Content-Encoding"), "gzip") {
+		return io.ReadAll(res.Body)
+	}
+	reader, err := gzip.NewReader(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	res.Header.Del("Content-Encoding")
+	res.Header.Del("Content-Length")
+	return data, nil
+}
+
+// gzipBody compresses a request body.
+func gzipBody(body []byte) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	gz.Write(body)
+	gz.Close()
+	return buf.Bytes()
+}
@@ -0,0 +1,74 @@
+package meraki
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCompression tests the Compression modifier.
+func TestCompression(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Accept-Encoding", r.Header.Get("Accept-Encoding"))
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			w.Write([]byte(`{"compressed":false}`))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(`{"compressed":true}`))
+		gz.Close()
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), Transport(server.Client().Transport))
+	res, err := client.Get("/url")
+	assert.NoError(t, err)
+	assert.True(t, res.Get("compressed").Bool())
+	assert.Equal(t, "", res.Header.Get("Content-Encoding"))
+
+	client, _ = NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), Transport(server.Client().Transport), Compression(false))
+	res, err = client.Get("/url")
+	assert.NoError(t, err)
+	assert.False(t, res.Get("compressed").Bool())
+	assert.Equal(t, "identity", res.Header.Get("X-Accept-Encoding"))
+}
+
+// TestCompressRequests tests the CompressRequests modifier and the CompressBody request modifier.
+func TestCompressRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body []byte
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			if err != nil {
+				w.WriteHeader(400)
+				return
+			}
+			body, _ = io.ReadAll(gz)
+		} else {
+			body, _ = io.ReadAll(r.Body)
+		}
+		fmt.Fprintf(w, `{"encoding":"%s","body":%s}`, r.Header.Get("Content-Encoding"), body)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), Transport(server.Client().Transport))
+	res, err := client.Put("/url", `{"name":"a"}`, CompressBody)
+	assert.NoError(t, err)
+	assert.Equal(t, "gzip", res.Get("encoding").String())
+	assert.Equal(t, "a", res.Get("body.name").String())
+	res, _ = client.Put("/url", `{"name":"a"}`)
+	assert.Equal(t, "", res.Get("encoding").String())
+
+	client, _ = NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), Transport(server.Client().Transport), CompressRequests())
+	res, _ = client.Post("/url", `{"name":"b"}`)
+	assert.Equal(t, "gzip", res.Get("encoding").String())
+	assert.Equal(t, "b", res.Get("body.name").String())
+	res, _ = client.Post("/url", `{"name":"b"}`, BodyCompression(false))
+	assert.Equal(t, "", res.Get("encoding").String())
+}
@@ -0,0 +1,142 @@
+package meraki
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultConfigTokenEnv is the environment variable the API token is read from if a
+// configuration does not reference a token.
+const DefaultConfigTokenEnv string = "MERAKI_DASHBOARD_API_KEY"
+
+// Config is a client configuration profile, see NewClientFromConfig. Zero values keep the
+// defaults of NewClient. Durations are in seconds, like the corresponding modifiers.
+type Config struct {
+	// BaseUrl is the API base URL, see BaseUrl
+	BaseUrl string `yaml:"baseUrl"`
+	// Region is the region of the API, taking precedence over BaseUrl, see Region
+	Region string `yaml:"region"`
+	// TokenEnv is the environment variable holding the API token, default is DefaultConfigTokenEnv
+	TokenEnv string `yaml:"tokenEnv"`
+	// TokenFile is a file holding the API token, taking precedence over TokenEnv
+	TokenFile string `yaml:"tokenFile"`
+	// UserAgent is the HTTP User-Agent string, see UserAgent
+	UserAgent string `yaml:"userAgent"`
+	// RequestPerSecond is the maximum number of requests per second, see RequestPerSecond
+	RequestPerSecond int `yaml:"requestPerSecond"`
+	// Burst is the maximum number of requests sent at once, see Burst
+	Burst int `yaml:"burst"`
+	// RequestTimeout is the total HTTP request timeout, see RequestTimeout
+	RequestTimeout int `yaml:"requestTimeout"`
+	// MaxRetries is the maximum number of retries, nil keeps the default, see MaxRetries
+	MaxRetries *int `yaml:"maxRetries"`
+	// BackoffMinDelay is the minimum delay between two retries, see BackoffMinDelay
+	BackoffMinDelay int `yaml:"backoffMinDelay"`
+	// BackoffMaxDelay is the maximum delay between two retries, see BackoffMaxDelay
+	BackoffMaxDelay int `yaml:"backoffMaxDelay"`
+	// BackoffDelayFactor is the backoff delay factor, see BackoffDelayFactor
+	BackoffDelayFactor float64 `yaml:"backoffDelayFactor"`
+}
+
+// LoadConfig reads a configuration profile from a YAML or JSON file. Unknown keys are
+// rejected, so typos do not silently fall back to defaults.
+func LoadConfig(path string) (Config, error) {
+	config := Config{}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return config, fmt.Errorf("invalid configuration %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// Token resolves the API token referenced by the configuration.
+func (config Config) Token() (string, error) {
+	if config.TokenFile != "" {
+		data, err := os.ReadFile(config.TokenFile)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	env := config.TokenEnv
+	if env == "" {
+		env = DefaultConfigTokenEnv
+	}
+	token := os.Getenv(env)
+	if token == "" {
+		return "", fmt.Errorf("no API token, environment variable %s not set", env)
+	}
+	return token, nil
+}
+
+// Modifiers returns the client modifiers of the configuration.
+func (config Config) Modifiers() []func(*Client) {
+	mods := make([]func(*Client), 0)
+	if config.BaseUrl != "" {
+		mods = append(mods, BaseUrl(config.BaseUrl))
+	}
+	if config.Region != "" {
+		mods = append(mods, Region(config.Region))
+	}
+	if config.UserAgent != "" {
+		mods = append(mods, UserAgent(config.UserAgent))
+	}
+	if config.RequestPerSecond > 0 {
+		mods = append(mods, RequestPerSecond(config.RequestPerSecond))
+	}
+	if config.Burst > 0 {
+		mods = append(mods, Burst(config.Burst))
+	}
+	if config.RequestTimeout > 0 {
+		mods = append(mods, RequestTimeout(time.Duration(config.RequestTimeout)))
+	}
+	if config.MaxRetries != nil {
+		mods = append(mods, MaxRetries(*config.MaxRetries))
+	}
+	if config.BackoffMinDelay > 0 {
+		mods = append(mods, BackoffMinDelay(config.BackoffMinDelay))
+	}
+	if config.BackoffMaxDelay > 0 {
+		mods = append(mods, BackoffMaxDelay(config.BackoffMaxDelay))
+	}
+	if config.BackoffDelayFactor > 0 {
+		mods = append(mods, BackoffDelayFactor(config.BackoffDelayFactor))
+	}
+	return mods
+}
+
+// NewClientFromConfig creates a new Meraki HTTP client from a configuration profile, so
+// automation jobs can share settings without embedding the API token, e.g.
+//
+//	# meraki.yaml
+//	baseUrl: https://api.meraki.cn/api/v1
+//	tokenEnv: MERAKI_CN_API_KEY
+//	requestPerSecond: 5
+//	maxRetries: 5
+//
+//	client, _ := NewClientFromConfig("meraki.yaml", Slog(logger))
+//
+// Modifiers are applied after the settings of the configuration and therefore override them.
+func NewClientFromConfig(path string, mods ...func(*Client)) (Client, error) {
+	config, err := LoadConfig(path)
+	if err != nil {
+		return Client{}, err
+	}
+	token, err := config.Token()
+	if err != nil {
+		return Client{}, err
+	}
+	return NewClient(token, append(config.Modifiers(), mods...)...)
+}
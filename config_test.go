@@ -0,0 +1,50 @@
+package meraki
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNewClientFromConfig tests the NewClientFromConfig function.
+func TestNewClientFromConfig(t *testing.T) {
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "meraki.yaml")
+	os.WriteFile(yamlFile, []byte("baseUrl: https://api.meraki.cn/api/v1\ntokenEnv: TEST_MERAKI_KEY\nrequestPerSecond: 5\nburst: 8\nrequestTimeout: 120\nmaxRetries: 0\nbackoffDelayFactor: 2\n"), 0600)
+	t.Setenv("TEST_MERAKI_KEY", "abc123")
+
+	client, err := NewClientFromConfig(yamlFile, UserAgent("job"))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.meraki.cn/api/v1", client.BaseUrl)
+	assert.Equal(t, "abc123", client.ApiToken)
+	assert.Equal(t, int64(8), client.RateLimiterBucket.Capacity())
+	assert.Equal(t, float64(5), client.RateLimiterBucket.Rate())
+	assert.Equal(t, 120*time.Second, client.HttpClient.Timeout)
+	assert.Equal(t, 0, client.MaxRetries)
+	assert.Equal(t, 2.0, client.BackoffDelayFactor)
+	assert.Equal(t, DefaultBackoffMaxDelay, client.BackoffMaxDelay)
+	assert.Equal(t, "job", client.UserAgent)
+
+	// JSON with token file
+	tokenFile := filepath.Join(dir, "token")
+	os.WriteFile(tokenFile, []byte("def456\n"), 0600)
+	jsonFile := filepath.Join(dir, "meraki.json")
+	os.WriteFile(jsonFile, []byte(`{"tokenFile": "`+tokenFile+`", "maxRetries": 5}`), 0600)
+	client, err = NewClientFromConfig(jsonFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "def456", client.ApiToken)
+	assert.Equal(t, 5, client.MaxRetries)
+
+	// Unknown key
+	os.WriteFile(jsonFile, []byte(`{"maxRetry": 5}`), 0600)
+	_, err = NewClientFromConfig(jsonFile)
+	assert.ErrorContains(t, err, "maxRetry")
+
+	// Missing token
+	os.WriteFile(yamlFile, []byte("tokenEnv: TEST_MERAKI_MISSING\n"), 0600)
+	_, err = NewClientFromConfig(yamlFile)
+	assert.ErrorContains(t, err, "TEST_MERAKI_MISSING")
+}
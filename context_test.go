@@ -0,0 +1,65 @@
+package meraki
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestClientContext tests the context variants of the request methods.
+func TestClientContext(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Get("/url").Reply(200).BodyString(`{"a":1}`)
+	gock.New(client.BaseUrl).Post("/url").Reply(200)
+	gock.New(client.BaseUrl).Put("/url").Reply(200)
+	gock.New(client.BaseUrl).Delete("/url").Reply(200)
+	ctx := context.Background()
+	res, err := client.GetContext(ctx, "/url")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), res.Get("a").Int())
+	_, err = client.PostContext(ctx, "/url", "{}")
+	assert.NoError(t, err)
+	_, err = client.PutContext(ctx, "/url", "{}")
+	assert.NoError(t, err)
+	_, err = client.DeleteContext(ctx, "/url")
+	assert.NoError(t, err)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = client.DoContext(cancelled, client.NewReq("GET", "/url", nil))
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// TestClientContextDeadline tests that deadlines bound hung requests, backoff and rate limit waits.
+func TestClientContextDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/hung":
+			<-r.Context().Done()
+		case "/limited":
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(429)
+		default:
+			w.WriteHeader(503)
+		}
+	}))
+	defer server.Close()
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MaxRetries(3), BackoffMinDelay(60))
+
+	for _, path := range []string{"/hung", "/limited", "/unavailable"} {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		start := time.Now()
+		_, err := client.GetContext(ctx, path)
+		cancel()
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), path)
+		assert.Less(t, time.Since(start), 5*time.Second, path)
+	}
+}
@@ -0,0 +1,39 @@
+package meraki
+
+// RequestCost is the number of rate limiter tokens debited for requests to matching paths.
+type RequestCost struct {
+	// Pattern is a path pattern, where * matches a single path segment and a trailing /** any number of segments
+	Pattern string
+	// Cost is the number of tokens debited per request attempt
+	Cost int64
+}
+
+// RequestCosts adds the number of rate limiter tokens debited for requests to a path pattern.
+// Requests to other paths cost a single token. The first matching pattern wins, e.g.
+//
+//	client, _ := NewClient("abc123", RequestCosts("/organizations/*/devices/statuses", 3))
+//
+// Expensive endpoints then use up more of the budget, which spreads them out over time
+// and avoids 429 responses on mixed workloads.
+func RequestCosts(pattern string, cost int64) func(*Client) {
+	return func(client *Client) {
+		client.RequestCosts = append(client.RequestCosts, RequestCost{Pattern: pattern, Cost: cost})
+	}
+}
+
+// requestCost returns the number of rate limiter tokens of a request.
+func (client *Client) requestCost(req Req) int64 {
+	if len(client.RequestCosts) == 0 {
+		return 1
+	}
+	path := client.relPath(req.HttpReq.URL)
+	for _, c := range client.RequestCosts {
+		if matchPathPattern(c.Pattern, path) {
+			if c.Cost < 1 {
+				return 1
+			}
+			return c.Cost
+		}
+	}
+	return 1
+}
@@ -0,0 +1,28 @@
+package meraki
+
+import (
+	"testing"
+	"time"
+
+	"github.com/juju/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestRequestCosts tests the RequestCosts modifier.
+func TestRequestCosts(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+	RequestCosts("/organizations/*/devices/statuses", 3)(&client)
+	RequestCosts("/organizations/**", 0)(&client)
+	client.RateLimiterBucket = ratelimit.NewBucketWithQuantum(time.Hour, 10, 10)
+
+	assert.Equal(t, int64(3), client.requestCost(client.NewReq("GET", "/organizations/123/devices/statuses", nil)))
+	assert.Equal(t, int64(1), client.requestCost(client.NewReq("GET", "/organizations/123", nil)))
+	assert.Equal(t, int64(1), client.requestCost(client.NewReq("GET", "/networks/N_1", nil)))
+
+	gock.New(client.BaseUrl).Get("/organizations/123/devices/statuses").Reply(200).BodyString(`[]`)
+	_, err := client.Get("/organizations/123/devices/statuses")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), client.RateLimiterBucket.Available())
+}
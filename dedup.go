@@ -0,0 +1,74 @@
+package meraki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// flightGroup collapses identical concurrent GET requests. It is shared by all copies of a client.
+type flightGroup struct {
+	mutex   sync.Mutex
+	flights map[string]*flight
+}
+
+// flight is a GET request in progress, whose result is shared by all identical requests.
+type flight struct {
+	done       chan struct{}
+	res        Res
+	statusCode int
+	err        error
+}
+
+// DeduplicateGets collapses identical concurrent GET requests, i.e. requests of the same URL
+// with the same headers, into a single API request and shares its response, e.g. many
+// goroutines fetching the device list of an organization at the same time. This reduces the
+// number of rate limiter tokens consumed by concurrent callers, see Stats.Deduplicated.
+func DeduplicateGets() func(*Client) {
+	return func(client *Client) {
+		if client.flights == nil {
+			client.flights = &flightGroup{flights: make(map[string]*flight)}
+		}
+	}
+}
+
+// doShared implements do with deduplication of identical concurrent GET requests, see DeduplicateGets.
+func (client *Client) doShared(req Req) (Res, int, error) {
+	g := client.flights
+	if g == nil || req.HttpReq.Method != "GET" {
+		return client.do(req)
+	}
+	// fmt prints maps sorted by key
+	key := fmt.Sprintf("%s %v", req.HttpReq.URL, req.HttpReq.Header)
+	g.mutex.Lock()
+	if f, ok := g.flights[key]; ok {
+		g.mutex.Unlock()
+		ctx := req.HttpReq.Context()
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			return Res{}, 0, ctx.Err()
+		}
+		// the shared request was canceled by its caller, not by this one
+		if ctx.Err() == nil && (errors.Is(f.err, context.Canceled) || errors.Is(f.err, context.DeadlineExceeded)) {
+			return client.do(req)
+		}
+		client.stats.deduplicated.Add(1)
+		res := f.res
+		res.Header = f.res.Header.Clone()
+		return res, f.statusCode, f.err
+	}
+	f := &flight{done: make(chan struct{})}
+	g.flights[key] = f
+	g.mutex.Unlock()
+
+	defer func() {
+		g.mutex.Lock()
+		delete(g.flights, key)
+		g.mutex.Unlock()
+		close(f.done)
+	}()
+	f.res, f.statusCode, f.err = client.do(req)
+	return f.res, f.statusCode, f.err
+}
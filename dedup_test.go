@@ -0,0 +1,50 @@
+package meraki
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDeduplicateGets tests the DeduplicateGets modifier.
+func TestDeduplicateGets(t *testing.T) {
+	var count atomic.Int64
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count.Add(1)
+		if r.URL.Path == "/devices" {
+			<-release
+		}
+		w.Write([]byte(`[{"serial":"Q2XX-AB12-CD34"}]`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), Transport(server.Client().Transport), DeduplicateGets())
+	var wg sync.WaitGroup
+	serials := make([]string, 5)
+	for i := range serials {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			res, err := client.Get("/devices")
+			assert.NoError(t, err)
+			serials[i] = res.Get("0.serial").String()
+		}(i)
+	}
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int64(1), count.Load())
+	assert.Equal(t, int64(4), client.Stats().Deduplicated)
+	assert.Equal(t, []string{"Q2XX-AB12-CD34", "Q2XX-AB12-CD34", "Q2XX-AB12-CD34", "Q2XX-AB12-CD34", "Q2XX-AB12-CD34"}, serials)
+
+	// Different headers and sequential requests are not deduplicated
+	client.Get("/other")
+	client.Get("/other", Header("X-Test", "1"))
+	assert.Equal(t, int64(3), count.Load())
+}
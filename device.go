@@ -0,0 +1,66 @@
+package meraki
+
+import (
+	"slices"
+)
+
+// AddDeviceTags adds tags to a device, keeping its existing tags.
+// The tags are fetched, merged and updated while holding the client write lock,
+// so concurrent tag updates through the same client do not overwrite each other.
+func (client *Client) AddDeviceTags(serial string, tags ...string) ([]string, error) {
+	return client.updateDeviceTags(serial, func(current []string) []string {
+		for _, tag := range tags {
+			if !slices.Contains(current, tag) {
+				current = append(current, tag)
+			}
+		}
+		return current
+	})
+}
+
+// RemoveDeviceTags removes tags from a device, keeping its other tags.
+// Like AddDeviceTags, the update is performed while holding the client write lock.
+func (client *Client) RemoveDeviceTags(serial string, tags ...string) ([]string, error) {
+	return client.updateDeviceTags(serial, func(current []string) []string {
+		result := make([]string, 0, len(current))
+		for _, tag := range current {
+			if !slices.Contains(tags, tag) {
+				result = append(result, tag)
+			}
+		}
+		return result
+	})
+}
+
+// updateDeviceTags performs a read-modify-write of the tags of a device under the write lock.
+func (client *Client) updateDeviceTags(serial string, fn func([]string) []string) ([]string, error) {
+	lock := client.writeLock("/devices/" + serial)
+	lock.Lock()
+	defer lock.Unlock()
+
+	res, err := client.Get("/devices/" + serial)
+	if err != nil {
+		return nil, err
+	}
+	current := make([]string, 0)
+	for _, tag := range res.Get("tags").Array() {
+		current = append(current, tag.String())
+	}
+	updated := fn(append([]string{}, current...))
+	if slices.Equal(current, updated) {
+		return current, nil
+	}
+	body := Body{}.Set("tags", updated)
+	res, err = client.Put("/devices/"+serial, body.Str, writeLocked)
+	if err != nil {
+		return nil, err
+	}
+	if !res.Get("tags").Exists() {
+		return updated, nil
+	}
+	result := make([]string, 0)
+	for _, tag := range res.Get("tags").Array() {
+		result = append(result, tag.String())
+	}
+	return result, nil
+}
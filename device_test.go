@@ -0,0 +1,33 @@
+package meraki
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestClientAddDeviceTags tests the Client::AddDeviceTags and Client::RemoveDeviceTags methods.
+func TestClientAddDeviceTags(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Get("/devices/Q-1").Reply(200).BodyString(`{"tags":["a","b"]}`)
+	gock.New(client.BaseUrl).Put("/devices/Q-1").JSON(`{"tags":["a","b","c"]}`).Reply(200).BodyString(`{"tags":["a","b","c"]}`)
+	tags, err := client.AddDeviceTags("Q-1", "b", "c")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, tags)
+
+	gock.New(client.BaseUrl).Get("/devices/Q-1").Reply(200).BodyString(`{"tags":["a","b","c"]}`)
+	gock.New(client.BaseUrl).Put("/devices/Q-1").JSON(`{"tags":["c"]}`).Reply(200).BodyString(`{"tags":["c"]}`)
+	tags, err = client.RemoveDeviceTags("Q-1", "a", "b")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c"}, tags)
+
+	// Unchanged tags skip the update
+	gock.New(client.BaseUrl).Get("/devices/Q-1").Reply(200).BodyString(`{"tags":["c"]}`)
+	tags, err = client.AddDeviceTags("Q-1", "c")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c"}, tags)
+	assert.True(t, gock.IsDone())
+}
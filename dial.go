@@ -0,0 +1,124 @@
+package meraki
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// dialConfig is the dialer configuration of a client transport.
+type dialConfig struct {
+	dialer  net.Dialer
+	network string
+	// custom dial function replacing the dialer, nil if not configured
+	dial func(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// DialContext dials using the configured IP family.
+func (d *dialConfig) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	if d.network != "" && network == "tcp" {
+		network = d.network
+	}
+	if d.dial != nil {
+		return d.dial(ctx, network, address)
+	}
+	return d.dialer.DialContext(ctx, network, address)
+}
+
+// IPv4Only restricts connections to IPv4, e.g. in environments with broken IPv6 connectivity.
+func IPv4Only() func(*Client) {
+	return func(client *Client) {
+		if d := client.dialConfig(); d != nil {
+			d.network = "tcp4"
+		}
+	}
+}
+
+// IPv6Only restricts connections to IPv6.
+func IPv6Only() func(*Client) {
+	return func(client *Client) {
+		if d := client.dialConfig(); d != nil {
+			d.network = "tcp6"
+		}
+	}
+}
+
+// FallbackDelay modifies the Happy Eyeballs delay before falling back from IPv6 to IPv4,
+// see net.Dialer.FallbackDelay. Default value is 300ms, a negative value disables the fallback.
+func FallbackDelay(x time.Duration) func(*Client) {
+	return func(client *Client) {
+		if d := client.dialConfig(); d != nil {
+			d.dialer.FallbackDelay = x
+		}
+	}
+}
+
+// DialContext replaces the dial function of the HTTP transport, e.g. to pin connections to
+// specific addresses without replacing the HTTP client:
+//
+//	client, _ := NewClient("abc123", DialContext(func(ctx context.Context, network, address string) (net.Conn, error) {
+//		return (&net.Dialer{}).DialContext(ctx, network, "203.0.113.10:443")
+//	}))
+//
+// The network passed to the function reflects IPv4Only and IPv6Only, the other dial settings
+// like DialTimeout do not apply to custom dial functions.
+func DialContext(fn func(ctx context.Context, network, address string) (net.Conn, error)) func(*Client) {
+	return func(client *Client) {
+		if d := client.dialConfig(); d != nil {
+			d.dial = fn
+		}
+	}
+}
+
+// Resolver replaces the DNS resolver used to resolve the API host names, e.g. to query the
+// internal resolver of a split-horizon DNS setup.
+func Resolver(r *net.Resolver) func(*Client) {
+	return func(client *Client) {
+		if d := client.dialConfig(); d != nil {
+			d.dialer.Resolver = r
+		}
+	}
+}
+
+// LocalAddr binds connections to a local source IP address, e.g. to select the egress NAT of
+// a multi-homed host. NewClient fails if the address is not a valid IP address.
+func LocalAddr(ip string) func(*Client) {
+	return func(client *Client) {
+		addr := net.ParseIP(ip)
+		if addr == nil {
+			client.fail(fmt.Errorf("invalid local address '%s'", ip))
+			return
+		}
+		if d := client.dialConfig(); d != nil {
+			d.dialer.LocalAddr = &net.TCPAddr{IP: addr}
+		}
+	}
+}
+
+// dialConfig returns the dialer configuration of the client transport, installing it if needed.
+// It returns nil if the client uses a custom transport, which is not an *http.Transport.
+func (client *Client) dialConfig() *dialConfig {
+	if client.dial != nil {
+		return client.dial
+	}
+	transport := client.transport()
+	if transport == nil {
+		client.logf("[WARNING] Dial settings ignored, HTTP client uses a custom transport")
+		return nil
+	}
+	client.dial = &dialConfig{dialer: net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}}
+	transport.DialContext = client.dial.DialContext
+	return client.dial
+}
+
+// transport returns the *http.Transport of the HTTP client, cloning http.DefaultTransport
+// if none is set. It returns nil if the transport is not an *http.Transport.
+func (client *Client) transport() *http.Transport {
+	if client.HttpClient.Transport == nil {
+		client.HttpClient.Transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	transport, _ := client.HttpClient.Transport.(*http.Transport)
+	return transport
+}
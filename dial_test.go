@@ -0,0 +1,59 @@
+package meraki
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestIPv4Only tests the IPv4Only, IPv6Only and FallbackDelay modifiers.
+func TestIPv4Only(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), IPv4Only(), FallbackDelay(-1))
+	assert.Equal(t, "tcp4", client.dial.network)
+	assert.Equal(t, time.Duration(-1), client.dial.dialer.FallbackDelay)
+	_, err := client.Get("/url")
+	assert.NoError(t, err)
+
+	client, _ = NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), IPv6Only())
+	_, err = client.Get("/url")
+	assert.Error(t, err)
+}
+
+// TestDialContext tests the DialContext, Resolver and LocalAddr modifiers.
+func TestDialContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	// Pin the API host name to the test server
+	dialed := make([]string, 0)
+	client, _ := NewClient("abc123", BaseUrl("http://api.example.com/api/v1"), MaxRetries(0), IPv4Only(),
+		DialContext(func(ctx context.Context, network, address string) (net.Conn, error) {
+			dialed = append(dialed, network+" "+address)
+			return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+		}))
+	_, err := client.Get("/url")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tcp4 api.example.com:80"}, dialed)
+
+	resolver := &net.Resolver{PreferGo: true}
+	client, err = NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), Resolver(resolver), LocalAddr("127.0.0.1"))
+	assert.NoError(t, err)
+	assert.Same(t, resolver, client.dial.dialer.Resolver)
+	_, err = client.Get("/url")
+	assert.NoError(t, err)
+
+	_, err = NewClient("abc123", LocalAddr("invalid"))
+	assert.ErrorContains(t, err, "invalid local address")
+}
@@ -0,0 +1,165 @@
+package meraki
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// ReadOnlyFields is the default list of server assigned top-level read-only fields ignored by
+// Diff and Normalize. Nested read-only fields have to be added by path, e.g. "rules.#.id".
+var ReadOnlyFields = []string{"id", "url", "createdAt", "updatedAt", "lastUpdatedAt"}
+
+// DiffType is the type of a difference.
+type DiffType string
+
+const (
+	DiffAdded   DiffType = "added"
+	DiffRemoved DiffType = "removed"
+	DiffChanged DiffType = "changed"
+)
+
+// DiffEntry is a single difference between two JSON documents.
+type DiffEntry struct {
+	// Path is the GJSON path of the difference
+	Path string
+	// Type is the type of the difference
+	Type DiffType
+	// Old is the value in the first document, empty if added
+	Old gjson.Result
+	// New is the value in the second document, empty if removed
+	New gjson.Result
+}
+
+// String returns a human readable representation of the difference.
+func (entry DiffEntry) String() string {
+	switch entry.Type {
+	case DiffAdded:
+		return fmt.Sprintf("+ %s: %s", entry.Path, entry.New.Raw)
+	case DiffRemoved:
+		return fmt.Sprintf("- %s: %s", entry.Path, entry.Old.Raw)
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", entry.Path, entry.Old.Raw, entry.New.Raw)
+	}
+}
+
+// DiffConfig is the configuration of Diff.
+type DiffConfig struct {
+	// Ignore is a list of ignored fields. Entries match full paths, where # matches any array
+	// index, e.g. "id" only matches the top-level id and "rules.#.id" the ids of all rules.
+	Ignore []string
+}
+
+// DiffIgnore replaces the list of ignored fields. Default value is ReadOnlyFields.
+func DiffIgnore(fields ...string) func(*DiffConfig) {
+	return func(config *DiffConfig) {
+		config.Ignore = fields
+	}
+}
+
+// Diff returns the differences between two JSON documents, e.g.
+//
+//	for _, d := range meraki.Diff(current, desired) {
+//		println(d.String())
+//	}
+func Diff(a, b Res, mods ...func(*DiffConfig)) []DiffEntry {
+	config := DiffConfig{Ignore: ReadOnlyFields}
+	for _, mod := range mods {
+		mod(&config)
+	}
+	entries := make([]DiffEntry, 0)
+	return config.diff(entries, "", a.Result, b.Result)
+}
+
+// matchField reports whether a path matches an entry of a list of fields. Entries match full
+// paths, where # matches any array index, so entries without a dot only match top-level fields.
+func matchField(fields []string, path string) bool {
+	for _, field := range fields {
+		f, p := strings.Split(field, "."), strings.Split(path, ".")
+		if len(f) != len(p) {
+			continue
+		}
+		match := true
+		for i := range f {
+			if f[i] != p[i] && (f[i] != "#" || !isIndex(p[i])) {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+func isIndex(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
+
+func (config DiffConfig) diff(entries []DiffEntry, path string, a, b gjson.Result) []DiffEntry {
+	if path != "" && matchField(config.Ignore, path) {
+		return entries
+	}
+	switch {
+	case !a.Exists() && !b.Exists():
+	case !a.Exists():
+		entries = append(entries, DiffEntry{Path: path, Type: DiffAdded, New: b})
+	case !b.Exists():
+		entries = append(entries, DiffEntry{Path: path, Type: DiffRemoved, Old: a})
+	case a.IsObject() && b.IsObject():
+		keys := make([]string, 0)
+		seen := make(map[string]bool)
+		for _, r := range []gjson.Result{a, b} {
+			r.ForEach(func(k, _ gjson.Result) bool {
+				if !seen[k.Str] {
+					seen[k.Str] = true
+					keys = append(keys, k.Str)
+				}
+				return true
+			})
+		}
+		for _, k := range keys {
+			escaped := escapePath(k)
+			entries = config.diff(entries, joinPath(path, escaped), a.Get(escaped), b.Get(escaped))
+		}
+	case a.IsArray() && b.IsArray():
+		aa, ba := a.Array(), b.Array()
+		for i := 0; i < len(aa) || i < len(ba); i++ {
+			var av, bv gjson.Result
+			if i < len(aa) {
+				av = aa[i]
+			}
+			if i < len(ba) {
+				bv = ba[i]
+			}
+			entries = config.diff(entries, joinPath(path, strconv.Itoa(i)), av, bv)
+		}
+	case a.Type != b.Type || a.IsObject() != b.IsObject() || a.Value() != b.Value():
+		entries = append(entries, DiffEntry{Path: path, Type: DiffChanged, Old: a, New: b})
+	}
+	return entries
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// escapePath escapes GJSON path special characters in a key.
+func escapePath(key string) string {
+	var sb strings.Builder
+	for _, c := range key {
+		switch c {
+		case '.', '*', '?', '|', '#', '@', '\\', '!', '=', '<', '>', '%':
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(c)
+	}
+	return sb.String()
+}
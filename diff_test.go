@@ -0,0 +1,43 @@
+package meraki
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestDiff tests the Diff function.
+func TestDiff(t *testing.T) {
+	a := Body{Str: `{"id":"1","name":"a","tags":["x","y"],"vlan":{"id":10,"subnet":"10.0.0.0/24"},"old":true}`}.Res()
+	b := Body{Str: `{"id":"2","name":"b","tags":["x"],"vlan":{"id":10,"subnet":"10.0.0.0/24"},"new":1}`}.Res()
+
+	entries := Diff(a, b)
+	assert.Equal(t, []DiffEntry{
+		{Path: "name", Type: DiffChanged, Old: a.Get("name"), New: b.Get("name")},
+		{Path: "tags.1", Type: DiffRemoved, Old: a.Get("tags.1")},
+		{Path: "old", Type: DiffRemoved, Old: a.Get("old")},
+		{Path: "new", Type: DiffAdded, New: b.Get("new")},
+	}, entries)
+	assert.Equal(t, `~ name: "a" -> "b"`, entries[0].String())
+
+	// Custom ignore list
+	entries = Diff(a, b, DiffIgnore("vlan.id", "name", "tags", "old", "new"))
+	assert.Equal(t, []DiffEntry{{Path: "id", Type: DiffChanged, Old: a.Get("id"), New: b.Get("id")}}, entries)
+
+	// Nested ids are only ignored by path
+	a = Body{Str: `{"id":"1","rules":[{"id":"r1","policy":"allow"}],"vlan":{"id":10}}`}.Res()
+	b = Body{Str: `{"id":"2","rules":[{"id":"r2","policy":"allow"}],"vlan":{"id":20}}`}.Res()
+	assert.Equal(t, []string{"rules.0.id", "vlan.id"}, diffPaths(Diff(a, b)))
+	assert.Equal(t, []string{"vlan.id"}, diffPaths(Diff(a, b, DiffIgnore("id", "rules.#.id"))))
+
+	// Equal documents
+	assert.Empty(t, Diff(a, a))
+}
+
+func diffPaths(entries []DiffEntry) []string {
+	paths := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		paths = append(paths, entry.Path)
+	}
+	return paths
+}
@@ -0,0 +1,150 @@
+package meraki
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrorCategory is a stable category of API errors.
+type ErrorCategory string
+
+const (
+	CategoryUnknown     ErrorCategory = "unknown"
+	CategoryValidation  ErrorCategory = "validation"
+	CategoryAuth        ErrorCategory = "auth"
+	CategoryNotFound    ErrorCategory = "not-found"
+	CategoryConflict    ErrorCategory = "conflict"
+	CategoryQuota       ErrorCategory = "quota"
+	CategoryLicensing   ErrorCategory = "licensing"
+	CategoryUnsupported ErrorCategory = "unsupported"
+	CategoryServer      ErrorCategory = "server"
+	CategoryUnavailable ErrorCategory = "unavailable"
+)
+
+// ErrorPattern maps an error message pattern to a category.
+type ErrorPattern struct {
+	// Pattern is matched case-insensitively as substring of the error messages
+	Pattern string
+	// Category is the category of matching errors
+	Category ErrorCategory
+}
+
+// ErrorPatterns is the knowledge base of common Meraki error messages. Patterns are
+// evaluated in order, the first match wins. Errors not matching any pattern are
+// categorized by their HTTP status code.
+var ErrorPatterns = []ErrorPattern{
+	{"license", CategoryLicensing},
+	{"licensing", CategoryLicensing},
+	{"subscription", CategoryLicensing},
+	{"rate limit", CategoryQuota},
+	{"too many requests", CategoryQuota},
+	{"maximum number of", CategoryQuota},
+	{"limit reached", CategoryQuota},
+	{"exceeds the limit", CategoryQuota},
+	{"quota", CategoryQuota},
+	{"already exists", CategoryConflict},
+	{"already been taken", CategoryConflict},
+	{"already claimed", CategoryConflict},
+	{"already in use", CategoryConflict},
+	{"conflict", CategoryConflict},
+	{"not found", CategoryNotFound},
+	{"does not exist", CategoryNotFound},
+	{"invalid api key", CategoryAuth},
+	{"unauthorized", CategoryAuth},
+	{"permission", CategoryAuth},
+	{"not supported", CategoryUnsupported},
+	{"unsupported", CategoryUnsupported},
+	{"is not available for", CategoryUnsupported},
+	{"try again", CategoryUnavailable},
+	{"temporarily unavailable", CategoryUnavailable},
+	{"must be", CategoryValidation},
+	{"is invalid", CategoryValidation},
+	{"invalid", CategoryValidation},
+	{"is required", CategoryValidation},
+	{"cannot be blank", CategoryValidation},
+}
+
+// ApiError is an error returned by the API, either a non-2xx HTTP status code or a JSON error message.
+type ApiError struct {
+	// StatusCode is the HTTP status code
+	StatusCode int
+	// Method is the HTTP method of the request
+	Method string
+	// Url is the URL of the request
+	Url string
+	// Messages are the error messages returned in the "errors" attribute
+	Messages []string
+	// Res is the response
+	Res Res
+	msg string
+}
+
+// newApiError creates an ApiError from a response.
+func newApiError(req Req, statusCode int, res Res, msg string) *ApiError {
+	e := &ApiError{
+		StatusCode: statusCode,
+		Method:     req.HttpReq.Method,
+		Url:        req.HttpReq.URL.String(),
+		Messages:   make([]string, 0),
+		Res:        res,
+		msg:        msg,
+	}
+	for _, m := range res.Get("errors").Array() {
+		e.Messages = append(e.Messages, m.String())
+	}
+	return e
+}
+
+// Error implements the error interface.
+func (e *ApiError) Error() string {
+	return e.msg
+}
+
+// Category returns the category of the error based on ErrorPatterns and the HTTP status code.
+func (e *ApiError) Category() ErrorCategory {
+	for _, p := range ErrorPatterns {
+		pattern := strings.ToLower(p.Pattern)
+		for _, m := range e.Messages {
+			if strings.Contains(strings.ToLower(m), pattern) {
+				return p.Category
+			}
+		}
+	}
+	switch {
+	case e.StatusCode == 400 || e.StatusCode == 422:
+		return CategoryValidation
+	case e.StatusCode == 401 || e.StatusCode == 403:
+		return CategoryAuth
+	case e.StatusCode == 404:
+		return CategoryNotFound
+	case e.StatusCode == 409:
+		return CategoryConflict
+	case e.StatusCode == 429:
+		return CategoryQuota
+	case e.StatusCode == 502 || e.StatusCode == 503 || e.StatusCode == 504:
+		return CategoryUnavailable
+	case e.StatusCode >= 500 && e.StatusCode <= 599:
+		return CategoryServer
+	}
+	return CategoryUnknown
+}
+
+// Category returns CategoryConflict.
+func (e *ConflictError) Category() ErrorCategory {
+	return CategoryConflict
+}
+
+// ErrorCategoryOf returns the category of an error, or CategoryUnknown if it
+// is neither an *ApiError nor a *ConflictError, e.g.
+//
+//	_, err := client.Post("/organizations/123/networks", body.Str)
+//	if meraki.ErrorCategoryOf(err) == meraki.CategoryConflict {
+//		...
+//	}
+func ErrorCategoryOf(err error) ErrorCategory {
+	var categorized interface{ Category() ErrorCategory }
+	if errors.As(err, &categorized) {
+		return categorized.Category()
+	}
+	return CategoryUnknown
+}
@@ -0,0 +1,39 @@
+package meraki
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestApiErrorCategory tests the ApiError::Category method.
+func TestApiErrorCategory(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Post("/organizations/1/networks").Reply(400).BodyString(`{"errors":["Name has already been taken"]}`)
+	_, err := client.Post("/organizations/1/networks", "{}")
+	var apiErr *ApiError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, 400, apiErr.StatusCode)
+	assert.Equal(t, []string{"Name has already been taken"}, apiErr.Messages)
+	assert.Equal(t, `HTTP Request failed: StatusCode 400, JSON error: ["Name has already been taken"]`, apiErr.Error())
+	assert.Equal(t, CategoryConflict, apiErr.Category())
+
+	gock.New(client.BaseUrl).Get("/networks/N_1").Reply(404)
+	_, err = client.Get("/networks/N_1")
+	assert.Equal(t, CategoryNotFound, ErrorCategoryOf(err))
+	assert.EqualError(t, err, "HTTP Request failed: StatusCode 404")
+
+	gock.New(client.BaseUrl).Get("/url").Reply(200).BodyString(`{"errors":["Maximum number of networks reached"]}`)
+	_, err = client.Get("/url")
+	assert.Equal(t, CategoryQuota, ErrorCategoryOf(err))
+
+	assert.Equal(t, CategoryLicensing, (&ApiError{StatusCode: 400, Messages: []string{"No valid license"}}).Category())
+	assert.Equal(t, CategoryValidation, (&ApiError{StatusCode: 400}).Category())
+	assert.Equal(t, CategoryServer, (&ApiError{StatusCode: 500}).Category())
+	assert.Equal(t, CategoryConflict, ErrorCategoryOf(&ConflictError{}))
+	assert.Equal(t, CategoryUnknown, ErrorCategoryOf(errors.New("fail")))
+}
@@ -0,0 +1,90 @@
+package meraki
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+)
+
+// etagCache is a LRU cache of GET responses with an ETag header. It is shared by all copies of a client.
+type etagCache struct {
+	mutex   sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+	hits    atomic.Int64
+}
+
+type etagEntry struct {
+	key  string
+	etag string
+	res  Res
+}
+
+// ETagCache enables a LRU cache with the given number of entries for GET responses with an
+// ETag header, e.g.
+//
+//	client, _ := NewClient("abc123", ETagCache(1000))
+//
+// Subsequent GET requests of a cached URL are sent with an If-None-Match header and the cached
+// response is returned if the API responds with 304 Not Modified, which saves bandwidth and
+// rate limiter tokens for endpoints that rarely change. Unlike LookupCache, every request is
+// still sent, so responses are never stale. Use NoCache to skip the cache for a request.
+func ETagCache(size int) func(*Client) {
+	return func(client *Client) {
+		client.etagCache = &etagCache{
+			size:    size,
+			entries: make(map[string]*list.Element),
+			order:   list.New(),
+		}
+	}
+}
+
+// conditional adds an If-None-Match header with the cached ETag of a GET request and returns the cached response.
+func (client *Client) conditional(req Req) (Res, bool) {
+	c := client.etagCache
+	if c == nil || req.HttpReq.Method != "GET" || req.NoCache {
+		return Res{}, false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	e, ok := c.entries[req.HttpReq.URL.String()]
+	if !ok {
+		return Res{}, false
+	}
+	entry := e.Value.(*etagEntry)
+	req.HttpReq.Header.Set("If-None-Match", entry.etag)
+	return entry.res, true
+}
+
+// notModified returns the cached response of a request answered with 304 Not Modified.
+func (client *Client) notModified(req Req, cached Res) Res {
+	client.etagCache.hits.Add(1)
+	client.logf("[DEBUG] HTTP Response not modified, served from ETag cache: %s", req.HttpReq.URL)
+	res := cached
+	res.Header = cached.Header.Clone()
+	return res
+}
+
+// storeETag caches the response of a successful GET request with an ETag header.
+func (client *Client) storeETag(req Req, res Res, err error) {
+	c := client.etagCache
+	if c == nil || req.HttpReq.Method != "GET" || req.NoCache || err != nil || res.Header.Get("ETag") == "" {
+		return
+	}
+	key := req.HttpReq.URL.String()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry := &etagEntry{key: key, etag: res.Header.Get("ETag"), res: res}
+	if e, ok := c.entries[key]; ok {
+		e.Value = entry
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.size > 0 && c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*etagEntry).key)
+	}
+}
@@ -0,0 +1,38 @@
+package meraki
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestETagCache tests the ETagCache modifier.
+func TestETagCache(t *testing.T) {
+	defer gock.Off()
+	client, _ := NewClient("abc123", MaxRetries(0), ETagCache(1))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(client.BaseUrl).Get("/networks/N_1").Reply(200).SetHeader("ETag", `"v1"`).JSON(`{"name":"Net1"}`)
+	gock.New(client.BaseUrl).Get("/networks/N_1").MatchHeader("If-None-Match", `"v1"`).Reply(304)
+	gock.New(client.BaseUrl).Get("/networks/N_1").MatchHeader("If-None-Match", `"v1"`).Reply(200).SetHeader("ETag", `"v2"`).JSON(`{"name":"Net2"}`)
+	gock.New(client.BaseUrl).Get("/networks/N_1").MatchHeader("If-None-Match", `"v2"`).Reply(304)
+	for _, name := range []string{"Net1", "Net1", "Net2", "Net2"} {
+		res, err := client.Get("/networks/N_1")
+		assert.NoError(t, err)
+		assert.Equal(t, name, res.Get("name").String())
+	}
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, int64(2), client.Stats().ETagCacheHits)
+
+	// Entries are evicted, NoCache skips the cache
+	gock.New(client.BaseUrl).Get("/networks/N_2").Reply(200).SetHeader("ETag", `"v1"`).JSON(`{}`)
+	client.Get("/networks/N_2")
+	gock.New(client.BaseUrl).Get("/networks/N_1").Reply(200).JSON(`{}`)
+	gock.New(client.BaseUrl).Get("/networks/N_2").Reply(200).JSON(`{}`)
+	client.Get("/networks/N_1")
+	_, err := client.Get("/networks/N_2", NoCache)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, 0, len(gock.GetUnmatchedRequests()))
+}
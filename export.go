@@ -0,0 +1,135 @@
+package meraki
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// ExportCheckpointFile is the name of the checkpoint file in an export directory.
+const ExportCheckpointFile string = "checkpoint.json"
+
+// ExportCheckpoint is the state of an export, see Export.
+type ExportCheckpoint struct {
+	// Path is the exported API path
+	Path string `json:"path"`
+	// Next is the path of the next page to be fetched, empty if the export is done
+	Next string `json:"next"`
+	// Pages is the number of pages written so far
+	Pages int `json:"pages"`
+	// Items is the number of items written so far
+	Items int `json:"items"`
+	// Done indicates that all pages have been written
+	Done bool `json:"done"`
+}
+
+// Export fetches all pages of a paginated GET request and writes each page as soon as
+// it arrives to dir, e.g. page-00001.json, recording the cursor of the next page in a
+// checkpoint file. If the export is interrupted, calling Export again with the same
+// path and directory resumes from the last completed page, e.g.
+//
+//	checkpoint, err := client.Export("/organizations/123/devices", "/tmp/devices")
+//	devices, err := ReadExport("/tmp/devices")
+func (client *Client) Export(path, dir string, mods ...func(*Req)) (ExportCheckpoint, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return ExportCheckpoint{}, err
+	}
+	checkpoint, err := readExportCheckpoint(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		checkpoint = ExportCheckpoint{Path: path, Next: path}
+	} else if err != nil {
+		return checkpoint, err
+	} else if checkpoint.Path != path {
+		return checkpoint, fmt.Errorf("Export directory %s contains an export of %s", dir, checkpoint.Path)
+	}
+	progress := Progress{Pages: checkpoint.Pages, Items: checkpoint.Items}
+	for !checkpoint.Done {
+		req := client.NewReq("GET", checkpoint.Next, nil, mods...)
+		res, err := client.Do(req)
+		if err != nil {
+			return checkpoint, err
+		}
+		progress.update(res)
+		if err := writeFileAtomic(filepath.Join(dir, fmt.Sprintf("page-%05d.json", checkpoint.Pages+1)), []byte(res.Raw)); err != nil {
+			return checkpoint, err
+		}
+		next, foundNext, err := client.nextPage(res.Header)
+		if err != nil {
+			return checkpoint, err
+		}
+		checkpoint.Pages = progress.Pages
+		checkpoint.Items = progress.Items
+		checkpoint.Next = next
+		checkpoint.Done = !foundNext
+		if err := writeExportCheckpoint(dir, checkpoint); err != nil {
+			return checkpoint, err
+		}
+		client.progress(req, progress)
+	}
+	return checkpoint, nil
+}
+
+// ReadExport reads all pages of a completed export and returns them as a single result,
+// like Get does for paginated requests.
+func ReadExport(dir string) (Res, error) {
+	checkpoint, err := readExportCheckpoint(dir)
+	if err != nil {
+		return Res{}, err
+	}
+	if !checkpoint.Done {
+		return Res{}, fmt.Errorf("Export of %s in %s is incomplete", checkpoint.Path, dir)
+	}
+	r := ""
+	for page := 1; page <= checkpoint.Pages; page++ {
+		data, err := os.ReadFile(filepath.Join(dir, fmt.Sprintf("page-%05d.json", page)))
+		if err != nil {
+			return Res{}, err
+		}
+		res := gjson.ParseBytes(data)
+		if checkpoint.Pages == 1 {
+			return Res{Result: res}, nil
+		}
+		if items := res.Get("items"); items.Exists() {
+			for _, item := range items.Array() {
+				r, _ = sjson.SetRaw(r, "response.items.-1", item.Raw)
+			}
+			continue
+		}
+		for _, item := range res.Array() {
+			r, _ = sjson.SetRaw(r, "response.-1", item.Raw)
+		}
+	}
+	return Res{Result: gjson.Parse(gjson.Get(r, "response").Raw)}, nil
+}
+
+func readExportCheckpoint(dir string) (ExportCheckpoint, error) {
+	checkpoint := ExportCheckpoint{}
+	data, err := os.ReadFile(filepath.Join(dir, ExportCheckpointFile))
+	if err != nil {
+		return checkpoint, err
+	}
+	err = json.Unmarshal(data, &checkpoint)
+	return checkpoint, err
+}
+
+func writeExportCheckpoint(dir string, checkpoint ExportCheckpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dir, ExportCheckpointFile), data)
+}
+
+// writeFileAtomic writes a file by renaming a temporary file, so an interrupted write never leaves a partial file.
+func writeFileAtomic(name string, data []byte) error {
+	tmp := name + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, name)
+}
@@ -0,0 +1,47 @@
+package meraki
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestClientExport tests the Client::Export method and the ReadExport function.
+func TestClientExport(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+	dir := t.TempDir()
+
+	gock.New(client.BaseUrl).Get("/devices").
+		Reply(200).
+		BodyString(`[{"serial":"1"},{"serial":"2"}]`).
+		AddHeader("Link", `<`+client.BaseUrl+`/devices?startingAfter=2>; rel="next"`)
+	gock.New(client.BaseUrl).Get("/devices").MatchParam("startingAfter", "2").
+		Reply(500)
+	checkpoint, err := client.Export("/devices", dir)
+	assert.Error(t, err)
+	assert.Equal(t, ExportCheckpoint{Path: "/devices", Next: "/devices?startingAfter=2", Pages: 1, Items: 2}, checkpoint)
+	_, err = ReadExport(dir)
+	assert.Error(t, err)
+
+	// Resume from the last completed page
+	gock.New(client.BaseUrl).Get("/devices").MatchParam("startingAfter", "2").
+		Reply(200).
+		BodyString(`[{"serial":"3"}]`).
+		AddHeader("Link", `<`+client.BaseUrl+`/devices>; rel="first"`)
+	checkpoint, err = client.Export("/devices", dir)
+	assert.NoError(t, err)
+	assert.Equal(t, ExportCheckpoint{Path: "/devices", Pages: 2, Items: 3, Done: true}, checkpoint)
+	assert.True(t, gock.IsDone())
+
+	// Completed exports are not fetched again
+	_, err = client.Export("/devices", dir)
+	assert.NoError(t, err)
+	_, err = client.Export("/networks", dir)
+	assert.Error(t, err)
+
+	res, err := ReadExport(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"serial":"1"},{"serial":"2"},{"serial":"3"}]`, res.Raw)
+}
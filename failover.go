@@ -0,0 +1,118 @@
+package meraki
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const DefaultFailoverThreshold int = 3
+const DefaultFailbackInterval time.Duration = 5 * time.Minute
+
+// failoverState is the state of the base URL failover. It is shared by all copies of a client.
+type failoverState struct {
+	mutex    sync.Mutex
+	index    int
+	failures int
+	since    time.Time
+}
+
+// FailoverUrls adds base URLs used in order if the connection to the current base URL fails
+// FailoverThreshold times in a row, e.g.
+//
+//	client, _ := NewClient("abc123", FailoverUrls("https://api-gw2.example.com/api/v1"))
+//
+// After FailbackInterval the primary base URL is probed again by the next request. If it
+// still fails, the client immediately fails over again.
+func FailoverUrls(urls ...string) func(*Client) {
+	return func(client *Client) {
+		client.FailoverUrls = append(client.FailoverUrls, urls...)
+		if client.failover == nil {
+			client.failover = &failoverState{}
+		}
+	}
+}
+
+// FailoverThreshold modifies the number of consecutive connection failures triggering a failover. Default value is 3.
+func FailoverThreshold(x int) func(*Client) {
+	return func(client *Client) {
+		client.FailoverThreshold = x
+	}
+}
+
+// FailbackInterval modifies the time after which the primary base URL is probed again. Default value is 5 minutes.
+func FailbackInterval(x time.Duration) func(*Client) {
+	return func(client *Client) {
+		client.FailbackInterval = x
+	}
+}
+
+// baseUrls returns the primary base URL followed by the failover URLs.
+func (client *Client) baseUrls() []string {
+	return append([]string{client.BaseUrl}, client.FailoverUrls...)
+}
+
+// activeBaseUrl returns the base URL to be used for the next request attempt.
+func (client *Client) activeBaseUrl() string {
+	f := client.failover
+	if f == nil || len(client.FailoverUrls) == 0 {
+		return client.BaseUrl
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if f.index > 0 && client.FailbackInterval > 0 && time.Since(f.since) >= client.FailbackInterval {
+		client.logf("[INFO] Probing primary base URL %s", client.BaseUrl)
+		f.index = 0
+		// a single failure of the probe fails over again
+		f.failures = client.FailoverThreshold - 1
+	}
+	return client.baseUrls()[f.index]
+}
+
+// reportConn records the outcome of a connection to a base URL and fails over to the
+// next base URL after FailoverThreshold consecutive failures. Requests canceled by the
+// caller are ignored, as they do not indicate a failure of the base URL.
+func (client *Client) reportConn(ctx context.Context, baseUrl string, err error) {
+	f := client.failover
+	if f == nil || len(client.FailoverUrls) == 0 || callerCanceled(ctx, err) {
+		return
+	}
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	urls := client.baseUrls()
+	if urls[f.index] != baseUrl {
+		return
+	}
+	if err == nil {
+		f.failures = 0
+		return
+	}
+	f.failures++
+	if f.failures >= client.FailoverThreshold {
+		f.index = (f.index + 1) % len(urls)
+		f.failures = 0
+		f.since = time.Now()
+		client.logf("[WARNING] Failing over from base URL %s to %s", baseUrl, urls[f.index])
+	}
+}
+
+// callerCanceled reports whether a request failed because its context was canceled or its deadline exceeded.
+func callerCanceled(ctx context.Context, err error) bool {
+	return ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// rebase replaces the base URL of a request URL with another base URL.
+func (client *Client) rebase(u *url.URL, baseUrl string) *url.URL {
+	s := u.String()
+	for _, b := range client.baseUrls() {
+		if strings.HasPrefix(s, b) {
+			if rebased, err := url.Parse(baseUrl + strings.TrimPrefix(s, b)); err == nil {
+				return rebased
+			}
+		}
+	}
+	return u
+}
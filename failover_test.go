@@ -0,0 +1,68 @@
+package meraki
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestFailoverUrls tests the FailoverUrls modifier.
+func TestFailoverUrls(t *testing.T) {
+	defer gock.Off()
+	client, _ := NewClient("abc123", MaxRetries(1), BackoffMinDelay(0), BackoffMaxDelay(0),
+		FailoverUrls("https://backup.example.com/api/v1"), FailoverThreshold(1), FailbackInterval(time.Hour))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(client.BaseUrl).Get("/organizations").ReplyError(errors.New("connection refused"))
+	gock.New("https://backup.example.com/api/v1").Get("/organizations").Reply(200).BodyString(`[]`)
+	req := client.NewReq("GET", "/organizations", nil)
+	_, err := client.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, client.BaseUrl+"/organizations", req.HttpReq.URL.String())
+
+	// Subsequent requests use the failover URL
+	gock.New("https://backup.example.com/api/v1").Get("/networks/N_1").Reply(200).BodyString(`{}`)
+	_, err = client.Get("/networks/N_1")
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+
+	// Fail back after the interval
+	client.failover.since = time.Now().Add(-2 * time.Hour)
+	assert.Equal(t, client.BaseUrl, client.activeBaseUrl())
+	client.reportConn(context.Background(), client.BaseUrl, errors.New("connection refused"))
+	assert.Equal(t, "https://backup.example.com/api/v1", client.activeBaseUrl())
+}
+
+// TestFailoverCanceled tests that requests canceled by the caller do not fail over.
+func TestFailoverCanceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MaxRetries(1), BackoffMinDelay(0), BackoffMaxDelay(0),
+		FailoverUrls("https://backup.example.com/api/v1"), FailoverThreshold(1))
+
+	for i := 0; i < 3; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		_, err := client.GetContext(ctx, "/organizations")
+		cancel()
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	}
+	assert.Equal(t, server.URL, client.activeBaseUrl())
+}
+
+// TestFailoverPagination tests pagination with links pointing to a failover URL.
+func TestFailoverPagination(t *testing.T) {
+	client, _ := NewClient("abc123", FailoverUrls("https://backup.example.com/api/v1"))
+	header := map[string][]string{"Link": {`<https://backup.example.com/api/v1/url?startingAfter=2>; rel="next"`}}
+	next, found, err := client.nextPage(header)
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "/url?startingAfter=2", next)
+}
@@ -0,0 +1,114 @@
+package meraki
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/juju/ratelimit"
+)
+
+// HedgeDelay enables request hedging of GET requests: if a GET request did not complete after
+// the delay, a second identical request is sent and the response of whichever request succeeds
+// first is returned, while the other one is canceled, e.g.
+//
+//	client, _ := NewClient("abc123", HedgeDelay(2*time.Second))
+//
+// Only the HTTP request of an attempt is hedged: the delay starts after the rate limiter wait,
+// and a hedged request is only sent if rate limiter tokens are available without waiting.
+// Retries of rate limited requests are not hedged. Hedging cuts the tail latency of read-heavy
+// tools at the cost of additional requests. A good delay is about the p95 latency of the
+// requests, see Stats.Latency. Default value is 0, which disables hedging.
+func HedgeDelay(x time.Duration) func(*Client) {
+	return func(client *Client) {
+		client.HedgeDelay = x
+	}
+}
+
+type hedgeResult struct {
+	res *http.Response
+	err error
+	i   int
+}
+
+// hedgeBody cancels the context of a hedged request when its response body is closed.
+type hedgeBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close implements the io.Closer interface.
+func (b hedgeBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// send sends the HTTP request of a request attempt. GET requests are hedged, see HedgeDelay,
+// if hedge is true and rate limiter tokens are available without waiting. Rate limiter waits
+// and retries of the attempt are not hedged.
+func (client *Client) send(httpClient *http.Client, req Req, bucket *ratelimit.Bucket, cost int64, hedge bool) (*http.Response, error) {
+	if !hedge || client.HedgeDelay <= 0 || req.HttpReq.Method != "GET" {
+		return httpClient.Do(req.HttpReq)
+	}
+	results := make(chan hedgeResult, 2)
+	cancels := make([]context.CancelFunc, 0, 2)
+	send := func(r *http.Request) {
+		ctx, cancel := context.WithCancel(r.Context())
+		r = r.WithContext(ctx)
+		i := len(cancels)
+		cancels = append(cancels, cancel)
+		go func() {
+			res, err := httpClient.Do(r)
+			results <- hedgeResult{res, err, i}
+		}()
+	}
+	send(req.HttpReq)
+	timer := time.NewTimer(client.HedgeDelay)
+	defer timer.Stop()
+	pending := 1
+	var result hedgeResult
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			if _, ok := bucket.TakeMaxDuration(client.adaptiveCost(cost), 0); !ok {
+				client.logf("[DEBUG] HTTP Request slower than %v, no rate limiter tokens for a hedged request: %s", client.HedgeDelay, req.HttpReq.URL)
+				continue
+			}
+			client.logf("[DEBUG] HTTP Request slower than %v, sending hedged request: %s", client.HedgeDelay, req.HttpReq.URL)
+			client.stats.hedges.Add(1)
+			hedged := req.HttpReq.Clone(req.HttpReq.Context())
+			if req.HttpReq.GetBody != nil {
+				hedged.Body, _ = req.HttpReq.GetBody()
+			}
+			send(hedged)
+			pending++
+		case result = <-results:
+			pending--
+			if result.err != nil {
+				// the hedged request is not sent if the first request fails before the delay
+				continue
+			}
+			// cancel the other request and close its response, if any
+			for i, cancel := range cancels {
+				if i != result.i {
+					cancel()
+				}
+			}
+			if pending > 0 {
+				go func() {
+					if r := <-results; r.err == nil {
+						r.res.Body.Close()
+					}
+				}()
+			}
+			result.res.Body = hedgeBody{result.res.Body, cancels[result.i]}
+			return result.res, nil
+		}
+	}
+	for _, cancel := range cancels {
+		cancel()
+	}
+	return result.res, result.err
+}
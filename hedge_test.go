@@ -0,0 +1,88 @@
+package meraki
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestHedgeDelay tests the HedgeDelay modifier.
+func TestHedgeDelay(t *testing.T) {
+	var count atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if count.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(time.Second):
+			}
+			w.Write([]byte(`{"attempt":1}`))
+			return
+		}
+		w.Write([]byte(`{"attempt":2}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), Transport(server.Client().Transport), HedgeDelay(50*time.Millisecond))
+	start := time.Now()
+	res, err := client.Get("/url")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), res.Get("attempt").Int())
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+	assert.Equal(t, int64(1), client.Stats().Hedges)
+
+	// Fast requests and writes are not hedged
+	res, err = client.Get("/url")
+	assert.NoError(t, err)
+	_, err = client.Post("/url", `{}`)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), count.Load())
+	assert.Equal(t, int64(1), client.Stats().Hedges)
+}
+
+// TestHedgeDelayAttempt tests that only the HTTP request of an attempt is hedged.
+func TestHedgeDelayAttempt(t *testing.T) {
+	var count atomic.Int64
+	var slow atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := count.Add(1)
+		if r.URL.Path == "/ratelimited" && n == 1 {
+			w.Header().Set("Retry-After", "0.01")
+			w.WriteHeader(429)
+			return
+		}
+		if slow.Load() {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	// Rate limiter waits are not hedged
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MaxRetries(1), Transport(server.Client().Transport), HedgeDelay(50*time.Millisecond), RequestPerSecond(5), Burst(1))
+	_, err := client.Get("/url")
+	assert.NoError(t, err)
+	_, err = client.Get("/url")
+	assert.NoError(t, err)
+	assert.Greater(t, client.Stats().RateLimitWait, 100*time.Millisecond)
+	assert.Equal(t, int64(0), client.Stats().Hedges)
+
+	// Requests are not hedged without rate limiter tokens
+	slow.Store(true)
+	client, _ = NewClient("abc123", BaseUrl(server.URL), MaxRetries(1), Transport(server.Client().Transport), HedgeDelay(50*time.Millisecond), RequestPerSecond(1))
+	_, err = client.Get("/url")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), client.Stats().Hedges)
+
+	// Retries of rate limited requests are not hedged
+	count.Store(0)
+	client, _ = NewClient("abc123", BaseUrl(server.URL), MaxRetries(1), Transport(server.Client().Transport), HedgeDelay(50*time.Millisecond), BackoffMinDelay(0), BackoffMaxDelay(0))
+	_, err = client.Get("/ratelimited")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count.Load())
+	assert.Equal(t, int64(0), client.Stats().Hedges)
+}
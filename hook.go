@@ -0,0 +1,80 @@
+package meraki
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// HookError is the error of a hook or callback which panicked, e.g. an OnProgress callback or an AuditSink.
+// Panics of hooks are recovered, so a misbehaving observer never takes down the request path.
+type HookError struct {
+	// Hook is the name of the hook, e.g. OnProgress
+	Hook string
+	// Value is the value passed to panic
+	Value interface{}
+	// Stack is the stack trace of the panic
+	Stack []byte
+}
+
+// Error implements the error interface.
+func (e *HookError) Error() string {
+	return fmt.Sprintf("hook %s panicked: %v", e.Hook, e.Value)
+}
+
+// OnHookError registers a callback called with the HookError of every hook which panicked, e.g.
+//
+//	client, _ := NewClient("abc123", OnHookError(func(err error) {
+//		log.Printf("%s\n%s", err, err.(*HookError).Stack)
+//	}))
+func OnHookError(fn func(err error)) func(*Client) {
+	return func(client *Client) {
+		client.OnHookError = fn
+	}
+}
+
+// runHook calls a hook and converts a panic into a HookError attributed to the hook.
+func (client *Client) runHook(name string, fn func()) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			hookErr := &HookError{Hook: name, Value: v, Stack: debug.Stack()}
+			client.hookError(hookErr)
+			err = hookErr
+		}
+	}()
+	fn()
+	return nil
+}
+
+// runHooks calls all hooks, even if some of them panic, and returns the errors of all failed hooks joined together.
+func (client *Client) runHooks(name string, fns ...func()) error {
+	errs := make([]error, 0)
+	for i, fn := range fns {
+		if err := client.runHook(fmt.Sprintf("%s[%d]", name, i), fn); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// hookError reports the error of a failed hook.
+func (client *Client) hookError(err *HookError) {
+	client.stats.hookErrors.Add(1)
+	client.logf("[ERROR] %s", err)
+	if client.OnHookError == nil {
+		return
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			client.logf("[ERROR] hook OnHookError panicked: %v", v)
+		}
+	}()
+	client.OnHookError(err)
+}
+
+// progress calls the OnProgress callback of a request.
+func (client *Client) progress(req Req, progress Progress) {
+	if req.OnProgress != nil {
+		client.runHook("OnProgress", func() { req.OnProgress(progress) })
+	}
+}
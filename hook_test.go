@@ -0,0 +1,53 @@
+package meraki
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+type panicAuditSink struct{}
+
+func (panicAuditSink) Audit(record AuditRecord) {
+	panic("sink failure")
+}
+
+// TestRunHooks tests the panic recovery and aggregation of hooks.
+func TestRunHooks(t *testing.T) {
+	client := testClient()
+	hookErrs := make([]error, 0)
+	OnHookError(func(err error) { hookErrs = append(hookErrs, err) })(&client)
+
+	called := 0
+	err := client.runHooks("test", func() { called++ }, func() { panic("boom") }, func() { called++ })
+	assert.Equal(t, 2, called)
+	var hookErr *HookError
+	assert.True(t, errors.As(err, &hookErr))
+	assert.Equal(t, "test[1]", hookErr.Hook)
+	assert.Equal(t, "hook test[1] panicked: boom", hookErr.Error())
+	assert.NotEmpty(t, hookErr.Stack)
+	assert.Len(t, hookErrs, 1)
+	assert.Equal(t, int64(1), client.Stats().HookErrors)
+	assert.NoError(t, client.runHooks("test", func() {}))
+}
+
+// TestHookPanicRequest tests that panicking hooks do not fail requests.
+func TestHookPanicRequest(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+	Audit(panicAuditSink{})(&client)
+	OnHookError(func(err error) { panic("handler failure") })(&client)
+
+	gock.New(client.BaseUrl).Put("/networks/N_1").Reply(200).BodyString(`{"id":"N_1"}`)
+	res, err := client.Put("/networks/N_1", `{}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "N_1", res.Get("id").String())
+
+	gock.New(client.BaseUrl).Get("/networks").Reply(200).BodyString(`[{"id":"N_1"}]`)
+	res, err = client.Get("/networks", OnProgress(func(p Progress) { panic("progress failure") }))
+	assert.NoError(t, err)
+	assert.Len(t, res.Array(), 1)
+	assert.Equal(t, int64(2), client.Stats().HookErrors)
+}
@@ -0,0 +1,73 @@
+//go:build go1.23
+
+package meraki
+
+import (
+	"iter"
+
+	"github.com/tidwall/gjson"
+)
+
+// Items returns an iterator over the items of a paginated collection. Pages are
+// fetched lazily while iterating and no further pages are fetched once the loop
+// is left, e.g.
+//
+//	for item, err := range client.Items("/organizations/123/devices") {
+//		if err != nil {
+//			return err
+//		}
+//		println(item.Get("serial").String())
+//	}
+//
+// An error is yielded at most once and ends the iteration.
+func (client *Client) Items(path string, mods ...func(*Req)) iter.Seq2[gjson.Result, error] {
+	return func(yield func(gjson.Result, error) bool) {
+		progress := Progress{}
+		pages := client.newPager(mods)
+		defer pages.close()
+		for {
+			req, res, err := pages.fetch(path)
+			if err != nil {
+				yield(gjson.Result{}, err)
+				return
+			}
+			progress.update(res)
+			client.progress(req, progress)
+			items := res.Result
+			if items.Get("items").Exists() {
+				items = items.Get("items")
+			}
+			for _, item := range items.Array() {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			next, ok, err := client.nextPage(res.Header)
+			if err != nil {
+				yield(gjson.Result{}, err)
+				return
+			}
+			if !ok {
+				return
+			}
+			path = next
+		}
+	}
+}
+
+// ItemsOf is like Client.Items, but unmarshals every item into a value of type T, e.g.
+//
+//	for network, err := range meraki.ItemsOf[Network](&client, "/organizations/123/networks") {
+func ItemsOf[T any](client *Client, path string, mods ...func(*Req)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for item, err := range client.Items(path, mods...) {
+			var v T
+			if err == nil {
+				err = Res{Result: item, useNumber: client.PreserveNumbers}.Unmarshal(&v)
+			}
+			if !yield(v, err) || err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,114 @@
+//go:build go1.23
+
+package meraki
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestClientItems tests the Client::Items method.
+func TestClientItems(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Get("/url").
+		Reply(200).
+		BodyString(`["1","2"]`).
+		Header.Set("Link", `<`+client.BaseUrl+`/url?offset=3>; rel="next"`)
+	gock.New(client.BaseUrl).Get("/url").MatchParam("offset", "3").
+		Reply(200).
+		BodyString(`["3"]`)
+
+	items := make([]string, 0)
+	for item, err := range client.Items("/url") {
+		assert.NoError(t, err)
+		items = append(items, item.String())
+	}
+	assert.Equal(t, []string{"1", "2", "3"}, items)
+
+	// Early break does not fetch further pages
+	gock.New(client.BaseUrl).Get("/url").
+		Reply(200).
+		BodyString(`{"items":["1","2"]}`).
+		Header.Set("Link", `<`+client.BaseUrl+`/url?offset=3>; rel="next"`)
+	for item := range client.Items("/url") {
+		assert.Equal(t, "1", item.String())
+		break
+	}
+	assert.True(t, gock.IsDone())
+
+	// Error
+	gock.New(client.BaseUrl).Get("/url").Reply(404)
+	for _, err := range client.Items("/url") {
+		assert.Error(t, err)
+	}
+}
+
+// TestItemsOf tests the ItemsOf function.
+func TestItemsOf(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Get("/url").Reply(200).BodyString(`[{"serial":"A"},{"serial":1}]`)
+
+	type device struct {
+		Serial string `json:"serial"`
+	}
+	serials := make([]string, 0)
+	var lastErr error
+	for d, err := range ItemsOf[device](&client, "/url") {
+		lastErr = err
+		serials = append(serials, d.Serial)
+	}
+	assert.Equal(t, []string{"A", ""}, serials)
+	assert.Error(t, lastErr)
+}
+
+// TestItemsPrefetch tests the Prefetch modifier with cursor based pagination.
+func TestItemsPrefetch(t *testing.T) {
+	var requests atomic.Int64
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Query().Get("startingAfter") {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf("<%s/clients?startingAfter=b>; rel=\"next\"", server.URL))
+			w.Write([]byte(`[{"id":"a"},{"id":"b"}]`))
+		case "b":
+			w.Header().Set("Link", fmt.Sprintf("<%s/clients?startingAfter=d>; rel=\"next\"", server.URL))
+			w.Write([]byte(`[{"id":"c"},{"id":"d"}]`))
+		default:
+			w.Write([]byte(`[{"id":"e"}]`))
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), Transport(server.Client().Transport))
+	ids := ""
+	for item, err := range client.Items("/clients", Prefetch(1)) {
+		assert.NoError(t, err)
+		if ids == "" {
+			// The next page is fetched while the first page is processed
+			assert.Eventually(t, func() bool { return requests.Load() == 2 }, time.Second, time.Millisecond)
+		}
+		ids += item.Get("id").String()
+	}
+	assert.Equal(t, "abcde", ids)
+	assert.Equal(t, int64(3), requests.Load())
+
+	// Leaving the loop early cancels the prefetched page and does not fetch further pages
+	requests.Store(0)
+	for range client.Items("/clients", Prefetch(1)) {
+		break
+	}
+	time.Sleep(20 * time.Millisecond)
+	assert.LessOrEqual(t, requests.Load(), int64(2))
+}
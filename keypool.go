@@ -0,0 +1,174 @@
+package meraki
+
+import (
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/juju/ratelimit"
+)
+
+// DefaultKeyFailureThreshold is the default number of consecutive key failures, see
+// isKeyFailure, after which an API key of a key pool is considered unhealthy.
+const DefaultKeyFailureThreshold int = 3
+
+// apiKey is an API key of a key pool with its own rate limiter bucket.
+type apiKey struct {
+	token  string
+	bucket *ratelimit.Bucket
+	// Consecutive key failures, see isKeyFailure
+	failures int
+	// Unhealthy keys are skipped as long as healthy keys are available
+	unhealthy bool
+}
+
+// keyPool is a pool of API keys. It is shared by all copies of a client.
+type keyPool struct {
+	mutex sync.Mutex
+	keys  []*apiKey
+}
+
+// ApiKeys adds API keys to a pool of keys used in addition to the API token of the client, e.g.
+//
+//	client, _ := NewClient("abc123", ApiKeys("def456", "ghi789"))
+//
+// Meraki throttles requests per key and organization, therefore every key gets its own
+// rate limiter bucket with the rate of RequestPerSecond and requests are sent with the
+// key with the most available tokens, which increases the total throughput. Keys failing
+// with persistent 401 responses, or 403 responses reporting an invalid key, are skipped, see
+// OnKeyUnhealthy. Other 403 responses, e.g. a key without access to an organization, fail
+// the request without trying other keys.
+func ApiKeys(tokens ...string) func(*Client) {
+	return func(client *Client) {
+		client.ApiKeys = append(client.ApiKeys, tokens...)
+	}
+}
+
+// KeyFailureThreshold modifies the number of consecutive 401 responses, or 403 responses
+// reporting an invalid key, after which an API key of a key pool is considered unhealthy
+// from the default of 3.
+func KeyFailureThreshold(x int) func(*Client) {
+	return func(client *Client) {
+		client.KeyFailureThreshold = x
+	}
+}
+
+// OnKeyUnhealthy registers a callback called when an API key of a key pool is considered
+// unhealthy, e.g. revoked during a key rotation. Requests transparently continue with the
+// remaining keys. The key is passed masked, e.g. ****c123.
+func OnKeyUnhealthy(fn func(maskedKey string, statusCode int)) func(*Client) {
+	return func(client *Client) {
+		client.OnKeyUnhealthy = fn
+	}
+}
+
+// newKeyPool creates a key pool of the API token of the client using the client bucket and
+// the additional API keys with new buckets of the same rate.
+func (client *Client) newKeyPool() *keyPool {
+	if len(client.ApiKeys) == 0 {
+		return nil
+	}
+	pool := &keyPool{keys: []*apiKey{{token: client.currentToken(), bucket: client.RateLimiterBucket}}}
+	capacity := client.RateLimiterBucket.Capacity()
+	rate := int64(math.Round(client.RateLimiterBucket.Rate()))
+	for _, token := range client.ApiKeys {
+		pool.keys = append(pool.keys, &apiKey{
+			token:  token,
+			bucket: newBucket(rate, capacity),
+		})
+	}
+	return pool
+}
+
+// apiKey returns the token and rate limiter bucket for the next request attempt, which is
+// the key with the most available tokens in key pool mode. Healthy keys are preferred over
+// unhealthy ones and keys without over keys with recent key failures.
+func (client *Client) apiKey() (string, *ratelimit.Bucket) {
+	if client.keyPool == nil {
+		return client.currentToken(), client.RateLimiterBucket
+	}
+	client.keyPool.mutex.Lock()
+	defer client.keyPool.mutex.Unlock()
+	best := client.keyPool.keys[0]
+	for _, key := range client.keyPool.keys[1:] {
+		switch {
+		case key.unhealthy != best.unhealthy:
+			if !key.unhealthy {
+				best = key
+			}
+		case key.failures != best.failures:
+			if key.failures < best.failures {
+				best = key
+			}
+		case key.bucket.Available() > best.bucket.Available():
+			best = key
+		}
+	}
+	return best.token, best.bucket
+}
+
+// isKeyFailure reports whether a response indicates an invalid or revoked API key, which is
+// a 401 response or a 403 response whose errors report an invalid key. Other 403 responses
+// deny access to a resource with a valid key.
+func isKeyFailure(statusCode int, res Res) bool {
+	if statusCode == 401 {
+		return true
+	}
+	if statusCode != 403 {
+		return false
+	}
+	for _, e := range res.Get("errors").Array() {
+		msg := strings.ToLower(e.String())
+		if strings.Contains(msg, "api key") && (strings.Contains(msg, "invalid") || strings.Contains(msg, "revoked") || strings.Contains(msg, "expired")) {
+			return true
+		}
+	}
+	return false
+}
+
+// reportKey records the response to a request sent with a key of the key pool. It returns
+// true if the request failed with a key failure, see isKeyFailure, and should be retried
+// with another key.
+func (client *Client) reportKey(token string, statusCode int, res Res) bool {
+	if client.keyPool == nil {
+		return false
+	}
+	authFailure := isKeyFailure(statusCode, res)
+	threshold := client.KeyFailureThreshold
+	if threshold < 1 {
+		threshold = DefaultKeyFailureThreshold
+	}
+	client.keyPool.mutex.Lock()
+	var failed *apiKey
+	healthy := 0
+	for _, key := range client.keyPool.keys {
+		if key.token == token {
+			failed = key
+		} else if !key.unhealthy {
+			healthy++
+		}
+	}
+	if failed == nil {
+		client.keyPool.mutex.Unlock()
+		return false
+	}
+	if !authFailure {
+		failed.failures = 0
+		failed.unhealthy = false
+		client.keyPool.mutex.Unlock()
+		return false
+	}
+	failed.failures++
+	newlyUnhealthy := !failed.unhealthy && failed.failures >= threshold
+	if newlyUnhealthy {
+		failed.unhealthy = true
+	}
+	client.keyPool.mutex.Unlock()
+	if newlyUnhealthy {
+		client.logf("[ERROR] API key %s unhealthy after %d consecutive %d responses", maskToken(token), failed.failures, statusCode)
+		if client.OnKeyUnhealthy != nil {
+			client.runHook("OnKeyUnhealthy", func() { client.OnKeyUnhealthy(maskToken(token), statusCode) })
+		}
+	}
+	return healthy > 0
+}
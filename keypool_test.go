@@ -0,0 +1,117 @@
+package meraki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestApiKeys tests the ApiKeys modifier.
+func TestApiKeys(t *testing.T) {
+	defer gock.Off()
+	client, _ := NewClient("abc123", MaxRetries(0), RequestPerSecond(5), ApiKeys("def456"))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(client.BaseUrl).Get("/url").MatchHeader("Authorization", "Bearer abc123").Reply(200)
+	gock.New(client.BaseUrl).Get("/url").MatchHeader("Authorization", "Bearer def456").Reply(200)
+	gock.New(client.BaseUrl).Get("/url").MatchHeader("Authorization", "Bearer abc123").Reply(200)
+	for i := 0; i < 3; i++ {
+		_, err := client.Get("/url")
+		assert.NoError(t, err)
+	}
+	assert.True(t, gock.IsDone())
+
+	stats := client.Stats()
+	assert.Equal(t, float64(10), stats.RequestPerSecond)
+	assert.LessOrEqual(t, stats.AvailableTokens, int64(7))
+
+	// Every organization of a scheduler gets its own buckets
+	scheduler := NewScheduler(&client, OrgRequestPerSecond(2))
+	orgClient := scheduler.orgClient(context.Background(), nil)
+	assert.Len(t, orgClient.keyPool.keys, 2)
+	assert.Equal(t, int64(2), orgClient.keyPool.keys[1].bucket.Capacity())
+}
+
+// TestKeyFailover tests the failover of revoked API keys of a key pool.
+func TestKeyFailover(t *testing.T) {
+	defer gock.Off()
+	unhealthy := make([]string, 0)
+	client, _ := NewClient("abc123", MaxRetries(0), ApiKeys("def456"), KeyFailureThreshold(1),
+		OnKeyUnhealthy(func(maskedKey string, statusCode int) {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s %d", maskedKey, statusCode))
+		}))
+	gock.InterceptClient(client.HttpClient)
+
+	// The revoked key is unhealthy, requests continue with the other key
+	gock.New(client.BaseUrl).Get("/url").MatchHeader("Authorization", "Bearer abc123").Reply(401)
+	gock.New(client.BaseUrl).Get("/url").MatchHeader("Authorization", "Bearer def456").Times(3).Reply(200)
+	for i := 0; i < 3; i++ {
+		_, err := client.Get("/url")
+		assert.NoError(t, err)
+	}
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, []string{"****c123 401"}, unhealthy)
+
+	// If all keys are unhealthy, the error is returned
+	gock.New(client.BaseUrl).Get("/url").MatchHeader("Authorization", "Bearer def456").Reply(403).BodyString(`{"errors":["Invalid API key"]}`)
+	_, err := client.Get("/url")
+	assert.Error(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, []string{"****c123 401", "****f456 403"}, unhealthy)
+
+	// A successful response makes a key healthy again
+	gock.New(client.BaseUrl).Get("/url").MatchHeader("Authorization", "Bearer abc123").Reply(200)
+	_, err = client.Get("/url")
+	assert.NoError(t, err)
+	assert.False(t, client.keyPool.keys[0].unhealthy)
+
+	// A single key never fails over
+	client = testClient()
+	assert.False(t, client.reportKey("abc123", 401, Res{}))
+}
+
+// TestKeyFailoverRetries tests that key failovers do not count as retries.
+func TestKeyFailoverRetries(t *testing.T) {
+	defer gock.Off()
+	client, _ := NewClient("abc123", MaxRetries(1), BackoffMinDelay(0), BackoffMaxDelay(0), ApiKeys("def456"), KeyFailureThreshold(1))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(client.BaseUrl).Get("/url").MatchHeader("Authorization", "Bearer abc123").Reply(401)
+	gock.New(client.BaseUrl).Get("/url").MatchHeader("Authorization", "Bearer def456").Reply(500)
+	gock.New(client.BaseUrl).Get("/url").MatchHeader("Authorization", "Bearer def456").Reply(200)
+	_, err := client.Get("/url")
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+
+	stats := client.Stats()
+	assert.Equal(t, int64(3), stats.Requests)
+	assert.Equal(t, int64(1), stats.Retries)
+	assert.Equal(t, int64(1), stats.ServerErrorRetries)
+}
+
+// TestKeyPermissionDenied tests that 403 responses denying access do not fail over keys.
+func TestKeyPermissionDenied(t *testing.T) {
+	defer gock.Off()
+	unhealthy := 0
+	client, _ := NewClient("abc123", MaxRetries(0), ApiKeys("def456"), KeyFailureThreshold(1),
+		OnKeyUnhealthy(func(maskedKey string, statusCode int) { unhealthy++ }))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(client.BaseUrl).Get("/organizations/1").Times(2).Reply(403).BodyString(`{"errors":["You don't have permission to access this organization"]}`)
+	for i := 0; i < 2; i++ {
+		_, err := client.Get("/organizations/1")
+		var apiErr *ApiError
+		assert.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, 403, apiErr.StatusCode)
+	}
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, 0, unhealthy)
+	for _, key := range client.keyPool.keys {
+		assert.False(t, key.unhealthy)
+		assert.Equal(t, 0, key.failures)
+	}
+}
@@ -0,0 +1,57 @@
+package meraki
+
+import (
+	"context"
+	"maps"
+	"sort"
+	"strings"
+)
+
+type labelsKey struct{}
+
+// WithLabels returns a copy of ctx carrying labels, e.g. a tenant or trace ID, given as
+// key value pairs. Labels of requests made with the context are passed to audit records
+// and are added to log messages, so every API call can be attributed, e.g.
+//
+//	ctx := meraki.WithLabels(ctx, "tenant", "a", "trace", traceID)
+//	client.PutContext(ctx, "/networks/N_123", body)
+//
+// Labels already present in ctx are kept unless overwritten.
+func WithLabels(ctx context.Context, kv ...string) context.Context {
+	labels := maps.Clone(LabelsFromContext(ctx))
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	for i := 0; i+1 < len(kv); i += 2 {
+		labels[kv[i]] = kv[i+1]
+	}
+	return context.WithValue(ctx, labelsKey{}, labels)
+}
+
+// LabelsFromContext returns the labels of a context, nil if there are none. The map must not be modified.
+func LabelsFromContext(ctx context.Context) map[string]string {
+	labels, _ := ctx.Value(labelsKey{}).(map[string]string)
+	return labels
+}
+
+// Labels returns the labels of the request context, see WithLabels.
+func (req Req) Labels() map[string]string {
+	if req.HttpReq == nil {
+		return nil
+	}
+	return LabelsFromContext(req.HttpReq.Context())
+}
+
+// labelString formats the labels of a request for log messages, e.g. " [tenant=a trace=b]".
+func (req Req) labelString() string {
+	labels := req.Labels()
+	if len(labels) == 0 {
+		return ""
+	}
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return " [" + strings.Join(pairs, " ") + "]"
+}
@@ -0,0 +1,41 @@
+package meraki
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+type testAuditSink []AuditRecord
+
+func (sink *testAuditSink) Audit(record AuditRecord) {
+	*sink = append(*sink, record)
+}
+
+// TestWithLabels tests the WithLabels and LabelsFromContext functions.
+func TestWithLabels(t *testing.T) {
+	ctx := WithLabels(context.Background(), "tenant", "a", "trace", "1")
+	child := WithLabels(ctx, "trace", "2")
+	assert.Equal(t, map[string]string{"tenant": "a", "trace": "1"}, LabelsFromContext(ctx))
+	assert.Equal(t, map[string]string{"tenant": "a", "trace": "2"}, LabelsFromContext(child))
+	assert.Nil(t, LabelsFromContext(context.Background()))
+}
+
+// TestReqLabels tests the propagation of labels to audit records.
+func TestReqLabels(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+	sink := &testAuditSink{}
+	Audit(sink)(&client)
+
+	gock.New(client.BaseUrl).Put("/networks/N_1").Reply(200).BodyString(`{}`)
+	req := client.NewReq("PUT", "/networks/N_1", strings.NewReader(`{}`))
+	req.HttpReq = req.HttpReq.WithContext(WithLabels(context.Background(), "tenant", "a", "trace", "1"))
+	assert.Equal(t, " [tenant=a trace=1]", req.labelString())
+	_, err := client.Do(req)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"tenant": "a", "trace": "1"}, (*sink)[0].Labels)
+}
@@ -0,0 +1,143 @@
+package meraki
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// latencySamples is the number of most recent durations kept per path pattern.
+const latencySamples int = 1000
+
+// LatencyThreshold is the latency threshold of requests to matching paths.
+type LatencyThreshold struct {
+	// Pattern is a path pattern, where * matches a single path segment and a trailing /** any number of segments
+	Pattern string
+	// Threshold is the duration after which a request is considered slow, 0 to only track latencies
+	Threshold time.Duration
+}
+
+// LatencyStats are the latency percentiles of the most recent requests of a path pattern.
+type LatencyStats struct {
+	// Count is the total number of requests
+	Count int64 `json:"count"`
+	// Slow is the total number of requests exceeding the threshold
+	Slow int64 `json:"slow"`
+	// P50 is the median duration
+	P50 time.Duration `json:"p50"`
+	// P95 is the 95th percentile duration
+	P95 time.Duration `json:"p95"`
+	// P99 is the 99th percentile duration
+	P99 time.Duration `json:"p99"`
+}
+
+// latencyTracker records request durations per path pattern. It is shared by all copies of a client.
+type latencyTracker struct {
+	mutex    sync.Mutex
+	patterns map[string]*latencyPattern
+}
+
+type latencyPattern struct {
+	count   int64
+	slow    int64
+	samples []time.Duration
+	next    int
+}
+
+// SlowRequestThreshold tracks the latency of requests to a path pattern and logs a warning
+// for requests exceeding the threshold, including retries. The first matching pattern wins,
+// the percentiles are exposed by Stats, e.g.
+//
+//	client, _ := NewClient("abc123", SlowRequestThreshold("/organizations/*/devices/statuses", 10*time.Second))
+//	p95 := client.Stats().Latency["/organizations/*/devices/statuses"].P95
+func SlowRequestThreshold(pattern string, threshold time.Duration) func(*Client) {
+	return func(client *Client) {
+		client.LatencyThresholds = append(client.LatencyThresholds, LatencyThreshold{Pattern: pattern, Threshold: threshold})
+		if client.latency == nil {
+			client.latency = &latencyTracker{patterns: make(map[string]*latencyPattern)}
+		}
+	}
+}
+
+// OnSlowRequest registers a callback called for every request exceeding its latency threshold.
+func OnSlowRequest(fn func(req Req, duration time.Duration)) func(*Client) {
+	return func(client *Client) {
+		client.OnSlowRequest = fn
+	}
+}
+
+// observeLatency records the duration of a request to the first matching path pattern.
+func (client *Client) observeLatency(req Req, duration time.Duration) {
+	if client.latency == nil {
+		return
+	}
+	path := client.relPath(req.HttpReq.URL)
+	for _, t := range client.LatencyThresholds {
+		if !matchPathPattern(t.Pattern, path) {
+			continue
+		}
+		slow := t.Threshold > 0 && duration > t.Threshold
+		client.latency.record(t.Pattern, duration, slow)
+		if slow {
+			client.logf("[WARNING] HTTP Request slow: %s %s%s took %v, threshold %v", req.HttpReq.Method, req.HttpReq.URL, req.labelString(), duration, t.Threshold)
+			if client.OnSlowRequest != nil {
+				client.runHook("OnSlowRequest", func() { client.OnSlowRequest(req, duration) })
+			}
+		}
+		return
+	}
+}
+
+func (tracker *latencyTracker) record(pattern string, duration time.Duration, slow bool) {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+	p, ok := tracker.patterns[pattern]
+	if !ok {
+		p = &latencyPattern{samples: make([]time.Duration, 0, latencySamples)}
+		tracker.patterns[pattern] = p
+	}
+	p.count++
+	if slow {
+		p.slow++
+	}
+	if len(p.samples) < latencySamples {
+		p.samples = append(p.samples, duration)
+	} else {
+		p.samples[p.next] = duration
+		p.next = (p.next + 1) % latencySamples
+	}
+}
+
+// stats returns the latency percentiles of all path patterns.
+func (tracker *latencyTracker) stats() map[string]LatencyStats {
+	tracker.mutex.Lock()
+	defer tracker.mutex.Unlock()
+	stats := make(map[string]LatencyStats, len(tracker.patterns))
+	for pattern, p := range tracker.patterns {
+		samples := append([]time.Duration(nil), p.samples...)
+		sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+		stats[pattern] = LatencyStats{
+			Count: p.count,
+			Slow:  p.slow,
+			P50:   percentile(samples, 0.50),
+			P95:   percentile(samples, 0.95),
+			P99:   percentile(samples, 0.99),
+		}
+	}
+	return stats
+}
+
+// percentile returns the nearest rank percentile of sorted durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(p*float64(len(sorted))+0.5) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(sorted) {
+		i = len(sorted) - 1
+	}
+	return sorted[i]
+}
@@ -0,0 +1,57 @@
+package meraki
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSlowRequestThreshold tests the SlowRequestThreshold and OnSlowRequest modifiers.
+func TestSlowRequestThreshold(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/networks/N_2" {
+			time.Sleep(50 * time.Millisecond)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	slow := make([]string, 0)
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MaxRetries(0),
+		SlowRequestThreshold("/networks/*", 20*time.Millisecond),
+		SlowRequestThreshold("/organizations/**", 0),
+		OnSlowRequest(func(req Req, duration time.Duration) {
+			assert.Greater(t, duration, 20*time.Millisecond)
+			slow = append(slow, req.HttpReq.URL.Path)
+		}))
+
+	for _, path := range []string{"/networks/N_1", "/networks/N_2", "/organizations/1/networks", "/devices/Q"} {
+		_, err := client.Get(path)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, []string{"/networks/N_2"}, slow)
+
+	latency := client.Stats().Latency
+	assert.Len(t, latency, 2)
+	assert.Equal(t, int64(2), latency["/networks/*"].Count)
+	assert.Equal(t, int64(1), latency["/networks/*"].Slow)
+	assert.Greater(t, latency["/networks/*"].P99, 20*time.Millisecond)
+	assert.Less(t, latency["/networks/*"].P50, 20*time.Millisecond)
+	assert.Equal(t, int64(1), latency["/organizations/**"].Count)
+	assert.Equal(t, int64(0), latency["/organizations/**"].Slow)
+}
+
+// TestPercentile tests the percentile function.
+func TestPercentile(t *testing.T) {
+	samples := make([]time.Duration, 100)
+	for i := range samples {
+		samples[i] = time.Duration(i+1) * time.Millisecond
+	}
+	assert.Equal(t, 50*time.Millisecond, percentile(samples, 0.50))
+	assert.Equal(t, 95*time.Millisecond, percentile(samples, 0.95))
+	assert.Equal(t, 99*time.Millisecond, percentile(samples, 0.99))
+	assert.Equal(t, time.Duration(0), percentile(nil, 0.5))
+}
@@ -0,0 +1,216 @@
+package meraki
+
+import (
+	"container/list"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultLookupPaths are the path patterns of identity style lookups cached by LookupCache.
+var DefaultLookupPaths = []string{
+	"/organizations",
+	"/organizations/*",
+	"/networks/*",
+	"/devices/*",
+}
+
+// lookupCache is a LRU cache of GET responses of identity style lookups, see LookupCache,
+// or of all GET responses, see ResponseCache.
+type lookupCache struct {
+	mutex    sync.Mutex
+	size     int
+	ttl      time.Duration
+	patterns []string
+	entries  map[string]*list.Element
+	order    *list.List
+	hits     atomic.Int64
+	misses   atomic.Int64
+}
+
+type lookupEntry struct {
+	key     string
+	path    string
+	res     Res
+	expires time.Time
+}
+
+// LookupCache enables a LRU cache with the given number of entries for identity style
+// lookups, e.g. the organization list, networks by ID or devices by serial.
+// Pass path patterns to replace DefaultLookupPaths, where * matches a single path segment.
+//
+// Cached entries are invalidated by any DELETE, POST or PUT request to the same path,
+// a parent path or a child path, e.g. a PUT to /networks/N_123 invalidates /networks/N_123.
+func LookupCache(size int, patterns ...string) func(*Client) {
+	return func(client *Client) {
+		if len(patterns) == 0 {
+			patterns = DefaultLookupPaths
+		}
+		client.lookupCache = &lookupCache{
+			size:     size,
+			patterns: patterns,
+			entries:  make(map[string]*list.Element),
+			order:    list.New(),
+		}
+	}
+}
+
+// ResponseCache enables a LRU cache with the given number of entries for all GET responses,
+// which expire after the TTL, e.g. for read-mostly dashboards:
+//
+//	client, _ := NewClient("abc123", ResponseCache(10000, 5*time.Minute))
+//
+// The response cache is independent of LookupCache, lookups are served from the lookup cache
+// first and do not expire. Like LookupCache, cached entries are invalidated by any DELETE, POST
+// or PUT request to the same path, a parent path or a child path, see InvalidateLookup. Changes
+// not made by the client are visible after the TTL at the latest. Use NoCache to skip the cache
+// for a request.
+func ResponseCache(size int, ttl time.Duration) func(*Client) {
+	return func(client *Client) {
+		client.responseCache = &lookupCache{
+			size:     size,
+			ttl:      ttl,
+			patterns: []string{"/**"},
+			entries:  make(map[string]*list.Element),
+			order:    list.New(),
+		}
+	}
+}
+
+// InvalidateLookup removes all cached lookups and responses of a path, its parent paths and
+// its child paths.
+func (client *Client) InvalidateLookup(path string) {
+	if client.lookupCache != nil {
+		client.lookupCache.invalidate(path)
+	}
+	if client.responseCache != nil {
+		client.responseCache.invalidate(path)
+	}
+}
+
+// lookup returns the cached response of a GET request.
+func (client *Client) lookup(req Req) (Res, bool) {
+	return client.lookupCache.lookup(req, client.relPath(req.HttpReq.URL))
+}
+
+// lookupResponse returns the response of a GET request cached by ResponseCache.
+func (client *Client) lookupResponse(req Req) (Res, bool) {
+	return client.responseCache.lookup(req, client.relPath(req.HttpReq.URL))
+}
+
+// storeLookup caches the response of a successful GET request or invalidates
+// cached lookups affected by a write request.
+func (client *Client) storeLookup(req Req, res Res, err error) {
+	client.lookupCache.store(req, client.relPath(req.HttpReq.URL), res, err)
+}
+
+// storeResponse caches the response of a successful GET request or invalidates
+// cached responses affected by a write request, see ResponseCache.
+func (client *Client) storeResponse(req Req, res Res, err error) {
+	client.responseCache.store(req, client.relPath(req.HttpReq.URL), res, err)
+}
+
+func (c *lookupCache) lookup(req Req, path string) (Res, bool) {
+	if c == nil || req.HttpReq.Method != "GET" || req.NoCache || !matchPathPatterns(c.patterns, path) {
+		return Res{}, false
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if e, ok := c.entries[req.HttpReq.URL.String()]; ok {
+		if entry := e.Value.(*lookupEntry); !entry.expires.IsZero() && time.Now().After(entry.expires) {
+			c.order.Remove(e)
+			delete(c.entries, entry.key)
+			c.misses.Add(1)
+			return Res{}, false
+		}
+		c.order.MoveToFront(e)
+		c.hits.Add(1)
+		return e.Value.(*lookupEntry).res, true
+	}
+	c.misses.Add(1)
+	return Res{}, false
+}
+
+func (c *lookupCache) store(req Req, path string, res Res, err error) {
+	if c == nil {
+		return
+	}
+	if req.HttpReq.Method != "GET" {
+		c.invalidate(path)
+		return
+	}
+	if err != nil || !matchPathPatterns(c.patterns, path) {
+		return
+	}
+	c.add(req.HttpReq.URL.String(), path, res)
+}
+
+func (c *lookupCache) add(key, path string, res Res) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	var expires time.Time
+	if c.ttl > 0 {
+		expires = time.Now().Add(c.ttl)
+	}
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*lookupEntry).res = res
+		e.Value.(*lookupEntry).expires = expires
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lookupEntry{key: key, path: path, res: res, expires: expires})
+	for c.size > 0 && c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lookupEntry).key)
+	}
+}
+
+func (c *lookupCache) invalidate(path string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for key, e := range c.entries {
+		p := e.Value.(*lookupEntry).path
+		if p == path || strings.HasPrefix(path, p+"/") || strings.HasPrefix(p, path+"/") {
+			c.order.Remove(e)
+			delete(c.entries, key)
+		}
+	}
+}
+
+// relPath returns the path of a request URL relative to the base URL.
+func (client *Client) relPath(u *url.URL) string {
+	base, err := url.Parse(client.BaseUrl)
+	if err != nil {
+		return u.Path
+	}
+	return strings.TrimPrefix(u.Path, strings.TrimSuffix(base.Path, "/"))
+}
+
+// matchPathPatterns reports whether a path matches any of the patterns, see matchPathPattern.
+func matchPathPatterns(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if matchPathPattern(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPathPattern reports whether a path matches a pattern, where * matches a
+// single path segment and a trailing /** matches any number of segments.
+func matchPathPattern(pattern, path string) bool {
+	p := strings.Split(strings.Trim(pattern, "/"), "/")
+	s := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range p {
+		if segment == "**" && i == len(p)-1 {
+			return true
+		}
+		if i >= len(s) || (segment != "*" && segment != s[i]) {
+			return false
+		}
+	}
+	return len(p) == len(s)
+}
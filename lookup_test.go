@@ -0,0 +1,128 @@
+package meraki
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestLookupCache tests the LookupCache modifier.
+func TestLookupCache(t *testing.T) {
+	defer gock.Off()
+	client, _ := NewClient("abc123", MaxRetries(0), LookupCache(2))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(client.BaseUrl).Get("/networks/N_1").Times(1).Reply(200).BodyString(`{"name":"a"}`)
+	res, err := client.Get("/networks/N_1")
+	assert.NoError(t, err)
+	res, err = client.Get("/networks/N_1")
+	assert.NoError(t, err)
+	assert.Equal(t, "a", res.Get("name").String())
+	assert.Equal(t, int64(1), client.Stats().LookupCacheHits)
+	assert.Equal(t, int64(1), client.Stats().LookupCacheMisses)
+
+	// Writes invalidate the cache
+	gock.New(client.BaseUrl).Put("/networks/N_1").Reply(200)
+	gock.New(client.BaseUrl).Get("/networks/N_1").Reply(200).BodyString(`{"name":"b"}`)
+	client.Put("/networks/N_1", `{"name":"b"}`)
+	res, _ = client.Get("/networks/N_1")
+	assert.Equal(t, "b", res.Get("name").String())
+
+	// Paths not matching the patterns are not cached
+	gock.New(client.BaseUrl).Get("/networks/N_1/devices").Times(2).Reply(200).BodyString(`[]`)
+	client.Get("/networks/N_1/devices")
+	client.Get("/networks/N_1/devices")
+	assert.True(t, gock.IsDone())
+
+	// Least recently used entries are evicted
+	gock.New(client.BaseUrl).Get("/devices/A").Reply(200)
+	gock.New(client.BaseUrl).Get("/devices/B").Reply(200)
+	gock.New(client.BaseUrl).Get("/networks/N_1").Reply(200).BodyString(`{"name":"c"}`)
+	client.Get("/devices/A")
+	client.Get("/devices/B")
+	res, _ = client.Get("/networks/N_1")
+	assert.Equal(t, "c", res.Get("name").String())
+
+	// Explicit invalidation
+	gock.New(client.BaseUrl).Get("/devices/A").Reply(200).BodyString(`{"serial":"A"}`)
+	client.InvalidateLookup("/devices")
+	res, _ = client.Get("/devices/A")
+	assert.Equal(t, "A", res.Get("serial").String())
+}
+
+// TestResponseCache tests the ResponseCache modifier.
+func TestResponseCache(t *testing.T) {
+	defer gock.Off()
+	client, _ := NewClient("abc123", MaxRetries(0), ResponseCache(10, 50*time.Millisecond))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(client.BaseUrl).Get("/networks/N_1/devices").Times(1).Reply(200).BodyString(`[{"serial":"A"}]`)
+	client.Get("/networks/N_1/devices")
+	res, err := client.Get("/networks/N_1/devices")
+	assert.NoError(t, err)
+	assert.Equal(t, "A", res.Get("0.serial").String())
+	assert.True(t, gock.IsDone())
+
+	// Writes to a parent path invalidate the cache
+	gock.New(client.BaseUrl).Put("/networks/N_1").Reply(200)
+	gock.New(client.BaseUrl).Get("/networks/N_1/devices").Times(1).Reply(200).BodyString(`[{"serial":"B"}]`)
+	client.Put("/networks/N_1", `{}`)
+	res, _ = client.Get("/networks/N_1/devices")
+	assert.Equal(t, "B", res.Get("0.serial").String())
+	assert.True(t, gock.IsDone())
+
+	// Entries expire
+	time.Sleep(60 * time.Millisecond)
+	gock.New(client.BaseUrl).Get("/networks/N_1/devices").Reply(200).BodyString(`[{"serial":"C"}]`)
+	res, _ = client.Get("/networks/N_1/devices")
+	assert.Equal(t, "C", res.Get("0.serial").String())
+	assert.True(t, gock.IsDone())
+}
+
+// TestLookupAndResponseCache tests the LookupCache and ResponseCache modifiers combined.
+func TestLookupAndResponseCache(t *testing.T) {
+	defer gock.Off()
+	client, _ := NewClient("abc123", MaxRetries(0), LookupCache(10), ResponseCache(10, 50*time.Millisecond))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(client.BaseUrl).Get("/networks/N_1").Times(1).Reply(200).BodyString(`{"name":"a"}`)
+	gock.New(client.BaseUrl).Get("/networks/N_1/devices").Times(1).Reply(200).BodyString(`[{"serial":"A"}]`)
+	client.Get("/networks/N_1")
+	client.Get("/networks/N_1/devices")
+	client.Get("/networks/N_1")
+	client.Get("/networks/N_1/devices")
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, int64(1), client.Stats().LookupCacheHits)
+	assert.Equal(t, int64(1), client.Stats().ResponseCacheHits)
+
+	// The TTL of the response cache does not apply to lookups
+	time.Sleep(60 * time.Millisecond)
+	gock.New(client.BaseUrl).Get("/networks/N_1/devices").Reply(200).BodyString(`[{"serial":"B"}]`)
+	res, _ := client.Get("/networks/N_1")
+	assert.Equal(t, "a", res.Get("name").String())
+	res, _ = client.Get("/networks/N_1/devices")
+	assert.Equal(t, "B", res.Get("0.serial").String())
+	assert.True(t, gock.IsDone())
+
+	// Writes invalidate both caches
+	gock.New(client.BaseUrl).Put("/networks/N_1").Reply(200)
+	gock.New(client.BaseUrl).Get("/networks/N_1").Reply(200).BodyString(`{"name":"c"}`)
+	gock.New(client.BaseUrl).Get("/networks/N_1/devices").Reply(200).BodyString(`[{"serial":"C"}]`)
+	client.Put("/networks/N_1", `{}`)
+	res, _ = client.Get("/networks/N_1")
+	assert.Equal(t, "c", res.Get("name").String())
+	res, _ = client.Get("/networks/N_1/devices")
+	assert.Equal(t, "C", res.Get("0.serial").String())
+	assert.True(t, gock.IsDone())
+}
+
+// TestMatchPathPattern tests the matchPathPattern function.
+func TestMatchPathPattern(t *testing.T) {
+	assert.True(t, matchPathPattern("/networks/*", "/networks/N_1"))
+	assert.False(t, matchPathPattern("/networks/*", "/networks/N_1/devices"))
+	assert.False(t, matchPathPattern("/networks/*", "/networks"))
+	assert.True(t, matchPathPattern("/networks/**", "/networks/N_1/devices"))
+	assert.True(t, matchPathPattern("/organizations", "/organizations/"))
+}
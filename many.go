@@ -0,0 +1,69 @@
+package meraki
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// GetResult is the result of a single path of GetMany.
+type GetResult struct {
+	// Path is the requested path
+	Path string
+	// Res is the response, including all pages
+	Res Res
+	// Err is the error of the request, nil if it succeeded
+	Err error
+}
+
+// GetResults is a list of GetResult in the order of the paths.
+type GetResults []GetResult
+
+// Err returns the errors of all failed paths joined together or nil if all requests succeeded.
+func (results GetResults) Err() error {
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", result.Path, result.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// GetMany sends GET requests of many paths using a pool of concurrency workers and returns
+// the results in the order of the paths. All requests share the rate limiter of the client,
+// so the concurrency limits the number of requests in flight but not the request rate. A
+// failed path does not abort the others, e.g.
+//
+//	results := client.GetMany([]string{"/networks/N_1/clients", "/networks/N_2/clients"}, 5)
+//	for _, result := range results {
+//		if result.Err != nil {
+//			log.Printf("%s: %s", result.Path, result.Err)
+//		}
+//	}
+//
+// Pass request modifiers to modify all requests, e.g. a Context to cancel the remaining requests.
+func (client *Client) GetMany(paths []string, concurrency int, mods ...func(*Req)) GetResults {
+	results := make(GetResults, len(paths))
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(concurrency, len(paths)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				res, err := client.Get(paths[i], mods...)
+				results[i] = GetResult{Path: paths[i], Res: res, Err: err}
+			}
+		}()
+	}
+	for i := range paths {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
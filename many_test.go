@@ -0,0 +1,47 @@
+package meraki
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGetMany tests the Client::GetMany method.
+func TestGetMany(t *testing.T) {
+	var active, peak atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := active.Add(1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		time.Sleep(10 * time.Millisecond)
+		active.Add(-1)
+		if r.URL.Path == "/networks/N_3" {
+			w.WriteHeader(404)
+			return
+		}
+		w.Write([]byte(`{"id":"` + r.URL.Path[len("/networks/"):] + `"}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), Transport(server.Client().Transport))
+	paths := []string{"/networks/N_1", "/networks/N_2", "/networks/N_3", "/networks/N_4", "/networks/N_5", "/networks/N_6"}
+	results := client.GetMany(paths, 2)
+	assert.Len(t, results, 6)
+	for i, result := range results {
+		assert.Equal(t, paths[i], result.Path)
+		if i == 2 {
+			assert.Error(t, result.Err)
+			continue
+		}
+		assert.NoError(t, result.Err)
+		assert.Equal(t, paths[i][len("/networks/"):], result.Res.Get("id").String())
+	}
+	assert.ErrorContains(t, results.Err(), "/networks/N_3: ")
+	assert.LessOrEqual(t, peak.Load(), int64(2))
+
+	assert.Empty(t, client.GetMany(nil, 2))
+}
@@ -0,0 +1,254 @@
+package meraki
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MetricsRecorder receives measurements of a client, e.g. to export them to a monitoring
+// system, see Metrics. The context is the context of the request, e.g. to attribute
+// measurements to the labels of LabelsFromContext or to attach exemplars of the span of the
+// request. Implementations must be safe for concurrent use.
+type MetricsRecorder interface {
+	// ObserveRequest is called after every request attempt, including retries. The path is
+	// sanitized, see RoutePath, and the status code is 0 if the attempt failed with a
+	// connection error
+	ObserveRequest(ctx context.Context, method, path string, statusCode int, duration time.Duration)
+	// ObserveRetry is called for every retry of a request
+	ObserveRetry(ctx context.Context, method, path string, cause RetryCause)
+	// ObserveRateLimitWait is called with the time a request attempt waited for a rate limiter token
+	ObserveRateLimitWait(ctx context.Context, duration time.Duration)
+}
+
+// Metrics reports request counts, durations, retries and rate limiter waits of a client to a
+// metrics recorder, e.g. in the Prometheus text format using PrometheusMetrics:
+//
+//	metrics := NewPrometheusMetrics()
+//	client, _ := NewClient("abc123", Metrics(metrics))
+//	http.Handle("/metrics", metrics)
+//
+// To use the Prometheus client library or another monitoring system instead, implement
+// MetricsRecorder on top of its counters and histograms.
+func Metrics(m MetricsRecorder) func(*Client) {
+	return func(client *Client) {
+		client.Metrics = m
+	}
+}
+
+// routeIDRegexp matches path segments of API resources, e.g. networks or vlans, as opposed
+// to IDs, serials, MAC addresses and numbers. Digits are accepted between letters and at
+// the end, e.g. l3FirewallRules or ipv6.
+var routeIDRegexp = regexp.MustCompile(`^[a-z][a-zA-Z]*(?:[0-9][a-zA-Z]+)*[0-9]?$`)
+
+// RoutePath returns a path with all IDs replaced by {id} to limit the cardinality of metric
+// labels and span names, e.g. /networks/N_123/appliance/vlans/10 becomes
+// /networks/{id}/appliance/vlans/{id}.
+func RoutePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if segment != "" && !routeIDRegexp.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// observeRequest reports a request attempt to the metrics recorder.
+func (client *Client) observeRequest(req Req, statusCode int, duration time.Duration) {
+	if client.Metrics == nil {
+		return
+	}
+	path := RoutePath(client.relPath(req.HttpReq.URL))
+	client.runHook("Metrics", func() {
+		client.Metrics.ObserveRequest(req.HttpReq.Context(), req.HttpReq.Method, path, statusCode, duration)
+	})
+}
+
+// observeRetry reports a retry of a request to the metrics recorder.
+func (client *Client) observeRetry(req Req, cause RetryCause) {
+	if client.Metrics == nil {
+		return
+	}
+	path := RoutePath(client.relPath(req.HttpReq.URL))
+	client.runHook("Metrics", func() { client.Metrics.ObserveRetry(req.HttpReq.Context(), req.HttpReq.Method, path, cause) })
+}
+
+// observeRateLimitWait reports the rate limiter wait of a request attempt to the metrics recorder.
+func (client *Client) observeRateLimitWait(ctx context.Context, duration time.Duration) {
+	if client.Metrics == nil {
+		return
+	}
+	client.runHook("Metrics", func() { client.Metrics.ObserveRateLimitWait(ctx, duration) })
+}
+
+// DefaultMetricsBuckets are the upper bounds in seconds of the duration histograms of PrometheusMetrics.
+var DefaultMetricsBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// PrometheusMetrics is a MetricsRecorder serving its metrics in the Prometheus text
+// exposition format, without depending on the Prometheus client library. It exports:
+//
+//	meraki_requests_total{method,path,status}           counter of request attempts
+//	meraki_request_duration_seconds{method,path}        histogram of request attempt durations
+//	meraki_retries_total{method,path,cause}             counter of retries
+//	meraki_rate_limit_wait_seconds                      histogram of rate limiter waits
+//
+// The status is "error" for connection errors. Use meraki.NewPrometheusMetrics to initiate
+// a recorder.
+type PrometheusMetrics struct {
+	buckets   []float64
+	mutex     sync.Mutex
+	requests  map[[3]string]int64
+	durations map[[2]string]*histogram
+	retries   map[[3]string]int64
+	waits     *histogram
+}
+
+// histogram is a cumulative histogram with fixed bucket bounds.
+type histogram struct {
+	counts []int64
+	count  int64
+	sum    float64
+}
+
+// NewPrometheusMetrics creates a Prometheus metrics recorder with duration histograms using
+// the given buckets in seconds, default is DefaultMetricsBuckets.
+func NewPrometheusMetrics(buckets ...float64) *PrometheusMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultMetricsBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &PrometheusMetrics{
+		buckets:   buckets,
+		requests:  make(map[[3]string]int64),
+		durations: make(map[[2]string]*histogram),
+		retries:   make(map[[3]string]int64),
+		waits:     &histogram{counts: make([]int64, len(buckets))},
+	}
+}
+
+// ObserveRequest implements the MetricsRecorder interface.
+func (m *PrometheusMetrics) ObserveRequest(ctx context.Context, method, path string, statusCode int, duration time.Duration) {
+	status := "error"
+	if statusCode != 0 {
+		status = strconv.Itoa(statusCode)
+	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.requests[[3]string{method, path, status}]++
+	h, ok := m.durations[[2]string{method, path}]
+	if !ok {
+		h = &histogram{counts: make([]int64, len(m.buckets))}
+		m.durations[[2]string{method, path}] = h
+	}
+	m.observe(h, duration)
+}
+
+// ObserveRetry implements the MetricsRecorder interface.
+func (m *PrometheusMetrics) ObserveRetry(ctx context.Context, method, path string, cause RetryCause) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.retries[[3]string{method, path, string(cause)}]++
+}
+
+// ObserveRateLimitWait implements the MetricsRecorder interface.
+func (m *PrometheusMetrics) ObserveRateLimitWait(ctx context.Context, duration time.Duration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.observe(m.waits, duration)
+}
+
+func (m *PrometheusMetrics) observe(h *histogram, duration time.Duration) {
+	seconds := duration.Seconds()
+	for i, bound := range m.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// ServeHTTP implements the http.Handler interface, serving all metrics in the Prometheus text format.
+func (m *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.Write([]byte(m.String()))
+}
+
+// String returns all metrics in the Prometheus text format, sorted by labels.
+func (m *PrometheusMetrics) String() string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	var b strings.Builder
+
+	b.WriteString("# HELP meraki_requests_total Number of Meraki Dashboard API request attempts.\n")
+	b.WriteString("# TYPE meraki_requests_total counter\n")
+	for _, k := range sortedKeys(m.requests) {
+		fmt.Fprintf(&b, "meraki_requests_total{%s} %d\n", promLabels("method", k[0], "path", k[1], "status", k[2]), m.requests[k])
+	}
+
+	b.WriteString("# HELP meraki_request_duration_seconds Duration of Meraki Dashboard API request attempts.\n")
+	b.WriteString("# TYPE meraki_request_duration_seconds histogram\n")
+	durations := make([][2]string, 0, len(m.durations))
+	for k := range m.durations {
+		durations = append(durations, k)
+	}
+	sort.Slice(durations, func(i, j int) bool {
+		return durations[i][0]+" "+durations[i][1] < durations[j][0]+" "+durations[j][1]
+	})
+	for _, k := range durations {
+		m.writeHistogram(&b, "meraki_request_duration_seconds", m.durations[k], "method", k[0], "path", k[1])
+	}
+
+	b.WriteString("# HELP meraki_retries_total Number of retried Meraki Dashboard API requests.\n")
+	b.WriteString("# TYPE meraki_retries_total counter\n")
+	for _, k := range sortedKeys(m.retries) {
+		fmt.Fprintf(&b, "meraki_retries_total{%s} %d\n", promLabels("method", k[0], "path", k[1], "cause", k[2]), m.retries[k])
+	}
+
+	b.WriteString("# HELP meraki_rate_limit_wait_seconds Time request attempts waited for a rate limiter token.\n")
+	b.WriteString("# TYPE meraki_rate_limit_wait_seconds histogram\n")
+	m.writeHistogram(&b, "meraki_rate_limit_wait_seconds", m.waits)
+	return b.String()
+}
+
+func (m *PrometheusMetrics) writeHistogram(b *strings.Builder, name string, h *histogram, kv ...string) {
+	for i, bound := range m.buckets {
+		fmt.Fprintf(b, "%s_bucket{%s} %d\n", name, promLabels(append(kv, "le", strconv.FormatFloat(bound, 'g', -1, 64))...), h.counts[i])
+	}
+	fmt.Fprintf(b, "%s_bucket{%s} %d\n", name, promLabels(append(kv, "le", "+Inf")...), h.count)
+	labels := ""
+	if len(kv) > 0 {
+		labels = "{" + promLabels(kv...) + "}"
+	}
+	fmt.Fprintf(b, "%s_sum%s %s\n", name, labels, strconv.FormatFloat(h.sum, 'g', -1, 64))
+	fmt.Fprintf(b, "%s_count%s %d\n", name, labels, h.count)
+}
+
+// promLabels formats label names and values, escaping the values.
+func promLabels(kv ...string) string {
+	labels := make([]string, 0, len(kv)/2)
+	for i := 0; i+1 < len(kv); i += 2 {
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(kv[i+1])
+		labels = append(labels, kv[i]+`="`+value+`"`)
+	}
+	return strings.Join(labels, ",")
+}
+
+func sortedKeys(m map[[3]string]int64) [][3]string {
+	keys := make([][3]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return strings.Join(keys[i][:], " ") < strings.Join(keys[j][:], " ")
+	})
+	return keys
+}
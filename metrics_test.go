@@ -0,0 +1,90 @@
+package meraki
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestRoutePath tests the RoutePath function.
+func TestRoutePath(t *testing.T) {
+	assert.Equal(t, "/networks/{id}/appliance/vlans/{id}", RoutePath("/networks/N_123/appliance/vlans/10"))
+	assert.Equal(t, "/organizations/{id}/devices", RoutePath("/organizations/123/devices"))
+	assert.Equal(t, "/devices/{id}/switch/ports/{id}", RoutePath("/devices/Q2XX-AB12-CD34/switch/ports/1"))
+	assert.Equal(t, "/networks/{id}/appliance/firewall/l3FirewallRules", RoutePath("/networks/L_1/appliance/firewall/l3FirewallRules"))
+	assert.Equal(t, "/networks/{id}/clients/{id}", RoutePath("/networks/N_1/clients/k74272e"))
+	assert.Equal(t, "/networks/{id}/clients/{id}", RoutePath("/networks/N_1/clients/00:11:22:33:44:55"))
+}
+
+// TestMetrics tests the Metrics modifier and PrometheusMetrics.
+func TestMetrics(t *testing.T) {
+	defer gock.Off()
+	metrics := NewPrometheusMetrics(0.1, 1)
+	client, _ := NewClient("abc123", MaxRetries(2), BackoffMinDelay(0), BackoffMaxDelay(0), Metrics(metrics))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(client.BaseUrl).Get("/networks/N_1/clients").ReplyError(errors.New("fail"))
+	gock.New(client.BaseUrl).Get("/networks/N_1/clients").Reply(500)
+	gock.New(client.BaseUrl).Get("/networks/N_1/clients").Reply(200)
+	_, err := client.Get("/networks/N_1/clients")
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+
+	recorder := httptest.NewRecorder()
+	metrics.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body := recorder.Body.String()
+	assert.Contains(t, body, `meraki_requests_total{method="GET",path="/networks/{id}/clients",status="200"} 1`)
+	assert.Contains(t, body, `meraki_requests_total{method="GET",path="/networks/{id}/clients",status="500"} 1`)
+	assert.Contains(t, body, `meraki_requests_total{method="GET",path="/networks/{id}/clients",status="error"} 1`)
+	assert.Contains(t, body, `meraki_request_duration_seconds_bucket{method="GET",path="/networks/{id}/clients",le="+Inf"} 3`)
+	assert.Contains(t, body, `meraki_request_duration_seconds_count{method="GET",path="/networks/{id}/clients"} 3`)
+	assert.Contains(t, body, `meraki_retries_total{method="GET",path="/networks/{id}/clients",cause="network"} 1`)
+	assert.Contains(t, body, `meraki_retries_total{method="GET",path="/networks/{id}/clients",cause="server_error"} 1`)
+	assert.Contains(t, body, `meraki_rate_limit_wait_seconds_bucket{le="0.1"} 3`)
+	assert.Contains(t, body, "meraki_rate_limit_wait_seconds_count 3")
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", recorder.Header().Get("Content-Type"))
+}
+
+type labelRecorder struct {
+	mutex   sync.Mutex
+	tenants []string
+}
+
+func (r *labelRecorder) record(ctx context.Context) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.tenants = append(r.tenants, LabelsFromContext(ctx)["tenant"])
+}
+
+func (r *labelRecorder) ObserveRequest(ctx context.Context, method, path string, statusCode int, duration time.Duration) {
+	r.record(ctx)
+}
+
+func (r *labelRecorder) ObserveRetry(ctx context.Context, method, path string, cause RetryCause) {
+	r.record(ctx)
+}
+
+func (r *labelRecorder) ObserveRateLimitWait(ctx context.Context, duration time.Duration) {
+	r.record(ctx)
+}
+
+// TestMetricsContext tests that the MetricsRecorder receives the context of the request.
+func TestMetricsContext(t *testing.T) {
+	defer gock.Off()
+	recorder := &labelRecorder{}
+	client, _ := NewClient("abc123", MaxRetries(1), BackoffMinDelay(0), BackoffMaxDelay(0), Metrics(recorder))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(client.BaseUrl).Get("/url").Reply(500)
+	gock.New(client.BaseUrl).Get("/url").Reply(200)
+	_, err := client.GetContext(WithLabels(context.Background(), "tenant", "a"), "/url")
+	assert.NoError(t, err)
+	// rate limit wait, request and retry of the first attempt, rate limit wait and request of the second attempt
+	assert.Equal(t, []string{"a", "a", "a", "a", "a"}, recorder.tenants)
+}
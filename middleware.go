@@ -0,0 +1,85 @@
+package meraki
+
+import (
+	"net/http"
+	"time"
+)
+
+// ResponseInfo describes the outcome of a request attempt, see OnResponse.
+type ResponseInfo struct {
+	// Req is the request, including its labels
+	Req Req
+	// Response is the HTTP response, nil if the request failed with a connection error. Its body must not be read
+	Response *http.Response
+	// StatusCode is the status code of the response, 0 if the request failed with a connection error
+	StatusCode int
+	// Attempt is the number of previous attempts of the request
+	Attempt int
+	// Duration is the duration of the attempt including reading the response body
+	Duration time.Duration
+	// RateLimitWait is the time the attempt waited for a rate limiter token before it was sent
+	RateLimitWait time.Duration
+	// Err is the connection error or the error reading the response body
+	Err error
+}
+
+// OnRequest registers a hook called before every request attempt, including retries, e.g. to
+// add headers:
+//
+//	client, _ := NewClient("abc123", OnRequest(func(req *http.Request) {
+//		req.Header.Set("X-Request-ID", uuid.NewString())
+//	}))
+//
+// Hooks are called in registration order after the authentication header was added.
+func OnRequest(fn func(req *http.Request)) func(*Client) {
+	return func(client *Client) {
+		client.OnRequest = append(client.OnRequest, fn)
+	}
+}
+
+// OnResponse registers a hook called after every request attempt, including failed attempts
+// and retries, e.g. to observe status codes and latencies:
+//
+//	client, _ := NewClient("abc123", OnResponse(func(info ResponseInfo) {
+//		log.Printf("%s %d %v", info.Req.HttpReq.URL, info.StatusCode, info.Duration)
+//	}))
+func OnResponse(fn func(info ResponseInfo)) func(*Client) {
+	return func(client *Client) {
+		client.OnResponse = append(client.OnResponse, fn)
+	}
+}
+
+// beforeAttempt calls the OnRequest hooks.
+func (client *Client) beforeAttempt(req Req) {
+	if len(client.OnRequest) == 0 {
+		return
+	}
+	fns := make([]func(), 0, len(client.OnRequest))
+	for _, hook := range client.OnRequest {
+		hook := hook
+		fns = append(fns, func() { hook(req.HttpReq) })
+	}
+	client.runHooks("OnRequest", fns...)
+}
+
+// afterAttempt logs a request attempt, reports it to the metrics recorder and tracer and calls
+// the OnResponse hooks.
+func (client *Client) afterAttempt(req Req, attempt int, res *http.Response, duration, wait time.Duration, err error) {
+	statusCode := 0
+	if res != nil {
+		statusCode = res.StatusCode
+	}
+	client.logAttempt(req, attempt, statusCode, duration, err)
+	client.observeRequest(req, statusCode, duration)
+	client.endAttempt(req, statusCode, err)
+	if len(client.OnResponse) == 0 {
+		return
+	}
+	info := ResponseInfo{Req: req, Response: res, StatusCode: statusCode, Attempt: attempt, Duration: duration, RateLimitWait: wait, Err: err}
+	fns := make([]func(), 0, len(client.OnResponse))
+	for _, hook := range client.OnResponse {
+		hook := hook
+		fns = append(fns, func() { hook(info) })
+	}
+	client.runHooks("OnResponse", fns...)
+}
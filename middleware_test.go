@@ -0,0 +1,36 @@
+package meraki
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestMiddleware tests the OnRequest and OnResponse modifiers.
+func TestMiddleware(t *testing.T) {
+	defer gock.Off()
+	infos := make([]ResponseInfo, 0)
+	client, _ := NewClient("abc123", MaxRetries(1), BackoffMinDelay(0), BackoffMaxDelay(0),
+		OnRequest(func(req *http.Request) { req.Header.Set("X-Request-ID", "1") }),
+		OnRequest(func(req *http.Request) { panic("fail") }),
+		OnResponse(func(info ResponseInfo) { infos = append(infos, info) }))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(client.BaseUrl).Get("/url").MatchHeader("X-Request-ID", "1").ReplyError(errors.New("fail"))
+	gock.New(client.BaseUrl).Get("/url").MatchHeader("X-Request-ID", "1").Reply(200)
+	_, err := client.Get("/url")
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+
+	assert.Len(t, infos, 2)
+	assert.Equal(t, 0, infos[0].Attempt)
+	assert.Error(t, infos[0].Err)
+	assert.Nil(t, infos[0].Response)
+	assert.Equal(t, 1, infos[1].Attempt)
+	assert.Equal(t, 200, infos[1].StatusCode)
+	assert.NoError(t, infos[1].Err)
+	assert.Equal(t, int64(2), client.Stats().HookErrors)
+}
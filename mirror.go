@@ -0,0 +1,66 @@
+package meraki
+
+import (
+	"io"
+	"net/http"
+)
+
+// maxMirrorRequests is the maximum number of mirrored requests in flight.
+const maxMirrorRequests = 8
+
+// MirrorUrl mirrors all GET requests asynchronously to a secondary base URL, e.g. a staging
+// gateway or a recorder, e.g.
+//
+//	client, _ := NewClient("abc123", MirrorUrl("https://recorder.example.com/api/v1"))
+//
+// Mirrored requests carry the same headers as the original request, except the
+// authentication headers, see MirrorAuth. They are not rate limited, not retried and never
+// affect the result of the original request. Their responses are discarded. Requests are dropped
+// instead of mirrored while a few mirrored requests are still in flight.
+func MirrorUrl(x string) func(*Client) {
+	return func(client *Client) {
+		client.MirrorUrl = x
+	}
+}
+
+// MirrorAuth sends the authentication headers, i.e. the API key, with mirrored requests. Only
+// enable it for mirror URLs trusted with the API key. Default value is false.
+func MirrorAuth(x bool) func(*Client) {
+	return func(client *Client) {
+		client.MirrorAuth = x
+	}
+}
+
+// mirror sends a copy of a GET request to the mirror URL in the background.
+func (client *Client) mirror(req Req) {
+	if client.MirrorUrl == "" || req.HttpReq.Method != "GET" {
+		return
+	}
+	mirrorReq, err := http.NewRequest("GET", client.rebase(req.HttpReq.URL, client.MirrorUrl).String(), nil)
+	if err != nil {
+		client.logf("[DEBUG] Cannot create mirror request: %s", err)
+		return
+	}
+	mirrorReq.Header = req.HttpReq.Header.Clone()
+	if !client.MirrorAuth {
+		mirrorReq.Header.Del("Authorization")
+		mirrorReq.Header.Del(AuthSchemeApiKey)
+	}
+	select {
+	case client.mirrorSlots <- struct{}{}:
+	default:
+		client.logf("[DEBUG] Mirror request dropped, %d requests in flight: %s", maxMirrorRequests, mirrorReq.URL)
+		return
+	}
+	go func() {
+		defer func() { <-client.mirrorSlots }()
+		res, err := client.HttpClient.Do(mirrorReq)
+		if err != nil {
+			client.logf("[DEBUG] Mirror request failed: %s, %s", mirrorReq.URL, err)
+			return
+		}
+		defer res.Body.Close()
+		io.Copy(io.Discard, res.Body)
+		client.logf("[DEBUG] Mirror request: %s, StatusCode %v", mirrorReq.URL, res.StatusCode)
+	}()
+}
@@ -0,0 +1,71 @@
+package meraki
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestMirrorUrl tests the MirrorUrl modifier.
+func TestMirrorUrl(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+	MirrorUrl("https://recorder.example.com/api/v1")(&client)
+
+	gock.New(client.BaseUrl).Get("/organizations").Reply(200).BodyString(`[{"id":"1"}]`)
+	gock.New("https://recorder.example.com/api/v1").Get("/organizations").
+		AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+			return req.Header.Get("Authorization") == "", nil
+		}).
+		Reply(500)
+	res, err := client.Get("/organizations")
+	assert.NoError(t, err)
+	assert.Equal(t, "1", res.Get("0.id").Str)
+	assert.Eventually(t, gock.IsDone, time.Second, 10*time.Millisecond)
+
+	// Write requests are not mirrored
+	gock.New(client.BaseUrl).Put("/organizations/1").Reply(200).BodyString(`{}`)
+	_, err = client.Put("/organizations/1", `{}`)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+
+	// Authentication headers are only mirrored if enabled
+	MirrorAuth(true)(&client)
+	gock.New(client.BaseUrl).Get("/organizations").Reply(200).BodyString(`[]`)
+	gock.New("https://recorder.example.com/api/v1").Get("/organizations").
+		MatchHeader("Authorization", "Bearer abc123").
+		Reply(200)
+	_, err = client.Get("/organizations")
+	assert.NoError(t, err)
+	assert.Eventually(t, gock.IsDone, time.Second, 10*time.Millisecond)
+}
+
+// TestMirrorUrlDropped tests dropping mirrored requests while others are in flight.
+func TestMirrorUrlDropped(t *testing.T) {
+	release := make(chan struct{})
+	var mirrored atomic.Int32
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mirrored.Add(1)
+		<-release
+	}))
+	defer mirror.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MirrorUrl(mirror.URL), RequestPerSecond(1000))
+
+	for i := 0; i < maxMirrorRequests+4; i++ {
+		_, err := client.Get("/organizations", NoCache)
+		assert.NoError(t, err)
+	}
+	assert.Eventually(t, func() bool { return mirrored.Load() == maxMirrorRequests }, time.Second, 10*time.Millisecond)
+	close(release)
+	assert.Eventually(t, func() bool { return len(client.mirrorSlots) == 0 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(maxMirrorRequests), mirrored.Load())
+}
@@ -0,0 +1,199 @@
+package meraki
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// DefaultIdentityKeys is the default list of keys used by Normalize to sort arrays of objects.
+var DefaultIdentityKeys = []string{"id", "serial", "mac", "networkId", "portId", "number", "name"}
+
+// NormalizeConfig is the configuration of Normalize.
+type NormalizeConfig struct {
+	// IdentityKeys is an ordered list of keys identifying array elements. Arrays of objects
+	// are sorted by the first key present in all elements, other arrays keep their order.
+	IdentityKeys []string
+	// Ignore is a list of dropped fields, see DiffConfig.Ignore
+	Ignore []string
+	// NumericStrings converts strings holding a number in canonical form into numbers, other
+	// strings like zip codes with leading zeros are kept
+	NumericStrings bool
+	// SortScalars sorts arrays of strings, numbers and booleans
+	SortScalars bool
+}
+
+// NormalizeIdentityKeys replaces the list of identity keys. Default value is DefaultIdentityKeys.
+func NormalizeIdentityKeys(keys ...string) func(*NormalizeConfig) {
+	return func(config *NormalizeConfig) {
+		config.IdentityKeys = keys
+	}
+}
+
+// NormalizeIgnore replaces the list of dropped fields. Default value is ReadOnlyFields.
+func NormalizeIgnore(fields ...string) func(*NormalizeConfig) {
+	return func(config *NormalizeConfig) {
+		config.Ignore = fields
+	}
+}
+
+// NormalizeKeepStrings keeps strings holding a number as strings.
+func NormalizeKeepStrings(config *NormalizeConfig) {
+	config.NumericStrings = false
+}
+
+// NormalizeSortScalars sorts arrays of scalar values, e.g. tags.
+func NormalizeSortScalars(config *NormalizeConfig) {
+	config.SortScalars = true
+}
+
+// Normalize returns a canonical representation of a JSON document, which can be
+// compared to find out whether anything actually changed. Object keys and arrays
+// of objects are sorted, read-only fields are dropped and numbers are formatted
+// consistently, e.g.
+//
+//	changed := meraki.Normalize(a).Raw != meraki.Normalize(b).Raw
+func Normalize(res Res, mods ...func(*NormalizeConfig)) Res {
+	config := NormalizeConfig{
+		IdentityKeys:   DefaultIdentityKeys,
+		Ignore:         ReadOnlyFields,
+		NumericStrings: true,
+	}
+	for _, mod := range mods {
+		mod(&config)
+	}
+	if !res.Exists() {
+		return res
+	}
+	raw := config.normalize("", res.Result)
+	res.Result = gjson.Parse(raw)
+	return res
+}
+
+func (config NormalizeConfig) normalize(path string, r gjson.Result) string {
+	switch {
+	case r.IsObject():
+		keys := make([]string, 0)
+		r.ForEach(func(k, _ gjson.Result) bool {
+			if !matchField(config.Ignore, joinPath(path, escapePath(k.Str))) {
+				keys = append(keys, k.Str)
+			}
+			return true
+		})
+		sort.Strings(keys)
+		var sb strings.Builder
+		sb.WriteByte('{')
+		for i, k := range keys {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			key, _ := json.Marshal(k)
+			sb.Write(key)
+			sb.WriteByte(':')
+			sb.WriteString(config.normalize(joinPath(path, escapePath(k)), r.Get(escapePath(k))))
+		}
+		sb.WriteByte('}')
+		return sb.String()
+	case r.IsArray():
+		elements := r.Array()
+		values := make([]string, len(elements))
+		for i, e := range elements {
+			values[i] = config.normalize(joinPath(path, strconv.Itoa(i)), e)
+		}
+		if sortKeys := config.sortKeys(elements); sortKeys != nil {
+			sort.Stable(byKey{keys: sortKeys, values: values})
+		}
+		return "[" + strings.Join(values, ",") + "]"
+	case r.Type == gjson.String:
+		if config.NumericStrings {
+			// Only strings in canonical form are numbers, e.g. not "0123", "1.50" or
+			// integers beyond int64
+			if n, ok := canonicalNumber(r.Str); ok && n == r.Str {
+				return n
+			}
+		}
+		s, _ := json.Marshal(r.Str)
+		return string(s)
+	case r.Type == gjson.Number:
+		if n, ok := canonicalNumber(r.Raw); ok {
+			return n
+		}
+		return r.Raw
+	default:
+		return r.Raw
+	}
+}
+
+// sortKeys returns the keys to sort array elements by, or nil if the order should be kept.
+func (config NormalizeConfig) sortKeys(elements []gjson.Result) []string {
+	if len(elements) < 2 {
+		return nil
+	}
+	if elements[0].IsObject() {
+		for _, identity := range config.IdentityKeys {
+			keys := make([]string, len(elements))
+			found := true
+			for i, e := range elements {
+				v := e.Get(escapePath(identity))
+				if !e.IsObject() || !v.Exists() {
+					found = false
+					break
+				}
+				keys[i] = config.normalize("", v)
+			}
+			if found {
+				return keys
+			}
+		}
+		return nil
+	}
+	if !config.SortScalars {
+		return nil
+	}
+	keys := make([]string, len(elements))
+	for i, e := range elements {
+		if e.IsObject() || e.IsArray() {
+			return nil
+		}
+		keys[i] = config.normalize("", e)
+	}
+	return keys
+}
+
+// canonicalNumber returns the canonical representation of a number.
+func canonicalNumber(s string) (string, bool) {
+	if s == "" || strings.TrimSpace(s) != s {
+		return "", false
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return strconv.FormatInt(i, 10), true
+	} else if errors.Is(err, strconv.ErrRange) {
+		// Integers beyond int64 would lose precision as float and are kept as they are
+		return "", false
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || strings.ContainsAny(s, "nNiIxX_") {
+		return "", false
+	}
+	n, err := json.Marshal(f)
+	if err != nil {
+		return "", false
+	}
+	return string(n), true
+}
+
+type byKey struct {
+	keys   []string
+	values []string
+}
+
+func (b byKey) Len() int           { return len(b.keys) }
+func (b byKey) Less(i, j int) bool { return b.keys[i] < b.keys[j] }
+func (b byKey) Swap(i, j int) {
+	b.keys[i], b.keys[j] = b.keys[j], b.keys[i]
+	b.values[i], b.values[j] = b.values[j], b.values[i]
+}
@@ -0,0 +1,25 @@
+package meraki
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestNormalize tests the Normalize function.
+func TestNormalize(t *testing.T) {
+	a := Body{Str: `{"name":"a","vlan":"10","ports":[{"portId":"2","enabled":true},{"portId":"1","enabled":false}],"tags":["b","a"],"url":"x"}`}.Res()
+	b := Body{Str: `{"tags":["b","a"],"ports":[{"enabled":false,"portId":"1"},{"enabled":true,"portId":"2"}],"vlan":10.0,"name":"a"}`}.Res()
+
+	assert.Equal(t, `{"name":"a","ports":[{"enabled":false,"portId":1},{"enabled":true,"portId":2}],"tags":["b","a"],"vlan":10}`, Normalize(a).Raw)
+	assert.Equal(t, Normalize(a).Raw, Normalize(b).Raw)
+
+	// Options
+	assert.Equal(t, `{"tags":["a","b"]}`, Normalize(Body{Str: `{"tags":["b","a"]}`}.Res(), NormalizeSortScalars).Raw)
+	assert.Equal(t, `{"vlan":"10"}`, Normalize(Body{Str: `{"vlan":"10","id":"1"}`}.Res(), NormalizeKeepStrings).Raw)
+	assert.Equal(t, `{"id":1}`, Normalize(Body{Str: `{"vlan":"10","id":"1"}`}.Res(), NormalizeIgnore("vlan")).Raw)
+	assert.Equal(t, `{"a":"02134","b":"1.50","c":"1e3","d":12345678901234567890,"e":"12345678901234567890","f":1.5,"g":-3}`,
+		Normalize(Body{Str: `{"a":"02134","b":"1.50","c":"1e3","d":12345678901234567890,"e":"12345678901234567890","f":"1.5","g":"-3"}`}.Res()).Raw)
+	assert.Equal(t, `{"vlan":{"id":10}}`, Normalize(Body{Str: `{"vlan":{"id":10},"id":"1"}`}.Res()).Raw)
+	assert.Equal(t, `[{"a":2,"b":1},{"a":1,"b":2}]`, Normalize(Body{Str: `[{"a":1,"b":2},{"a":2,"b":1}]`}.Res(), NormalizeIdentityKeys("b")).Raw)
+}
@@ -0,0 +1,151 @@
+package meraki
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultOAuthTokenUrl is the token endpoint of Meraki Dashboard OAuth applications.
+const DefaultOAuthTokenUrl string = "https://as.meraki.com/oauth/token"
+
+// DefaultOAuthTokenLifetime is the lifetime of access tokens issued without expires_in, which
+// matches the lifetime of Meraki Dashboard access tokens.
+const DefaultOAuthTokenLifetime time.Duration = time.Hour
+
+// oauthExpiryDelta is the time before the expiry of an access token at which it is refreshed,
+// at most half of the lifetime of short-lived tokens.
+const oauthExpiryDelta = time.Minute
+
+// OAuthConfig is the configuration of an OAuth token source.
+type OAuthConfig struct {
+	// TokenUrl is the token endpoint, default is DefaultOAuthTokenUrl
+	TokenUrl string
+	// ClientID is the client ID of the OAuth application
+	ClientID string
+	// ClientSecret is the client secret of the OAuth application
+	ClientSecret string
+	// RefreshToken is the refresh token of an authorized integration. If empty, the
+	// client credentials grant is used
+	RefreshToken string
+	// Scopes are the requested scopes of the client credentials grant, e.g. dashboard:general:config:read
+	Scopes []string
+	// OnRefreshToken is called when the token endpoint issued a new refresh token, which must
+	// be persisted, as Meraki rotates refresh tokens. It is called without holding the lock of
+	// the token source, so it may use the token source
+	OnRefreshToken func(refreshToken string)
+	// HttpClient is used for token requests, default is http.DefaultClient
+	HttpClient *http.Client
+}
+
+// OAuthTokenSource is a TokenProvider obtaining and refreshing access tokens from an OAuth
+// token endpoint. Use meraki.NewOAuthTokenSource to initiate a token source.
+type OAuthTokenSource struct {
+	config  OAuthConfig
+	mutex   sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// NewOAuthTokenSource creates a token source using the refresh token grant if a refresh token
+// is configured or the client credentials grant otherwise, e.g.
+//
+//	ts := NewOAuthTokenSource(OAuthConfig{ClientID: "id", ClientSecret: "secret", RefreshToken: "abc"})
+//	client, _ := NewClient("", OAuth(ts))
+func NewOAuthTokenSource(config OAuthConfig) *OAuthTokenSource {
+	if config.TokenUrl == "" {
+		config.TokenUrl = DefaultOAuthTokenUrl
+	}
+	if config.HttpClient == nil {
+		config.HttpClient = http.DefaultClient
+	}
+	return &OAuthTokenSource{config: config}
+}
+
+// GetToken returns the current access token, refreshing it shortly before it expires.
+// Tokens issued without an expiry expire after DefaultOAuthTokenLifetime.
+func (ts *OAuthTokenSource) GetToken(ctx context.Context) (string, error) {
+	token, refreshToken, err := ts.getToken(ctx)
+	if refreshToken != "" && ts.config.OnRefreshToken != nil {
+		ts.config.OnRefreshToken(refreshToken)
+	}
+	return token, err
+}
+
+// getToken implements GetToken and returns the new refresh token, if one was issued.
+func (ts *OAuthTokenSource) getToken(ctx context.Context) (string, string, error) {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+	if ts.token != "" && time.Now().Before(ts.expires) {
+		return ts.token, "", nil
+	}
+	form := url.Values{}
+	if ts.config.RefreshToken != "" {
+		form.Set("grant_type", "refresh_token")
+		form.Set("refresh_token", ts.config.RefreshToken)
+	} else {
+		form.Set("grant_type", "client_credentials")
+		if len(ts.config.Scopes) > 0 {
+			form.Set("scope", strings.Join(ts.config.Scopes, " "))
+		}
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", ts.config.TokenUrl, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(ts.config.ClientID), url.QueryEscape(ts.config.ClientSecret))
+	client, _ := ctx.Value(tokenClientKey{}).(*Client)
+	client.logf("[DEBUG] OAuth token request: %s, grant type %s", ts.config.TokenUrl, form.Get("grant_type"))
+	res, err := ts.config.HttpClient.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("OAuth token request failed: %w", err)
+	}
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", "", fmt.Errorf("OAuth token request failed: %w", err)
+	}
+	var token struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		ExpiresIn    int64  `json:"expires_in"`
+		Error        string `json:"error"`
+		Description  string `json:"error_description"`
+	}
+	json.Unmarshal(data, &token)
+	if res.StatusCode < 200 || res.StatusCode > 299 || token.AccessToken == "" {
+		return "", "", fmt.Errorf("OAuth token request failed: StatusCode %v, %s %s", res.StatusCode, token.Error, token.Description)
+	}
+	lifetime := time.Duration(token.ExpiresIn) * time.Second
+	if lifetime <= 0 {
+		lifetime = DefaultOAuthTokenLifetime
+	}
+	ts.token = token.AccessToken
+	ts.expires = time.Now().Add(lifetime - min(oauthExpiryDelta, lifetime/2))
+	if token.RefreshToken == "" || token.RefreshToken == ts.config.RefreshToken {
+		return ts.token, "", nil
+	}
+	ts.config.RefreshToken = token.RefreshToken
+	return ts.token, token.RefreshToken, nil
+}
+
+// Invalidate discards the current access token, so the next call of GetToken refreshes it.
+func (ts *OAuthTokenSource) Invalidate() {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+	ts.token = ""
+}
+
+// OAuth attaches access tokens of an OAuth token source to requests instead of the API token,
+// e.g. of a Meraki Dashboard OAuth application, see NewOAuthTokenSource and ApiTokenProvider.
+func OAuth(ts *OAuthTokenSource) func(*Client) {
+	return ApiTokenProvider(ts)
+}
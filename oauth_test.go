@@ -0,0 +1,116 @@
+package meraki
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestOAuth tests the OAuth modifier and the OAuthTokenSource.
+func TestOAuth(t *testing.T) {
+	defer gock.Off()
+	issued := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, _ := r.BasicAuth()
+		r.ParseForm()
+		if id != "id" || secret != "secret" || r.Form.Get("refresh_token") != fmt.Sprintf("refresh%d", issued) {
+			w.WriteHeader(400)
+			w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		issued++
+		fmt.Fprintf(w, `{"access_token":"access%d","refresh_token":"refresh%d","expires_in":3600}`, issued, issued)
+	}))
+	defer server.Close()
+
+	refreshTokens := make([]string, 0)
+	ts := NewOAuthTokenSource(OAuthConfig{
+		TokenUrl:       server.URL,
+		HttpClient:     server.Client(),
+		ClientID:       "id",
+		ClientSecret:   "secret",
+		RefreshToken:   "refresh0",
+		OnRefreshToken: func(refreshToken string) { refreshTokens = append(refreshTokens, refreshToken) },
+	})
+	client, _ := NewClient("", MaxRetries(0), OAuth(ts))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(client.BaseUrl).Get("/url").MatchHeader("Authorization", "Bearer access1").Times(2).Reply(200)
+	for i := 0; i < 2; i++ {
+		_, err := client.Get("/url")
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, issued)
+
+	// Revoked access tokens are refreshed once
+	gock.New(client.BaseUrl).Get("/url").MatchHeader("Authorization", "Bearer access1").Reply(401)
+	gock.New(client.BaseUrl).Get("/url").MatchHeader("Authorization", "Bearer access2").Reply(200)
+	_, err := client.Get("/url")
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, []string{"refresh1", "refresh2"}, refreshTokens)
+
+	gock.New(client.BaseUrl).Get("/url").Times(2).Reply(401)
+	_, err = client.Get("/url")
+	assert.Error(t, err)
+	assert.Equal(t, 3, issued)
+
+	// Token errors fail requests
+	ts = NewOAuthTokenSource(OAuthConfig{TokenUrl: server.URL, HttpClient: server.Client(), ClientID: "id", ClientSecret: "wrong"})
+	client, _ = NewClient("", MaxRetries(0), OAuth(ts))
+	_, err = client.Get("/url")
+	assert.ErrorContains(t, err, "invalid_grant")
+	_, err = ts.GetToken(context.Background())
+	assert.ErrorContains(t, err, "StatusCode 400")
+}
+
+// TestOAuthTokenLifetime tests tokens issued without expiry and token source calls of OnRefreshToken.
+func TestOAuthTokenLifetime(t *testing.T) {
+	issued := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		issued++
+		fmt.Fprintf(w, `{"access_token":"access%d","refresh_token":"refresh%d"}`, issued, issued)
+	}))
+	defer server.Close()
+
+	var ts *OAuthTokenSource
+	var fromCallback string
+	ts = NewOAuthTokenSource(OAuthConfig{
+		TokenUrl:       server.URL,
+		HttpClient:     server.Client(),
+		RefreshToken:   "refresh0",
+		OnRefreshToken: func(refreshToken string) { fromCallback, _ = ts.GetToken(context.Background()) },
+	})
+	token, err := ts.GetToken(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "access1", token)
+	assert.Equal(t, "access1", fromCallback)
+	token, _ = ts.GetToken(context.Background())
+	assert.Equal(t, "access1", token)
+	assert.Equal(t, 1, issued)
+}
+
+// TestOAuthShortTokenLifetime tests that tokens with a lifetime below the expiry delta are reused.
+func TestOAuthShortTokenLifetime(t *testing.T) {
+	issued := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		issued++
+		fmt.Fprintf(w, `{"access_token":"access%d","expires_in":30}`, issued)
+	}))
+	defer server.Close()
+
+	ts := NewOAuthTokenSource(OAuthConfig{TokenUrl: server.URL, HttpClient: server.Client(), ClientID: "id", ClientSecret: "secret"})
+	for i := 0; i < 3; i++ {
+		token, err := ts.GetToken(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "access1", token)
+	}
+	assert.Equal(t, 1, issued)
+	assert.WithinDuration(t, time.Now().Add(15*time.Second), ts.expires, time.Second)
+}
@@ -0,0 +1,129 @@
+package meraki
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Org is an organization returned by ForEachOrg.
+// It is scoped to a single organization, i.e. all request paths are relative
+// to /organizations/{id}, e.g.
+//
+//	org.Get("/networks")
+type Org struct {
+	// ID is the organization ID
+	ID string
+	// Name is the organization name
+	Name string
+	// Res is the raw organization object as returned by the API
+	Res Res
+	// Client is the client used for requests of this organization
+	Client *Client
+}
+
+// OrgError is an error that occurred while processing a single organization.
+type OrgError struct {
+	// OrgID is the ID of the organization
+	OrgID string
+	// OrgName is the name of the organization
+	OrgName string
+	// Err is the underlying error
+	Err error
+}
+
+// Error implements the error interface.
+func (e *OrgError) Error() string {
+	return fmt.Sprintf("organization %s (%s): %s", e.OrgID, e.OrgName, e.Err.Error())
+}
+
+// Unwrap returns the underlying error.
+func (e *OrgError) Unwrap() error {
+	return e.Err
+}
+
+// NewOrg creates an Org scoped to the organization with the given ID.
+func (client *Client) NewOrg(id string) Org {
+	return Org{ID: id, Client: client}
+}
+
+// Path returns the absolute API path of an organization relative path.
+func (org Org) Path(path string) string {
+	if path != "" && !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return "/organizations/" + org.ID + path
+}
+
+// NewReq creates a new Req request for this organization.
+func (org Org) NewReq(method, path string, body io.Reader, mods ...func(*Req)) Req {
+	return org.Client.NewReq(method, org.Path(path), body, mods...)
+}
+
+// Get makes a GET request relative to the organization path.
+func (org Org) Get(path string, mods ...func(*Req)) (Res, error) {
+	return org.Client.Get(org.Path(path), mods...)
+}
+
+// Delete makes a DELETE request relative to the organization path.
+func (org Org) Delete(path string, mods ...func(*Req)) (Res, error) {
+	return org.Client.Delete(org.Path(path), mods...)
+}
+
+// Post makes a POST request relative to the organization path.
+func (org Org) Post(path, data string, mods ...func(*Req)) (Res, error) {
+	return org.Client.Post(org.Path(path), data, mods...)
+}
+
+// Put makes a PUT request relative to the organization path.
+func (org Org) Put(path, data string, mods ...func(*Req)) (Res, error) {
+	return org.Client.Put(org.Path(path), data, mods...)
+}
+
+// Orgs lists all organizations accessible with the API token.
+func (client *Client) Orgs(mods ...func(*Req)) ([]Org, error) {
+	res, err := client.Get("/organizations", mods...)
+	if err != nil {
+		return nil, err
+	}
+	orgs := make([]Org, 0, len(res.Array()))
+	for _, item := range res.Array() {
+		orgs = append(orgs, Org{
+			ID:     item.Get("id").String(),
+			Name:   item.Get("name").String(),
+			Res:    Res{Result: item},
+			Client: client,
+		})
+	}
+	return orgs, nil
+}
+
+// ForEachOrg lists all organizations and calls fn once per organization.
+// Errors returned by fn do not stop the iteration. They are wrapped in an
+// *OrgError and returned joined together once all organizations have been
+// processed. The organization list is requested with ctx and the iteration
+// stops early if ctx is cancelled, e.g.
+//
+//	err := client.ForEachOrg(ctx, func(org meraki.Org) error {
+//		_, err := org.Get("/networks")
+//		return err
+//	})
+func (client *Client) ForEachOrg(ctx context.Context, fn func(org Org) error) error {
+	orgs, err := client.Orgs(Context(ctx))
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, org := range orgs {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if err := fn(org); err != nil {
+			errs = append(errs, &OrgError{OrgID: org.ID, OrgName: org.Name, Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,57 @@
+package meraki
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestOrgPath tests the Org::Path method.
+func TestOrgPath(t *testing.T) {
+	client := testClient()
+	org := client.NewOrg("123")
+	assert.Equal(t, "/organizations/123/networks", org.Path("/networks"))
+	assert.Equal(t, "/organizations/123/networks", org.Path("networks"))
+	assert.Equal(t, "/organizations/123", org.Path(""))
+}
+
+// TestClientForEachOrg tests the Client::ForEachOrg method.
+func TestClientForEachOrg(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Get("/organizations").
+		Reply(200).
+		BodyString(`[{"id":"1","name":"A"},{"id":"2","name":"B"}]`)
+	gock.New(client.BaseUrl).Get("/organizations/1/networks").Reply(200).BodyString(`[]`)
+	gock.New(client.BaseUrl).Get("/organizations/2/networks").Reply(404)
+
+	var names []string
+	err := client.ForEachOrg(context.Background(), func(org Org) error {
+		names = append(names, org.Name)
+		_, err := org.Get("/networks")
+		return err
+	})
+	assert.Equal(t, []string{"A", "B"}, names)
+	assert.Error(t, err)
+	var orgErr *OrgError
+	assert.True(t, errors.As(err, &orgErr))
+	assert.Equal(t, "2", orgErr.OrgID)
+
+	// Cancelled context
+	gock.New(client.BaseUrl).Get("/organizations").
+		Reply(200).
+		BodyString(`[{"id":"1","name":"A"}]`)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = client.ForEachOrg(ctx, func(org Org) error {
+		t.Fail()
+		return nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	// The organization list is requested with the context
+	assert.False(t, gock.IsDone())
+}
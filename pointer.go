@@ -0,0 +1,46 @@
+package meraki
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// Pointer returns the value at a RFC 6901 JSON Pointer, e.g.
+//
+//	serial := res.Pointer("/items/0/serial").String()
+//
+// The empty pointer refers to the whole document. Invalid pointers return a result which does not exist.
+func (res Res) Pointer(pointer string) gjson.Result {
+	if pointer == "" {
+		return res.Result
+	}
+	path, err := PointerPath(pointer)
+	if err != nil {
+		return gjson.Result{}
+	}
+	return res.Get(path)
+}
+
+// PointerPath converts a RFC 6901 JSON Pointer into a GJSON path, which can be used with
+// Res.Get, Res.Set or Body.Set, e.g. "/a~1b/0" is converted into "a/b.0".
+func PointerPath(pointer string) (string, error) {
+	if pointer == "" {
+		return "", fmt.Errorf("JSON pointer referring to the whole document has no path")
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return "", fmt.Errorf("Invalid JSON pointer '%s': must start with '/'", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		if token == "" {
+			return "", fmt.Errorf("Invalid JSON pointer '%s': empty reference tokens are not supported", pointer)
+		}
+		if strings.Contains(strings.NewReplacer("~0", "", "~1", "").Replace(token), "~") {
+			return "", fmt.Errorf("Invalid JSON pointer '%s': invalid escape sequence", pointer)
+		}
+		tokens[i] = escapePath(strings.NewReplacer("~1", "/", "~0", "~").Replace(token))
+	}
+	return strings.Join(tokens, "."), nil
+}
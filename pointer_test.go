@@ -0,0 +1,30 @@
+package meraki
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestResPointer tests the Res::Pointer method.
+func TestResPointer(t *testing.T) {
+	res := Body{Str: `{"items":[{"serial":"Q-1"}],"a/b":{"m~n":1},"c.d":2}`}.Res()
+	assert.Equal(t, "Q-1", res.Pointer("/items/0/serial").String())
+	assert.Equal(t, int64(1), res.Pointer("/a~1b/m~0n").Int())
+	assert.Equal(t, int64(2), res.Pointer("/c.d").Int())
+	assert.Equal(t, res.Raw, res.Pointer("").Raw)
+	assert.False(t, res.Pointer("/items/1").Exists())
+	assert.False(t, res.Pointer("items").Exists())
+}
+
+// TestPointerPath tests the PointerPath function.
+func TestPointerPath(t *testing.T) {
+	path, err := PointerPath("/a~1b/0/c.d")
+	assert.NoError(t, err)
+	assert.Equal(t, `a/b.0.c\.d`, path)
+
+	for _, pointer := range []string{"", "a", "/a//b", "/a~2"} {
+		_, err = PointerPath(pointer)
+		assert.Error(t, err, pointer)
+	}
+}
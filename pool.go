@@ -0,0 +1,79 @@
+package meraki
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+// The following modifiers tune the connection pool of the HTTP transport. The defaults of
+// http.DefaultTransport keep only 2 idle connections per host, therefore high-concurrency
+// pollers keep establishing new connections, e.g.
+//
+//	client, _ := NewClient("abc123", MaxIdleConnsPerHost(32), IdleConnTimeout(5*time.Minute))
+
+// MaxIdleConns modifies the maximum number of idle connections across all hosts from the default
+// of 100. A value of 0 means no limit.
+func MaxIdleConns(x int) func(*Client) {
+	return func(client *Client) {
+		if transport := client.transport(); transport != nil {
+			transport.MaxIdleConns = x
+		} else {
+			client.logf("[WARNING] Max idle connections ignored, HTTP client uses a custom transport")
+		}
+	}
+}
+
+// MaxIdleConnsPerHost modifies the maximum number of idle connections per host from the default of 2.
+func MaxIdleConnsPerHost(x int) func(*Client) {
+	return func(client *Client) {
+		if transport := client.transport(); transport != nil {
+			transport.MaxIdleConnsPerHost = x
+		} else {
+			client.logf("[WARNING] Max idle connections per host ignored, HTTP client uses a custom transport")
+		}
+	}
+}
+
+// IdleConnTimeout modifies the time after which idle connections are closed from the default of
+// 90 seconds. A value of 0 means no limit.
+func IdleConnTimeout(x time.Duration) func(*Client) {
+	return func(client *Client) {
+		if transport := client.transport(); transport != nil {
+			transport.IdleConnTimeout = x
+		} else {
+			client.logf("[WARNING] Idle connection timeout ignored, HTTP client uses a custom transport")
+		}
+	}
+}
+
+// HTTP2 enables or disables HTTP/2 of the HTTP transport. Disabling it forces HTTP/1.1, e.g. if
+// middleboxes break HTTP/2 connections to the API. By default, HTTP/2 is used if the server
+// supports it. HTTP2 must be passed after TLSConfig, which replaces the TLS configuration.
+func HTTP2(x bool) func(*Client) {
+	return func(client *Client) {
+		transport := client.transport()
+		if transport == nil {
+			client.logf("[WARNING] HTTP/2 setting ignored, HTTP client uses a custom transport")
+			return
+		}
+		transport.ForceAttemptHTTP2 = x
+		if x {
+			transport.TLSNextProto = nil
+			return
+		}
+		// A non-nil empty map disables HTTP/2
+		transport.TLSNextProto = make(map[string]func(string, *tls.Conn) http.RoundTripper)
+		if transport.TLSClientConfig != nil {
+			cfg := transport.TLSClientConfig.Clone()
+			protos := make([]string, 0, len(cfg.NextProtos))
+			for _, proto := range cfg.NextProtos {
+				if proto != "h2" {
+					protos = append(protos, proto)
+				}
+			}
+			cfg.NextProtos = protos
+			transport.TLSClientConfig = cfg
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package meraki
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestConnectionPool tests the MaxIdleConns, MaxIdleConnsPerHost and IdleConnTimeout modifiers.
+func TestConnectionPool(t *testing.T) {
+	client, _ := NewClient("abc123", MaxIdleConns(200), MaxIdleConnsPerHost(32), IdleConnTimeout(5*time.Minute))
+	transport := client.HttpClient.Transport.(*http.Transport)
+	assert.Equal(t, 200, transport.MaxIdleConns)
+	assert.Equal(t, 32, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 5*time.Minute, transport.IdleConnTimeout)
+	assert.Equal(t, 100, http.DefaultTransport.(*http.Transport).MaxIdleConns)
+}
+
+// TestHTTP2 tests the HTTP2 modifier.
+func TestHTTP2(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"proto":"` + r.Proto + `"}`))
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+	cfg := &tls.Config{RootCAs: server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs}
+
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), TLSConfig(cfg), HTTP2(true))
+	res, err := client.Get("/url")
+	assert.NoError(t, err)
+	assert.Equal(t, "HTTP/2.0", res.Get("proto").String())
+
+	client, _ = NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), TLSConfig(cfg), HTTP2(false))
+	res, err = client.Get("/url")
+	assert.NoError(t, err)
+	assert.Equal(t, "HTTP/1.1", res.Get("proto").String())
+}
@@ -0,0 +1,142 @@
+package meraki
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Prefetch fetches up to n pages of a paginated GET request ahead, while the current page is
+// processed, e.g. to cut the wall time of iterating over large client lists:
+//
+//	for item, err := range client.Items("/organizations/123/clients/search", Prefetch(2)) {
+//
+// Cursor based pages, i.e. most endpoints, are only known from the 'Link' header of the previous
+// page, therefore the next page is fetched while the current one is processed. Pages of offset
+// based endpoints, i.e. with a numeric offset parameter and a 'last' link, are fetched up to n
+// at a time. Prefetched pages not processed, e.g. when leaving the loop of
+// Items early, are canceled.
+func Prefetch(n int) func(*Req) {
+	return func(req *Req) {
+		req.Prefetch = n
+	}
+}
+
+// page is the result of fetching a page of a paginated request.
+type page struct {
+	req Req
+	res Res
+	err error
+}
+
+// pager fetches the pages of a paginated request, see Prefetch.
+type pager struct {
+	client   *Client
+	mods     []func(*Req)
+	prefetch int
+	ctx      context.Context
+	cancel   context.CancelFunc
+	// pending are the pages being prefetched by normalized path
+	pending map[string]chan page
+}
+
+// newPager creates a pager for the pages of a request. The pager must be closed to cancel
+// pages still being prefetched.
+func (client *Client) newPager(mods []func(*Req)) *pager {
+	return &pager{client: client, mods: mods}
+}
+
+// fetch returns a page, which was either prefetched or is fetched now, and starts prefetching
+// the following pages. Prefetching starts with the first page of a request with Prefetch.
+func (p *pager) fetch(path string) (Req, Res, error) {
+	var pg page
+	if ch, ok := p.pending[pagePathKey(path)]; ok {
+		delete(p.pending, pagePathKey(path))
+		pg = <-ch
+	} else {
+		pg = p.get(path)
+	}
+	if p.pending == nil && pg.req.Prefetch > 0 {
+		p.prefetch = pg.req.Prefetch
+		p.ctx, p.cancel = context.WithCancel(pg.req.HttpReq.Context())
+		p.pending = make(map[string]chan page)
+		p.mods = append(append([]func(*Req){}, p.mods...), Context(p.ctx))
+	}
+	if pg.err == nil && p.prefetch > 0 {
+		for _, next := range p.client.prefetchPaths(pg.res.Header, p.prefetch) {
+			if len(p.pending) >= p.prefetch {
+				break
+			}
+			if _, ok := p.pending[pagePathKey(next)]; ok {
+				continue
+			}
+			ch := make(chan page, 1)
+			p.pending[pagePathKey(next)] = ch
+			go func(path string) { ch <- p.get(path) }(next)
+		}
+	}
+	return pg.req, pg.res, pg.err
+}
+
+// get fetches a page.
+func (p *pager) get(path string) page {
+	req := p.client.NewReq("GET", path, nil, p.mods...)
+	res, err := p.client.Do(req)
+	return page{req: req, res: res, err: err}
+}
+
+// close cancels all pages still being prefetched.
+func (p *pager) close() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
+
+// prefetchPaths returns the paths of up to n following pages of a paginated response, which is
+// only the next page for cursor based pagination. Pages of offset based pagination are computed
+// from the offsets of the next and last pages and the page size.
+func (client *Client) prefetchPaths(header http.Header, n int) []string {
+	next, ok, err := client.nextPage(header)
+	if !ok || err != nil {
+		return nil
+	}
+	paths := []string{next}
+	last, ok, err := client.linkPage(header, "last")
+	if !ok || err != nil {
+		return paths
+	}
+	nextUrl, err1 := url.Parse(next)
+	lastUrl, err2 := url.Parse(last)
+	if err1 != nil || err2 != nil {
+		return paths
+	}
+	query := nextUrl.Query()
+	step, err := strconv.Atoi(query.Get("perPage"))
+	if err != nil || step < 1 {
+		return paths
+	}
+	offset, err1 := strconv.Atoi(query.Get("offset"))
+	end, err2 := strconv.Atoi(lastUrl.Query().Get("offset"))
+	if err1 != nil || err2 != nil {
+		return paths
+	}
+	for offset += step; offset <= end && len(paths) < n; offset += step {
+		query.Set("offset", strconv.Itoa(offset))
+		u := *nextUrl
+		u.RawQuery = query.Encode()
+		paths = append(paths, u.String())
+	}
+	return paths
+}
+
+// pagePathKey normalizes the path of a page, so paths with the same query parameters in
+// different order are the same page.
+func pagePathKey(path string) string {
+	u, err := url.Parse(path)
+	if err != nil {
+		return path
+	}
+	u.RawQuery = u.Query().Encode()
+	return u.String()
+}
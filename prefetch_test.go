@@ -0,0 +1,65 @@
+package meraki
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestPrefetch tests the Prefetch modifier with offset based pagination.
+func TestPrefetch(t *testing.T) {
+	var requests, active, peak atomic.Int64
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		n := active.Add(1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		time.Sleep(20 * time.Millisecond)
+		active.Add(-1)
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		link := fmt.Sprintf("<%s/clients?perPage=2&offset=6>; rel=\"last\"", server.URL)
+		if offset < 6 {
+			link = fmt.Sprintf("<%s/clients?offset=%d&perPage=2>; rel=\"next\", ", server.URL, offset+2) + link
+		}
+		w.Header().Set("Link", link)
+		fmt.Fprintf(w, `[{"id":%d},{"id":%d}]`, offset, offset+1)
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), Transport(server.Client().Transport))
+	res, err := client.Get("/clients?perPage=2", Prefetch(3))
+	assert.NoError(t, err)
+	assert.Equal(t, `[0,1,2,3,4,5,6,7]`, res.Get("#.id").Raw)
+	assert.Equal(t, int64(4), requests.Load())
+	assert.Equal(t, int64(3), peak.Load())
+
+	// Sequential without Prefetch
+	requests.Store(0)
+	peak.Store(0)
+	res, err = client.Get("/clients?perPage=2")
+	assert.NoError(t, err)
+	assert.Equal(t, `[0,1,2,3,4,5,6,7]`, res.Get("#.id").Raw)
+	assert.Equal(t, int64(4), requests.Load())
+	assert.Equal(t, int64(1), peak.Load())
+}
+
+// TestPrefetchPaths tests the Client::prefetchPaths method.
+func TestPrefetchPaths(t *testing.T) {
+	client := testClient()
+	header := http.Header{}
+	header.Set("Link", "<"+client.BaseUrl+"/clients?perPage=10&startingAfter=abc>; rel=\"next\", <"+client.BaseUrl+"/clients?perPage=10&startingAfter=xyz>; rel=\"last\"")
+	assert.Equal(t, []string{"/clients?perPage=10&startingAfter=abc"}, client.prefetchPaths(header, 3))
+
+	header.Set("Link", "<"+client.BaseUrl+"/clients?perPage=10&offset=10>; rel=\"next\", <"+client.BaseUrl+"/clients?perPage=10&offset=20>; rel=\"last\"")
+	assert.Equal(t, []string{"/clients?perPage=10&offset=10", "/clients?offset=20&perPage=10"}, client.prefetchPaths(header, 3))
+
+	header.Set("Link", "<"+client.BaseUrl+"/clients?perPage=10&offset=10>; rel=\"prev\"")
+	assert.Empty(t, client.prefetchPaths(header, 3))
+}
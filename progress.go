@@ -0,0 +1,78 @@
+package meraki
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Progress is the progress of a long running operation, e.g. a paginated request or a bulk operation.
+type Progress struct {
+	// Pages is the number of pages fetched so far
+	Pages int
+	// Items is the number of items fetched so far
+	Items int
+	// EstimatedPages is the estimated total number of pages, 0 if unknown
+	EstimatedPages int
+	// Done is the number of completed operations of a bulk operation
+	Done int
+	// Total is the total number of operations of a bulk operation
+	Total int
+}
+
+// update updates the progress with a fetched page.
+func (progress *Progress) update(res Res) {
+	progress.Pages++
+	if res.Get("items").Exists() {
+		progress.Items += len(res.Get("items").Array())
+	} else if res.IsArray() {
+		progress.Items += len(res.Array())
+	}
+	if remaining := remainingPages(res.Header.Get("Link")); remaining >= 0 {
+		progress.EstimatedPages = progress.Pages + remaining
+	}
+}
+
+// remainingPages estimates the number of remaining pages from a 'Link' header.
+// It returns -1 if the number of remaining pages cannot be estimated, which is the
+// case for cursor based pagination. Without a next link, no pages are remaining.
+func remainingPages(link string) int {
+	links := make(map[string]url.Values)
+	for _, l := range strings.Split(link, ",") {
+		parts := strings.Split(l, ";")
+		if len(parts) < 2 {
+			continue
+		}
+		u, err := url.Parse(strings.Trim(strings.TrimSpace(parts[0]), "<>"))
+		if err != nil {
+			continue
+		}
+		for _, p := range parts[1:] {
+			p = strings.TrimSpace(p)
+			if strings.HasPrefix(p, "rel=") {
+				links[strings.Trim(strings.TrimPrefix(p, "rel="), `"`)] = u.Query()
+			}
+		}
+	}
+	next, ok := links["next"]
+	if !ok {
+		return 0
+	}
+	last, ok := links["last"]
+	if !ok {
+		return -1
+	}
+	for _, key := range []string{"offset", "startingAfter"} {
+		n, err1 := strconv.Atoi(next.Get(key))
+		l, err2 := strconv.Atoi(last.Get(key))
+		if err1 != nil || err2 != nil || l < n {
+			continue
+		}
+		step, err := strconv.Atoi(next.Get("perPage"))
+		if err != nil || step < 1 {
+			return -1
+		}
+		return (l-n)/step + 1
+	}
+	return -1
+}
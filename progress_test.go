@@ -0,0 +1,60 @@
+package meraki
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestOnProgress tests the OnProgress modifier.
+func TestOnProgress(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Get("/url").
+		Reply(200).
+		BodyString(`["1","2"]`).
+		Header.Set("Link", `<`+client.BaseUrl+`/url?perPage=2&offset=0>; rel="first", <`+client.BaseUrl+`/url?perPage=2&offset=2>; rel="next", <`+client.BaseUrl+`/url?perPage=2&offset=4>; rel="last"`)
+	gock.New(client.BaseUrl).Get("/url").MatchParam("offset", "2").
+		Reply(200).
+		BodyString(`["3","4"]`).
+		Header.Set("Link", `<`+client.BaseUrl+`/url?perPage=2&offset=4>; rel="next", <`+client.BaseUrl+`/url?perPage=2&offset=4>; rel="last"`)
+	gock.New(client.BaseUrl).Get("/url").MatchParam("offset", "4").
+		Reply(200).
+		BodyString(`["5"]`).
+		Header.Set("Link", `<`+client.BaseUrl+`/url?perPage=2&offset=0>; rel="first"`)
+
+	progress := make([]Progress, 0)
+	_, err := client.Get("/url", OnProgress(func(p Progress) {
+		progress = append(progress, p)
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, []Progress{
+		{Pages: 1, Items: 2, EstimatedPages: 3},
+		{Pages: 2, Items: 4, EstimatedPages: 3},
+		{Pages: 3, Items: 5, EstimatedPages: 3},
+	}, progress)
+}
+
+// TestRemainingPages tests the remainingPages function.
+func TestRemainingPages(t *testing.T) {
+	assert.Equal(t, 0, remainingPages(""))
+	assert.Equal(t, -1, remainingPages(`<https://a/url?startingAfter=abc>; rel=next`))
+	assert.Equal(t, -1, remainingPages(`<https://a/url?startingAfter=abc>; rel="next", <https://a/url?endingBefore=zzz>; rel="last"`))
+	assert.Equal(t, 3, remainingPages(`<https://a/url?perPage=10&offset=10>; rel="next", <https://a/url?perPage=10&offset=30>; rel="last"`))
+}
+
+// TestReportProgress tests the ReportProgress modifier.
+func TestReportProgress(t *testing.T) {
+	client := testClient()
+	progress := make([]Progress, 0)
+	scheduler := NewScheduler(&client, ReportProgress(func(p Progress) {
+		progress = append(progress, p)
+	}))
+	scheduler.RunOrgs(context.Background(), []Org{client.NewOrg("1"), client.NewOrg("2")}, func(ctx context.Context, org Org) (interface{}, error) {
+		return nil, nil
+	})
+	assert.Equal(t, []Progress{{Done: 1, Total: 2}, {Done: 2, Total: 2}}, progress)
+}
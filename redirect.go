@@ -0,0 +1,174 @@
+package meraki
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// maxRedirects is the maximum number of redirects followed by a request, like the default of http.Client.
+const maxRedirects = 10
+
+// merakiDomains are the parent domains of the regional Dashboard API hosts, see Region.
+var merakiDomains = []string{"meraki.com", "meraki.ca", "meraki.cn", "meraki.in", "gov-meraki.com"}
+
+// shardRegexp matches the first label of the host of a Dashboard API shard, e.g. n123.
+var shardRegexp = regexp.MustCompile(`^n[0-9]+$`)
+
+// TrustRedirectHosts adds hosts the authentication headers are sent to when a request is
+// redirected to them, besides the shards of the Dashboard API, e.g. for a proxy or a mock
+// server redirecting to other hosts:
+//
+//	client, _ := NewClient("abc123", BaseUrl("https://meraki-proxy.example.com/api/v1"), TrustRedirectHosts("meraki-shard.example.com"))
+func TrustRedirectHosts(hosts ...string) func(*Client) {
+	return func(client *Client) {
+		client.RedirectHosts = append(client.RedirectHosts, hosts...)
+		client.HttpClient.CheckRedirect = redirectPolicy(client.RedirectHosts)
+	}
+}
+
+// redirectPolicy returns the redirect policy of the HTTP client. The Dashboard API redirects
+// requests to organization specific shards, e.g. from api.meraki.com to n123.meraki.com, where
+// Go strips the authentication headers, as the shard is not a subdomain of the original host.
+// They are re-applied if the target is the original host, a shard in the domain of a Dashboard
+// API host or one of the trusted hosts, and the redirect does not downgrade from HTTPS to HTTP,
+// and removed otherwise, as Go only strips the Authorization header but forwards the API key
+// header. Bodies of 307 and 308 redirects are sent again.
+func redirectPolicy(hosts []string) func(req *http.Request, via []*http.Request) error {
+	hosts = append([]string{}, hosts...)
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return errors.New("stopped after 10 redirects")
+		}
+		original := via[0]
+		if !trustedRedirect(original, req, hosts) {
+			req.Header.Del("Authorization")
+			req.Header.Del(AuthSchemeApiKey)
+			return nil
+		}
+		for _, header := range []string{"Authorization", AuthSchemeApiKey} {
+			if value := original.Header.Get(header); value != "" && req.Header.Get(header) == "" {
+				req.Header.Set(header, value)
+			}
+		}
+		return nil
+	}
+}
+
+// trustedRedirect reports whether the authentication headers of a request may be sent to a redirect target.
+func trustedRedirect(original, target *http.Request, hosts []string) bool {
+	if original.URL.Scheme == "https" && target.URL.Scheme != "https" {
+		return false
+	}
+	host, targetHost := strings.ToLower(original.URL.Hostname()), strings.ToLower(target.URL.Hostname())
+	if host == targetHost {
+		return true
+	}
+	for _, h := range hosts {
+		if strings.EqualFold(h, targetHost) {
+			return true
+		}
+	}
+	_, parent, _ := strings.Cut(host, ".")
+	label, targetParent, _ := strings.Cut(targetHost, ".")
+	return parent == targetParent && slices.Contains(merakiDomains, parent) && shardRegexp.MatchString(label)
+}
+
+// shardState is the shard of the base URL learned from redirects. It is shared by all copies of a client.
+type shardState struct {
+	mutex sync.Mutex
+	url   string
+}
+
+// CacheShard sends requests directly to the shard the API redirected the last request to,
+// e.g. n123.meraki.com instead of api.meraki.com, which avoids the additional round trip of
+// the redirect on every request during bulk operations. If the shard redirects again, the
+// new shard is used, if the connection to the shard fails, requests are sent to BaseUrl again.
+func CacheShard() func(*Client) {
+	return func(client *Client) {
+		if client.shard == nil {
+			client.shard = &shardState{}
+		}
+	}
+}
+
+// Shard returns the base URL of the cached shard or an empty string if no shard is cached, see CacheShard.
+func (client *Client) Shard() string {
+	if client.shard == nil {
+		return ""
+	}
+	client.shard.mutex.Lock()
+	defer client.shard.mutex.Unlock()
+	return client.shard.url
+}
+
+// shardUrl returns the base URL a request attempt to a base URL is sent to.
+func (client *Client) shardUrl(baseUrl string) string {
+	if baseUrl != client.BaseUrl {
+		return baseUrl
+	}
+	if shard := client.Shard(); shard != "" {
+		return shard
+	}
+	return baseUrl
+}
+
+// reportShard records the shard a request to BaseUrl was redirected to, or forgets the cached
+// shard if the connection to it failed. Requests canceled by the caller keep the cached shard.
+func (client *Client) reportShard(req *http.Request, baseUrl, targetUrl string, res *http.Response, err error) {
+	if client.shard == nil || baseUrl != client.BaseUrl || (err != nil && callerCanceled(req.Context(), err)) {
+		return
+	}
+	client.shard.mutex.Lock()
+	defer client.shard.mutex.Unlock()
+	if err != nil {
+		if targetUrl != baseUrl && client.shard.url == targetUrl {
+			client.logf("[WARNING] Connection to shard %s failed, using base URL %s", targetUrl, baseUrl)
+			client.shard.url = ""
+		}
+		return
+	}
+	final := res.Request
+	if final == nil || final.URL.Host == req.URL.Host || final.URL.Path != req.URL.Path || !trustedRedirect(req, final, client.RedirectHosts) {
+		return
+	}
+	base, err := url.Parse(client.BaseUrl)
+	if err != nil {
+		return
+	}
+	shard := final.URL.Scheme + "://" + final.URL.Host + strings.TrimSuffix(base.Path, "/")
+	if shard != client.shard.url {
+		client.logf("[DEBUG] Caching shard %s of base URL %s", shard, baseUrl)
+		client.shard.url = shard
+	}
+}
+
+// shardPath returns the path relative to the base URL of a link to a shard the API may have
+// redirected to, i.e. a trusted redirect target of a base URL with the same base path, e.g.
+// the 'next' link of a page served by n123.meraki.com for the base URL api.meraki.com.
+func (client *Client) shardPath(link string) (string, bool) {
+	target, err := url.Parse(link)
+	if err != nil || target.Host == "" {
+		return "", false
+	}
+	for _, baseUrl := range client.baseUrls() {
+		base, err := url.Parse(baseUrl)
+		if err != nil || !trustedRedirect(&http.Request{URL: base}, &http.Request{URL: target}, client.RedirectHosts) {
+			continue
+		}
+		basePath := strings.TrimSuffix(base.Path, "/")
+		if !strings.HasPrefix(target.Path, basePath+"/") {
+			continue
+		}
+		path := strings.TrimPrefix(target.Path, basePath)
+		if target.RawQuery != "" {
+			path += "?" + target.RawQuery
+		}
+		return path, true
+	}
+	return "", false
+}
@@ -0,0 +1,258 @@
+package meraki
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// redirectServers starts an API server redirecting to a shard server and returns a dial function
+// resolving api.meraki.com to the API server and other hosts to the shard server as well as the
+// number of requests to the API server.
+func redirectServers(t *testing.T, status int, shardHost string) (func(ctx context.Context, network, address string) (net.Conn, error), *int) {
+	apiRequests := 0
+	shard := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get("Authorization") != "Bearer abc123" {
+			w.WriteHeader(401)
+			w.Write([]byte(`{"errors":["Invalid API key"]}`))
+			return
+		}
+		w.Write([]byte(`{"host":"` + r.Host + `","method":"` + r.Method + `","body":"` + string(body) + `"}`))
+	}))
+	t.Cleanup(shard.Close)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiRequests++
+		http.Redirect(w, r, "http://"+shardHost+r.URL.RequestURI(), status)
+	}))
+	t.Cleanup(api.Close)
+	dial := func(ctx context.Context, network, address string) (net.Conn, error) {
+		if address == "api.meraki.com:80" {
+			address = api.Listener.Addr().String()
+		} else {
+			address = shard.Listener.Addr().String()
+		}
+		return (&net.Dialer{}).DialContext(ctx, network, address)
+	}
+	return dial, &apiRequests
+}
+
+// TestRedirect tests following shard redirects.
+func TestRedirect(t *testing.T) {
+	dial, _ := redirectServers(t, 308, "n123.meraki.com")
+	client, _ := NewClient("abc123", BaseUrl("http://api.meraki.com/api/v1"), MaxRetries(0), DialContext(dial))
+
+	res, err := client.Get("/organizations")
+	assert.NoError(t, err)
+	assert.Equal(t, "n123.meraki.com", res.Get("host").String())
+
+	res, err = client.Post("/organizations", `x`)
+	assert.NoError(t, err)
+	assert.Equal(t, "POST", res.Get("method").String())
+	assert.Equal(t, "x", res.Get("body").String())
+
+	// Authentication headers are not sent to other domains
+	dial, _ = redirectServers(t, 302, "n123.example.test")
+	client, _ = NewClient("abc123", BaseUrl("http://api.meraki.com/api/v1"), MaxRetries(0), DialContext(dial))
+	_, err = client.Get("/organizations")
+	assert.ErrorContains(t, err, "StatusCode 401")
+
+	// unless the host is trusted
+	client, _ = NewClient("abc123", BaseUrl("http://api.meraki.com/api/v1"), MaxRetries(0), DialContext(dial), TrustRedirectHosts("N123.example.test"))
+	res, err = client.Get("/organizations")
+	assert.NoError(t, err)
+	assert.Equal(t, "n123.example.test", res.Get("host").String())
+}
+
+// TestTrustedRedirect tests which redirect targets the authentication headers are sent to.
+func TestTrustedRedirect(t *testing.T) {
+	for _, c := range []struct {
+		original, target string
+		trusted          bool
+	}{
+		{"https://api.meraki.com/api/v1", "https://api.meraki.com/api/v1", true},
+		{"https://api.meraki.com/api/v1", "https://n123.meraki.com/api/v1", true},
+		{"https://api.gov-meraki.com/api/v1", "https://n7.gov-meraki.com/api/v1", true},
+		{"https://api.meraki.com/api/v1", "http://n123.meraki.com/api/v1", false},
+		{"https://api.meraki.com/api/v1", "https://www.meraki.com/api/v1", false},
+		{"https://api.meraki.com/api/v1", "https://n123.meraki.ca/api/v1", false},
+		{"https://api.example.co.uk/api/v1", "https://n123.example.co.uk/api/v1", false},
+		{"https://example.co.uk/api/v1", "https://n123.co.uk/api/v1", false},
+		{"https://api.example.com/api/v1", "https://shard.example.com/api/v1", false},
+		{"https://10.0.0.1/api/v1", "https://5.0.0.1/api/v1", false},
+	} {
+		original, _ := url.Parse(c.original)
+		target, _ := url.Parse(c.target)
+		assert.Equal(t, c.trusted, trustedRedirect(&http.Request{URL: original}, &http.Request{URL: target}, nil), c.target)
+	}
+
+	original, _ := url.Parse("https://api.example.com/api/v1")
+	target, _ := url.Parse("https://shard.example.com/api/v1")
+	assert.True(t, trustedRedirect(&http.Request{URL: original}, &http.Request{URL: target}, []string{"shard.example.com"}))
+}
+
+// TestCacheShard tests the CacheShard modifier.
+func TestCacheShard(t *testing.T) {
+	dial, apiRequests := redirectServers(t, 302, "n123.meraki.com")
+	client, _ := NewClient("abc123", BaseUrl("http://api.meraki.com/api/v1"), MaxRetries(0), DialContext(dial), CacheShard())
+
+	for i := 0; i < 3; i++ {
+		res, err := client.Get("/organizations")
+		assert.NoError(t, err)
+		assert.Equal(t, "n123.meraki.com", res.Get("host").String())
+	}
+	assert.Equal(t, 1, *apiRequests)
+	assert.Equal(t, "http://n123.meraki.com/api/v1", client.Shard())
+
+	// Unreachable shards are forgotten
+	client.shard.url = "http://n124.meraki.invalid/api/v1"
+	client.dial.dial = func(ctx context.Context, network, address string) (net.Conn, error) {
+		if address == "n124.meraki.invalid:80" {
+			return nil, errors.New("connection refused")
+		}
+		return dial(ctx, network, address)
+	}
+	_, err := client.Get("/organizations")
+	assert.Error(t, err)
+	assert.Equal(t, "", client.Shard())
+
+	// Requests canceled by the caller keep the shard
+	blocking := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer blocking.Close()
+	client.shard.url = blocking.URL + "/api/v1"
+	client.dial.dial = (&net.Dialer{}).DialContext
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = client.GetContext(ctx, "/organizations")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, blocking.URL+"/api/v1", client.Shard())
+
+	// Other domains are not cached
+	dial, _ = redirectServers(t, 302, "n123.example.test")
+	client, _ = NewClient("abc123", BaseUrl("http://api.meraki.com/api/v1"), MaxRetries(0), DialContext(dial), CacheShard())
+	client.Get("/organizations")
+	assert.Equal(t, "", client.Shard())
+}
+
+// TestRedirectUntrusted tests that authentication headers are not sent to untrusted redirect targets.
+func TestRedirectUntrusted(t *testing.T) {
+	headers := make(chan http.Header, 1)
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Clone()
+		w.Write([]byte(`{}`))
+	}))
+	defer target.Close()
+	redirect := func(location string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, location+r.URL.RequestURI(), 302)
+		}
+	}
+	foreign := httptest.NewServer(redirect("http://n123.example.test"))
+	defer foreign.Close()
+	downgrade := httptest.NewTLSServer(redirect("http://n123.meraki.com"))
+	defer downgrade.Close()
+	dial := func(ctx context.Context, network, address string) (net.Conn, error) {
+		switch address {
+		case "api.meraki.com:80":
+			address = foreign.Listener.Addr().String()
+		case "api.meraki.com:443":
+			address = downgrade.Listener.Addr().String()
+		default:
+			address = target.Listener.Addr().String()
+		}
+		return (&net.Dialer{}).DialContext(ctx, network, address)
+	}
+
+	for _, baseUrl := range []string{"http://api.meraki.com/api/v1", "https://api.meraki.com/api/v1"} {
+		for _, scheme := range []string{AuthSchemeBearer, AuthSchemeApiKey} {
+			client, _ := NewClient("abc123", BaseUrl(baseUrl), MaxRetries(0), DialContext(dial), Insecure(), AuthScheme(scheme))
+			_, err := client.Get("/organizations")
+			assert.NoError(t, err)
+			header := <-headers
+			assert.Empty(t, header.Get("Authorization"), baseUrl)
+			assert.Empty(t, header.Get(AuthSchemeApiKey), baseUrl)
+		}
+	}
+}
+
+// TestRedirectIP tests that authentication headers are only sent to the same host if the base URL is an IP address.
+func TestRedirectIP(t *testing.T) {
+	headers := make(chan http.Header, 1)
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers <- r.Header.Clone()
+		w.Write([]byte(`{}`))
+	}))
+	defer target.Close()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://5.0.0.1"+r.URL.RequestURI(), 302)
+	}))
+	defer api.Close()
+	dial := func(ctx context.Context, network, address string) (net.Conn, error) {
+		if address == "10.0.0.1:80" {
+			address = api.Listener.Addr().String()
+		} else {
+			address = target.Listener.Addr().String()
+		}
+		return (&net.Dialer{}).DialContext(ctx, network, address)
+	}
+
+	for _, scheme := range []string{AuthSchemeBearer, AuthSchemeApiKey} {
+		client, _ := NewClient("abc123", BaseUrl("http://10.0.0.1/api/v1"), MaxRetries(0), DialContext(dial), AuthScheme(scheme))
+		_, err := client.Get("/organizations")
+		assert.NoError(t, err)
+		header := <-headers
+		assert.Empty(t, header.Get("Authorization"))
+		assert.Empty(t, header.Get(AuthSchemeApiKey))
+	}
+}
+
+// TestRedirectPagination tests following the 'Link' header of pages served by a shard.
+func TestRedirectPagination(t *testing.T) {
+	shard := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("startingAfter") == "" {
+			w.Header().Set("Link", `<http://n123.meraki.com/api/v1/organizations?startingAfter=1>; rel="next"`)
+			w.Write([]byte(`[{"id":"1"}]`))
+			return
+		}
+		w.Header().Set("Link", `<http://n123.meraki.com/api/v1/organizations>; rel="first"`)
+		w.Write([]byte(`[{"id":"2"}]`))
+	}))
+	defer shard.Close()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://n123.meraki.com"+r.URL.RequestURI(), 302)
+	}))
+	defer api.Close()
+	dial := func(ctx context.Context, network, address string) (net.Conn, error) {
+		if address == "api.meraki.com:80" {
+			address = api.Listener.Addr().String()
+		} else {
+			address = shard.Listener.Addr().String()
+		}
+		return (&net.Dialer{}).DialContext(ctx, network, address)
+	}
+
+	for _, mods := range [][]func(*Client){{}, {CacheShard()}} {
+		mods = append(mods, BaseUrl("http://api.meraki.com/api/v1"), MaxRetries(0), DialContext(dial))
+		client, _ := NewClient("abc123", mods...)
+		res, err := client.Get("/organizations")
+		assert.NoError(t, err)
+		assert.Equal(t, `["1","2"]`, res.Get("#.id").Raw)
+	}
+
+	// Links to other domains are rejected
+	client, _ := NewClient("abc123", BaseUrl("http://api.meraki.com/api/v1"))
+	_, ok := client.shardPath("http://n123.example.test/api/v1/organizations")
+	assert.False(t, ok)
+	_, ok = client.shardPath("http://n123.meraki.com/other/organizations")
+	assert.False(t, ok)
+}
@@ -0,0 +1,47 @@
+package meraki
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Base URLs of the regional Dashboard API deployments.
+const (
+	BaseUrlGlobal    string = "https://api.meraki.com/api/v1"
+	BaseUrlCanada    string = "https://api.meraki.ca/api/v1"
+	BaseUrlChina     string = "https://api.meraki.cn/api/v1"
+	BaseUrlIndia     string = "https://api.meraki.in/api/v1"
+	BaseUrlUSFederal string = "https://api.gov-meraki.com/api/v1"
+)
+
+// regions are the base URLs by region name, see Region.
+var regions = map[string]string{
+	"global":     BaseUrlGlobal,
+	"canada":     BaseUrlCanada,
+	"china":      BaseUrlChina,
+	"india":      BaseUrlIndia,
+	"us-federal": BaseUrlUSFederal,
+}
+
+// Region modifies the API base URL to the Dashboard API of a region, i.e. global, canada, china,
+// india or us-federal, e.g.
+//
+//	client, _ := NewClient("abc123", Region("china"))
+//
+// Region names are case-insensitive. NewClient fails if the region is unknown.
+func Region(name string) func(*Client) {
+	return func(client *Client) {
+		baseUrl, ok := regions[strings.ToLower(name)]
+		if !ok {
+			names := make([]string, 0, len(regions))
+			for region := range regions {
+				names = append(names, region)
+			}
+			sort.Strings(names)
+			client.fail(fmt.Errorf("unknown region '%s', must be one of %s", name, strings.Join(names, ", ")))
+			return
+		}
+		client.BaseUrl = baseUrl
+	}
+}
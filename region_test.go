@@ -0,0 +1,20 @@
+package meraki
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRegion tests the Region modifier.
+func TestRegion(t *testing.T) {
+	client, err := NewClient("abc123", Region("China"))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.meraki.cn/api/v1", client.BaseUrl)
+
+	client, _ = NewClient("abc123", Region("us-federal"))
+	assert.Equal(t, BaseUrlUSFederal, client.BaseUrl)
+
+	_, err = NewClient("abc123", Region("mars"))
+	assert.ErrorContains(t, err, "unknown region 'mars', must be one of canada, china, global, india, us-federal")
+}
@@ -0,0 +1,241 @@
+package meraki
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ReplayRequest is a recorded request, see ReadHAR and ReadCapture.
+type ReplayRequest struct {
+	// Method is the HTTP method, e.g. GET
+	Method string `json:"method"`
+	// Path is the API path relative to the base URL including the query, e.g. /networks/N_123/clients?perPage=10
+	Path string `json:"path"`
+	// Body is the request body, empty for GET and DELETE requests
+	Body string `json:"body,omitempty"`
+}
+
+// ReplayResult is the result of a replayed request.
+type ReplayResult struct {
+	// Request is the replayed request after substitutions
+	Request ReplayRequest
+	// Res is the response
+	Res Res
+	// Err is the error of the request, nil if it succeeded
+	Err error
+}
+
+// ReplayOptions modify the behavior of Replay.
+type ReplayOptions struct {
+	// GetOnly skips all requests which are not GET requests
+	GetOnly bool
+	// Substitutions are replaced in the paths and bodies of the requests, e.g. IDs of another organization
+	Substitutions map[string]string
+	// Mods are the request modifiers of the replayed requests
+	Mods []func(*Req)
+}
+
+// ReplayGetOnly only replays GET requests, e.g. against a production organization.
+func ReplayGetOnly(options *ReplayOptions) {
+	options.GetOnly = true
+}
+
+// ReplaySubstitute replaces a string, e.g. an ID, in the paths and bodies of the replayed requests.
+func ReplaySubstitute(old, new string) func(*ReplayOptions) {
+	return func(options *ReplayOptions) {
+		if options.Substitutions == nil {
+			options.Substitutions = make(map[string]string)
+		}
+		options.Substitutions[old] = new
+	}
+}
+
+// ReplayReqMods adds request modifiers to the replayed requests.
+func ReplayReqMods(mods ...func(*Req)) func(*ReplayOptions) {
+	return func(options *ReplayOptions) {
+		options.Mods = append(options.Mods, mods...)
+	}
+}
+
+// Replay re-issues recorded requests in order through the client, e.g. to reproduce a bug or
+// to load test a proxy. GET requests are not paginated, so every page is replayed as recorded, e.g.
+//
+//	f, _ := os.Open("session.har")
+//	requests, _ := ReadHAR(f)
+//	results := client.Replay(requests, ReplayGetOnly, ReplaySubstitute("123456", "654321"))
+func (client *Client) Replay(requests []ReplayRequest, mods ...func(*ReplayOptions)) []ReplayResult {
+	options := ReplayOptions{}
+	for _, mod := range mods {
+		mod(&options)
+	}
+	// Longer substitutions are tried first, so overlapping IDs are replaced deterministically
+	olds := make([]string, 0, len(options.Substitutions))
+	for old := range options.Substitutions {
+		olds = append(olds, old)
+	}
+	sort.Slice(olds, func(i, j int) bool {
+		if len(olds[i]) != len(olds[j]) {
+			return len(olds[i]) > len(olds[j])
+		}
+		return olds[i] < olds[j]
+	})
+	pairs := make([]string, 0, 2*len(olds))
+	for _, old := range olds {
+		pairs = append(pairs, old, options.Substitutions[old])
+	}
+	replacer := strings.NewReplacer(pairs...)
+	results := make([]ReplayResult, 0, len(requests))
+	for _, r := range requests {
+		r.Method = strings.ToUpper(r.Method)
+		if options.GetOnly && r.Method != "GET" {
+			continue
+		}
+		r.Path = replacer.Replace(r.Path)
+		r.Body = replacer.Replace(r.Body)
+		var body io.Reader
+		if r.Body != "" {
+			body = strings.NewReader(r.Body)
+		}
+		req := client.NewReq(r.Method, r.Path, body, options.Mods...)
+		res, err := client.Do(req)
+		results = append(results, ReplayResult{Request: r, Res: res, Err: err})
+	}
+	return results
+}
+
+// Recorder records the requests of a client in the capture format, e.g. to replay a session
+// later, see Record. Use meraki.NewRecorder to initiate a recorder.
+type Recorder struct {
+	mutex    sync.Mutex
+	requests []ReplayRequest
+}
+
+// NewRecorder creates a new empty recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{requests: make([]ReplayRequest, 0)}
+}
+
+// Record records every request of the client, including the individual pages of paginated
+// GET requests and requests served from a cache, e.g.
+//
+//	recorder := NewRecorder()
+//	client, _ := NewClient("abc123", Record(recorder))
+//	// ...
+//	f, _ := os.Create("session.jsonl")
+//	recorder.WriteCapture(f)
+//
+// Request bodies are recorded as sent, use Anonymizer.Writer to mask identifiers in the capture.
+func Record(r *Recorder) func(*Client) {
+	return func(client *Client) {
+		client.Recorder = r
+	}
+}
+
+// Requests returns the recorded requests in the order they were made.
+func (r *Recorder) Requests() []ReplayRequest {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]ReplayRequest(nil), r.requests...)
+}
+
+// WriteCapture writes the recorded requests in the capture format, see WriteCapture.
+func (r *Recorder) WriteCapture(w io.Writer) error {
+	return WriteCapture(w, r.Requests())
+}
+
+// record adds a request to the recorder of the client.
+func (client *Client) record(req Req) {
+	if client.Recorder == nil {
+		return
+	}
+	path := client.relPath(req.HttpReq.URL)
+	if req.HttpReq.URL.RawQuery != "" {
+		path += "?" + req.HttpReq.URL.RawQuery
+	}
+	request := ReplayRequest{Method: req.HttpReq.Method, Path: path}
+	if req.HttpReq.GetBody != nil {
+		if body, err := req.HttpReq.GetBody(); err == nil {
+			data, _ := io.ReadAll(body)
+			request.Body = string(data)
+		}
+	}
+	client.Recorder.mutex.Lock()
+	defer client.Recorder.mutex.Unlock()
+	client.Recorder.requests = append(client.Recorder.requests, request)
+}
+
+// ReadHAR reads the Meraki API requests of a HTTP Archive (HAR), e.g. exported from the browser
+// developer tools. Requests to other hosts than the client base URL are included as well, their
+// paths are made relative by removing the /api/v1 prefix.
+func ReadHAR(r io.Reader) ([]ReplayRequest, error) {
+	var har struct {
+		Log struct {
+			Entries []struct {
+				Request struct {
+					Method   string `json:"method"`
+					URL      string `json:"url"`
+					PostData struct {
+						Text string `json:"text"`
+					} `json:"postData"`
+				} `json:"request"`
+			} `json:"entries"`
+		} `json:"log"`
+	}
+	if err := json.NewDecoder(r).Decode(&har); err != nil {
+		return nil, err
+	}
+	requests := make([]ReplayRequest, 0, len(har.Log.Entries))
+	for _, entry := range har.Log.Entries {
+		u, err := url.Parse(entry.Request.URL)
+		if err != nil {
+			return nil, err
+		}
+		path := u.Path
+		if i := strings.Index(path, "/api/v1/"); i >= 0 {
+			path = path[i+len("/api/v1"):]
+		}
+		if u.RawQuery != "" {
+			path += "?" + u.RawQuery
+		}
+		requests = append(requests, ReplayRequest{Method: entry.Request.Method, Path: path, Body: entry.Request.PostData.Text})
+	}
+	return requests, nil
+}
+
+// ReadCapture reads requests in the capture format, which is one JSON encoded ReplayRequest per line, e.g.
+//
+//	{"method":"GET","path":"/organizations/123/networks"}
+//	{"method":"PUT","path":"/networks/N_123","body":"{\"name\":\"New\"}"}
+func ReadCapture(r io.Reader) ([]ReplayRequest, error) {
+	requests := make([]ReplayRequest, 0)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var request ReplayRequest
+		if err := json.Unmarshal([]byte(line), &request); err != nil {
+			return nil, err
+		}
+		requests = append(requests, request)
+	}
+	return requests, scanner.Err()
+}
+
+// WriteCapture writes requests in the capture format, see ReadCapture.
+func WriteCapture(w io.Writer, requests []ReplayRequest) error {
+	encoder := json.NewEncoder(w)
+	for _, request := range requests {
+		if err := encoder.Encode(request); err != nil {
+			return err
+		}
+	}
+	return nil
+}
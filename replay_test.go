@@ -0,0 +1,105 @@
+package meraki
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+const testHAR = `{"log":{"entries":[
+	{"request":{"method":"GET","url":"https://api.meraki.com/api/v1/organizations/123/networks?perPage=10"}},
+	{"request":{"method":"PUT","url":"https://n123.meraki.com/api/v1/networks/N_123","postData":{"text":"{\"name\":\"N_123\"}"}}}
+]}}`
+
+// TestReadHAR tests the ReadHAR function.
+func TestReadHAR(t *testing.T) {
+	requests, err := ReadHAR(strings.NewReader(testHAR))
+	assert.NoError(t, err)
+	assert.Equal(t, []ReplayRequest{
+		{Method: "GET", Path: "/organizations/123/networks?perPage=10"},
+		{Method: "PUT", Path: "/networks/N_123", Body: `{"name":"N_123"}`},
+	}, requests)
+
+	_, err = ReadHAR(strings.NewReader(`{`))
+	assert.Error(t, err)
+}
+
+// TestReadCapture tests the ReadCapture and WriteCapture functions.
+func TestReadCapture(t *testing.T) {
+	requests, _ := ReadHAR(strings.NewReader(testHAR))
+	var buf bytes.Buffer
+	assert.NoError(t, WriteCapture(&buf, requests))
+	captured, err := ReadCapture(strings.NewReader(buf.String() + "\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, requests, captured)
+
+	_, err = ReadCapture(strings.NewReader(`{"method"`))
+	assert.Error(t, err)
+}
+
+// TestReplay tests the Client::Replay method.
+func TestReplay(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+	requests, _ := ReadHAR(strings.NewReader(testHAR))
+
+	gock.New(client.BaseUrl).Get("/organizations/456/networks").MatchParam("perPage", "10").Reply(200).BodyString(`[]`)
+	gock.New(client.BaseUrl).Put("/networks/N_456").BodyString(`{"name":"N_456"}`).Reply(200).BodyString(`{}`)
+	results := client.Replay(requests, ReplaySubstitute("123", "456"))
+	assert.Len(t, results, 2)
+	assert.NoError(t, results[0].Err)
+	assert.NoError(t, results[1].Err)
+	assert.Equal(t, "/networks/N_456", results[1].Request.Path)
+
+	// Overlapping substitutions are replaced longest first
+	requests = []ReplayRequest{{Method: "GET", Path: "/organizations/1234/networks"}, {Method: "GET", Path: "/organizations/123/networks"}}
+	gock.New(client.BaseUrl).Get("/organizations/9999/networks").Reply(200).BodyString(`[]`)
+	gock.New(client.BaseUrl).Get("/organizations/456/networks").Reply(200).BodyString(`[]`)
+	results = client.Replay(requests, ReplaySubstitute("123", "456"), ReplaySubstitute("1234", "9999"))
+	assert.Equal(t, "/organizations/9999/networks", results[0].Request.Path)
+	assert.Equal(t, "/organizations/456/networks", results[1].Request.Path)
+	assert.NoError(t, results[0].Err)
+	assert.NoError(t, results[1].Err)
+	requests, _ = ReadHAR(strings.NewReader(testHAR))
+
+	gock.New(client.BaseUrl).Get("/organizations/123/networks").Reply(500)
+	results = client.Replay(requests, ReplayGetOnly)
+	assert.Len(t, results, 1)
+	assert.Error(t, results[0].Err)
+}
+
+// TestRecord tests the Record modifier with a round trip through the capture format.
+func TestRecord(t *testing.T) {
+	defer gock.Off()
+	recorder := NewRecorder()
+	client, _ := NewClient("abc123", MaxRetries(0), Record(recorder))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(client.BaseUrl).Get("/organizations/123/networks").MatchParam("perPage", "10").Reply(200).BodyString(`[]`)
+	gock.New(client.BaseUrl).Put("/networks/N_123").Reply(200).BodyString(`{}`)
+	client.Get("/organizations/123/networks", Query("perPage", "10"))
+	client.Put("/networks/N_123", `{"name":"New"}`)
+	assert.True(t, gock.IsDone())
+	requests := []ReplayRequest{
+		{Method: "GET", Path: "/organizations/123/networks?perPage=10"},
+		{Method: "PUT", Path: "/networks/N_123", Body: `{"name":"New"}`},
+	}
+	assert.Equal(t, requests, recorder.Requests())
+
+	var buf bytes.Buffer
+	assert.NoError(t, recorder.WriteCapture(&buf))
+	captured, err := ReadCapture(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, requests, captured)
+
+	gock.New(client.BaseUrl).Get("/organizations/123/networks").MatchParam("perPage", "10").Reply(200).BodyString(`[]`)
+	gock.New(client.BaseUrl).Put("/networks/N_123").BodyString(`{"name":"New"}`).Reply(200).BodyString(`{}`)
+	results := client.Replay(captured)
+	assert.Len(t, results, 2)
+	assert.NoError(t, results[0].Err)
+	assert.NoError(t, results[1].Err)
+	assert.True(t, gock.IsDone())
+}
@@ -1,7 +1,10 @@
 package meraki
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
@@ -11,34 +14,90 @@ import (
 // Usage example:
 //
 //	Body{}.Set("name", "ABC").Str
+//
+// Errors of Set, SetRaw and Delete are recorded and returned by Build, e.g.
+//
+//	body, err := Body{}.Set("name", "ABC").Set("tags", tags).Build()
 type Body struct {
 	Str string
+	err error
 }
 
 // Set sets a JSON path to a value.
 func (body Body) Set(path string, value interface{}) Body {
-	res, _ := sjson.Set(body.Str, path, value)
-	body.Str = res
+	body, _ = body.SetE(path, value)
 	return body
 }
 
+// SetE sets a JSON path to a value and returns an error if the path or value is invalid.
+func (body Body) SetE(path string, value interface{}) (Body, error) {
+	res, err := sjson.Set(body.Str, path, value)
+	if err != nil {
+		return body.fail(fmt.Errorf("failed to set '%s': %w", path, err))
+	}
+	body.Str = res
+	return body, nil
+}
+
 // SetRaw sets a JSON path to a raw string value.
 // This is primarily used for building up nested structures, e.g.:
 //
 //	Body{}.SetRaw("children", Body{}.Set("name", "New").Str).Str
 func (body Body) SetRaw(path, rawValue string) Body {
-	res, _ := sjson.SetRaw(body.Str, path, rawValue)
+	res, err := body.SetRawE(path, rawValue)
+	if err != nil {
+		// keep the raw value for backward compatibility, Build reports the error
+		body.Str, _ = sjson.SetRaw(body.Str, path, rawValue)
+		body.err = res.err
+		return body
+	}
+	return res
+}
+
+// SetRawE sets a JSON path to a raw string value and returns an error if the path or value is invalid.
+func (body Body) SetRawE(path, rawValue string) (Body, error) {
+	if !gjson.Valid(rawValue) {
+		return body.fail(fmt.Errorf("failed to set '%s': invalid JSON value", path))
+	}
+	res, err := sjson.SetRaw(body.Str, path, rawValue)
+	if err != nil {
+		return body.fail(fmt.Errorf("failed to set '%s': %w", path, err))
+	}
 	body.Str = res
-	return body
+	return body, nil
 }
 
 // Delete deletes a JSON path.
 func (body Body) Delete(path string) Body {
-	res, _ := sjson.Delete(body.Str, path)
+	res, err := sjson.Delete(body.Str, path)
+	if err != nil {
+		body, _ = body.fail(fmt.Errorf("failed to delete '%s': %w", path, err))
+		return body
+	}
 	body.Str = res
 	return body
 }
 
+// Build returns the JSON body string. It returns the first error recorded while
+// building the body or an error if the resulting document is not valid JSON.
+func (body Body) Build() (string, error) {
+	if body.err != nil {
+		return body.Str, body.err
+	}
+	if body.Str != "" && !gjson.Valid(body.Str) {
+		return body.Str, fmt.Errorf("invalid JSON body: %s", body.Str)
+	}
+	return body.Str, nil
+}
+
+// fail records the first error of a body.
+func (body Body) fail(err error) (Body, error) {
+	if body.err == nil {
+		body.err = err
+	}
+	return body, err
+}
+
 // Res creates a Res object, i.e. a GJSON result object.
 func (body Body) Res() Res {
 	return Res{Result: gjson.Parse(body.Str)}
@@ -50,9 +109,104 @@ type Req struct {
 	HttpReq *http.Request
 	// LogPayload indicates whether logging of payloads should be enabled.
 	LogPayload bool
+	// OnProgress is called after every page fetched by paginated requests.
+	OnProgress func(Progress)
+	// NoCache indicates that responses must not be served from a cache.
+	NoCache bool
+	// Compress indicates that the request body is compressed with gzip.
+	Compress bool
+	// Timeout overrides the HTTP request timeout of the client if greater than 0.
+	Timeout time.Duration
+	// Prefetch is the number of pages of paginated requests fetched ahead, 0 if disabled.
+	Prefetch int
+	// writeLocked indicates that the caller already holds the client write lock.
+	writeLocked bool
 }
 
 // NoLogPayload prevents logging of payloads.
 func NoLogPayload(req *Req) {
 	req.LogPayload = false
 }
+
+// PayloadLogging enables or disables logging of payloads, overriding the client default.
+func PayloadLogging(x bool) func(*Req) {
+	return func(req *Req) {
+		req.LogPayload = x
+	}
+}
+
+// OnProgress registers a callback reporting the progress of paginated requests, e.g.
+//
+//	client.Get("/organizations/123/devices", OnProgress(func(p Progress) {
+//		log.Printf("%d items, %d pages", p.Items, p.Pages)
+//	}))
+func OnProgress(fn func(Progress)) func(*Req) {
+	return func(req *Req) {
+		req.OnProgress = fn
+	}
+}
+
+// Context sets the context of a request, which cancels the request, including rate limiter
+// waits and retries, when it is done, e.g.
+//
+//	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+//	defer cancel()
+//	org.Get("/devices", Context(ctx))
+func Context(ctx context.Context) func(*Req) {
+	return func(req *Req) {
+		req.HttpReq = req.HttpReq.WithContext(ctx)
+	}
+}
+
+// Timeout overrides the HTTP request timeout of the client for a single request, e.g. to give
+// long running configuration changes more time or to let health checks fail fast:
+//
+//	client.Get("/organizations", Timeout(5*time.Second))
+//
+// Like the client timeout, it applies to every attempt including reading the response body.
+func Timeout(d time.Duration) func(*Req) {
+	return func(req *Req) {
+		req.Timeout = d
+	}
+}
+
+// CompressBody compresses the request body with gzip and sends it with a Content-Encoding: gzip
+// header, e.g. for large action batches, see CompressRequests.
+func CompressBody(req *Req) {
+	req.Compress = true
+}
+
+// BodyCompression enables or disables gzip compression of the request body, overriding the client default.
+func BodyCompression(x bool) func(*Req) {
+	return func(req *Req) {
+		req.Compress = x
+	}
+}
+
+// NoCache prevents serving the response from a cache.
+func NoCache(req *Req) {
+	req.NoCache = true
+}
+
+// Header sets a HTTP request header, replacing any existing value.
+func Header(key, value string) func(*Req) {
+	return func(req *Req) {
+		req.HttpReq.Header.Set(key, value)
+	}
+}
+
+// Query sets a query parameter, replacing any existing value, e.g.
+//
+//	client.Get("/organizations/123/devices", Query("perPage", "1000"))
+func Query(key, value string) func(*Req) {
+	return func(req *Req) {
+		q := req.HttpReq.URL.Query()
+		q.Set(key, value)
+		req.HttpReq.URL.RawQuery = q.Encode()
+	}
+}
+
+// writeLocked marks a request as issued while holding the client write lock.
+func writeLocked(req *Req) {
+	req.writeLocked = true
+}
@@ -20,3 +20,38 @@ func TestDelete(t *testing.T) {
 	body = body.Delete("a.name")
 	assert.Equal(t, "", body.Res().Get("a.name").Str)
 }
+
+// TestQuery tests the Query modifier.
+func TestQuery(t *testing.T) {
+	client, _ := NewClient("abc123")
+	req := client.NewReq("GET", "/url?a=1", nil, Query("b", "2"), Query("a", "3"))
+	assert.Equal(t, "a=3&b=2", req.HttpReq.URL.RawQuery)
+}
+
+// TestBuild tests the Body::SetE and Body::Build methods.
+func TestBuild(t *testing.T) {
+	body, err := Body{}.SetE("a.b", 1)
+	assert.NoError(t, err)
+	str, err := body.Build()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":{"b":1}}`, str)
+
+	// invalid path
+	_, err = Body{}.SetE("", 1)
+	assert.Error(t, err)
+
+	// errors are recorded by Set and returned by Build
+	str, err = Body{}.Set("", 1).Set("a", 1).Build()
+	assert.Error(t, err)
+	assert.Equal(t, `{"a":1}`, str)
+
+	// invalid raw value
+	_, err = Body{}.SetRawE("a", `{"name":`)
+	assert.Error(t, err)
+	_, err = Body{}.SetRaw("a", `{"name":`).Build()
+	assert.Error(t, err)
+
+	// invalid document
+	_, err = Body{Str: `{"a":`}.Build()
+	assert.Error(t, err)
+}
@@ -1,9 +1,12 @@
 package meraki
 
 import (
+	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
 )
 
 // Res is an API response returned by client requests.
@@ -12,4 +15,69 @@ import (
 type Res struct {
 	gjson.Result
 	Header http.Header
+	// Warnings are the values of the Warning response headers, e.g. soft deprecations or partial results
+	Warnings []string
+	// useNumber decodes numbers as json.Number in Unmarshal, see PreserveNumbers
+	useNumber bool
+}
+
+// Set returns a copy of the result with a JSON path set to a value.
+// This is primarily used for GET-modify-PUT flows, e.g.:
+//
+//	res, _ := client.Get("/networks/N_123")
+//	client.Put("/networks/N_123", res.Set("name", "New").BodyForPut())
+func (res Res) Set(path string, value interface{}) Res {
+	raw, _ := sjson.Set(res.Raw, path, value)
+	res.Result = gjson.Parse(raw)
+	return res
+}
+
+// SetRaw returns a copy of the result with a JSON path set to a raw string value.
+func (res Res) SetRaw(path, rawValue string) Res {
+	raw, _ := sjson.SetRaw(res.Raw, path, rawValue)
+	res.Result = gjson.Parse(raw)
+	return res
+}
+
+// Delete returns a copy of the result with a JSON path deleted.
+func (res Res) Delete(path string) Res {
+	raw, _ := sjson.Delete(res.Raw, path)
+	res.Result = gjson.Parse(raw)
+	return res
+}
+
+// BodyForPut returns the result as JSON body string for PUT and POST requests.
+func (res Res) BodyForPut() string {
+	return res.Raw
+}
+
+// Body returns the result as Body.
+func (res Res) Body() Body {
+	return Body{Str: res.Raw}
+}
+
+// Unmarshal parses the result into the value pointed to by v, see json.Unmarshal.
+// Pass modifiers in to modify the decoder, e.g.
+//
+//	var v map[string]interface{}
+//	err := res.Unmarshal(&v, UseNumber)
+func (res Res) Unmarshal(v interface{}, mods ...func(*json.Decoder)) error {
+	if !res.Exists() {
+		return nil
+	}
+	decoder := json.NewDecoder(strings.NewReader(res.Raw))
+	if res.useNumber {
+		decoder.UseNumber()
+	}
+	for _, mod := range mods {
+		mod(decoder)
+	}
+	return decoder.Decode(v)
+}
+
+// UseNumber decodes numbers into interface{} values as json.Number instead of float64,
+// which preserves large IDs and counters exactly. A json.Number can be passed to Body.Set
+// and Res.Set as is.
+func UseNumber(decoder *json.Decoder) {
+	decoder.UseNumber()
 }
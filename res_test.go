@@ -0,0 +1,73 @@
+package meraki
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestResSet tests the Res::Set, Res::SetRaw and Res::Delete methods.
+func TestResSet(t *testing.T) {
+	res := Body{}.Set("name", "a").Set("id", "1").Res()
+
+	modified := res.Set("name", "b").SetRaw("vlan", `{"id":10}`).Delete("id")
+	assert.Equal(t, `{"name":"b","vlan":{"id":10}}`, modified.BodyForPut())
+	assert.Equal(t, "a", res.Get("name").Str)
+	assert.Equal(t, int64(10), modified.Body().Res().Get("vlan.id").Int())
+}
+
+// TestResUnmarshal tests the Res::Unmarshal method.
+func TestResUnmarshal(t *testing.T) {
+	var v struct {
+		Name string `json:"name"`
+	}
+	assert.NoError(t, Body{}.Set("name", "a").Res().Unmarshal(&v))
+	assert.Equal(t, "a", v.Name)
+	assert.Error(t, Body{Str: `{"name":1}`}.Res().Unmarshal(&v))
+}
+
+// TestResUnmarshalNumber tests the Res::Unmarshal method with number preservation.
+func TestResUnmarshalNumber(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+	PreserveNumbers()(&client)
+
+	res := Body{Str: `{"id":12345678901234567891}`}.Res()
+	m := make(map[string]interface{})
+	assert.NoError(t, res.Unmarshal(&m))
+	assert.IsType(t, float64(0), m["id"])
+	assert.NoError(t, res.Unmarshal(&m, UseNumber))
+	assert.Equal(t, json.Number("12345678901234567891"), m["id"])
+	assert.Equal(t, `{"id":12345678901234567891,"x":1}`, Body{}.Set("id", m["id"]).Set("x", 1).Str)
+
+	gock.New(client.BaseUrl).Get("/url").Reply(200).BodyString(`{"id":12345678901234567891}`)
+	res, err := client.Get("/url")
+	assert.NoError(t, err)
+	m = make(map[string]interface{})
+	assert.NoError(t, res.Unmarshal(&m))
+	assert.Equal(t, json.Number("12345678901234567891"), m["id"])
+}
+
+// TestResWarnings tests the Res::Warnings attribute.
+func TestResWarnings(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Get("/url").
+		Reply(200).
+		BodyString(`["1"]`).
+		AddHeader("Warning", `299 - "Deprecated"`).
+		AddHeader("Link", `<`+client.BaseUrl+`/url?offset=2>; rel="next"`)
+	gock.New(client.BaseUrl).Get("/url").MatchParam("offset", "2").
+		Reply(200).
+		BodyString(`["2"]`).
+		AddHeader("Warning", `299 - "Partial result"`).
+		AddHeader("Link", `<`+client.BaseUrl+`/url>; rel="first"`)
+
+	res, err := client.Get("/url")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`299 - "Deprecated"`, `299 - "Partial result"`}, res.Warnings)
+	assert.Equal(t, res.Warnings, res.Set("a", 1).Warnings)
+}
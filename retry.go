@@ -0,0 +1,333 @@
+package meraki
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultRetryAfter is the delay before retrying a rate limited request without a valid
+// Retry-After header. A random jitter of up to 25% is added or subtracted.
+const DefaultRetryAfter time.Duration = 15 * time.Second
+
+// RetryCause is the cause of a retry.
+type RetryCause string
+
+const (
+	// RetryRateLimited is a retry of a rate limited (429) request
+	RetryRateLimited RetryCause = "rate_limited"
+	// RetryServerError is a retry of a request failed with a 5xx status code
+	RetryServerError RetryCause = "server_error"
+	// RetryNetwork is a retry of a request failed with a connection error or while reading the response
+	RetryNetwork RetryCause = "network"
+	// RetryTransient is a retry of a request failed with a transient 4xx error message or a 4xx status code of RetryOnStatus
+	RetryTransient RetryCause = "transient"
+)
+
+// RetryStats is the number of retries of a request by cause.
+type RetryStats struct {
+	// RateLimited is the number of retries of rate limited (429) responses
+	RateLimited int
+	// ServerError is the number of retries of 5xx responses
+	ServerError int
+	// Network is the number of retries of connection errors and failures reading the response
+	Network int
+	// Transient is the number of retries of 4xx responses with transient error messages
+	Transient int
+}
+
+// Total returns the total number of retries.
+func (r RetryStats) Total() int {
+	return r.RateLimited + r.ServerError + r.Network + r.Transient
+}
+
+// String returns a human readable breakdown of the retries.
+func (r RetryStats) String() string {
+	return fmt.Sprintf("rate limited: %d, server error: %d, network: %d, transient: %d", r.RateLimited, r.ServerError, r.Network, r.Transient)
+}
+
+// OnRetriesExhausted registers a callback called when a retryable request failed after all retries,
+// e.g. to distinguish quota exhaustion from Dashboard instability:
+//
+//	client, _ := NewClient("abc123", OnRetriesExhausted(func(req Req, retries RetryStats, err error) {
+//		log.Printf("%s failed: %s (%s)", req.HttpReq.URL, err, retries)
+//	}))
+func OnRetriesExhausted(fn func(req Req, retries RetryStats, err error)) func(*Client) {
+	return func(client *Client) {
+		client.OnRetriesExhausted = fn
+	}
+}
+
+// OnRetry registers a callback called before waiting to retry a failed request with the number
+// of the upcoming attempt, starting at 1 for the first retry, the delay and the error of the
+// failed attempt, e.g. to tell users why an operation is waiting:
+//
+//	client, _ := NewClient("abc123", OnRetry(func(attempt int, delay time.Duration, reason error) {
+//		fmt.Printf("waiting %v before retry %d: %s\n", delay.Round(time.Second), attempt, reason)
+//	}))
+//
+// The reason is an *ApiError for failed responses, e.g. with status code 429 if rate limited.
+func OnRetry(fn func(attempt int, delay time.Duration, reason error)) func(*Client) {
+	return func(client *Client) {
+		client.OnRetry = fn
+	}
+}
+
+// countRetry counts a retry of a request for a cause.
+func (client *Client) countRetry(req Req, retries *RetryStats, cause RetryCause) {
+	client.stats.retries.Add(1)
+	client.observeRetry(req, cause)
+	switch cause {
+	case RetryRateLimited:
+		retries.RateLimited++
+		client.stats.rateLimitRetries.Add(1)
+	case RetryServerError:
+		retries.ServerError++
+		client.stats.serverErrorRetries.Add(1)
+	case RetryNetwork:
+		retries.Network++
+		client.stats.networkRetries.Add(1)
+	case RetryTransient:
+		retries.Transient++
+		client.stats.transientRetries.Add(1)
+	}
+}
+
+// RetryOnStatus modifies the status codes of retried responses besides 429, which is always
+// retried. Default is to retry all 5xx status codes, e.g. to retry only 502 and 504 as well
+// as 409 conflicts:
+//
+//	client, _ := NewClient("abc123", RetryOnStatus(409, 502, 504))
+//
+// 5xx status codes are retried as server errors, other status codes as transient errors,
+// see RetryWrites. Pass no codes to disable retries of 5xx status codes.
+func RetryOnStatus(codes ...int) func(*Client) {
+	return func(client *Client) {
+		client.RetryStatusCodes = append([]int{}, codes...)
+	}
+}
+
+// retryStatus reports whether a status code is retried, see RetryOnStatus.
+func (client *Client) retryStatus(statusCode int) bool {
+	if client.RetryStatusCodes == nil {
+		return statusCode >= 500 && statusCode <= 599
+	}
+	for _, code := range client.RetryStatusCodes {
+		if code == statusCode {
+			return true
+		}
+	}
+	return false
+}
+
+// retryCause returns the retry cause of a failed response, or an empty string if it is not retryable.
+func (client *Client) retryCause(statusCode int, res Res) RetryCause {
+	switch {
+	case statusCode == 429:
+		return RetryRateLimited
+	case client.retryStatus(statusCode):
+		if statusCode >= 500 {
+			return RetryServerError
+		}
+		return RetryTransient
+	case client.isTransientError(res):
+		return RetryTransient
+	}
+	return ""
+}
+
+// retriesExhausted calls the OnRetriesExhausted callback if a request failed for a cause which
+// allowed retries, i.e. not for writes which are not retried, see RetryWrites.
+func (client *Client) retriesExhausted(req Req, cause RetryCause, retries RetryStats, err error) {
+	if cause == "" || (client.RetryPolicy == nil && !client.canRetry(req, cause)) {
+		return
+	}
+	client.logf("[ERROR] HTTP Request retries exhausted: %s", retries)
+	if client.OnRetriesExhausted != nil {
+		client.runHook("OnRetriesExhausted", func() { client.OnRetriesExhausted(req, retries, err) })
+	}
+}
+
+// DefaultIdempotentPaths are the path patterns of effectively idempotent POST requests,
+// which are retried even if RetryWrites is disabled.
+var DefaultIdempotentPaths = []string{
+	"/organizations/*/claim",
+	"/organizations/*/inventory/claim",
+	"/networks/*/devices/claim",
+	"/networks/*/devices/remove",
+	"/networks/*/sm/devices/checkin",
+	"/devices/*/blinkLeds",
+}
+
+// RetryWrites enables or disables retries of POST requests failed with a 5xx status code or a
+// connection error, where the request might have been applied already. Default value is true.
+// Rate limited requests and transient errors are always retried, as well as GET, PUT and DELETE
+// requests, which are idempotent, and POST requests to IdempotentPaths.
+func RetryWrites(x bool) func(*Client) {
+	return func(client *Client) {
+		client.RetryWrites = x
+	}
+}
+
+// IdempotentPaths modifies the path patterns of POST requests retried even if RetryWrites is disabled,
+// where * matches a single path segment. Default value is DefaultIdempotentPaths.
+func IdempotentPaths(patterns ...string) func(*Client) {
+	return func(client *Client) {
+		client.IdempotentPaths = patterns
+	}
+}
+
+// canRetry reports whether a failed request may be retried for a cause, see RetryWrites.
+func (client *Client) canRetry(req Req, cause RetryCause) bool {
+	if client.RetryWrites || req.HttpReq.Method != "POST" || cause == RetryRateLimited || cause == RetryTransient {
+		return true
+	}
+	return matchPathPatterns(client.IdempotentPaths, client.relPath(req.HttpReq.URL))
+}
+
+// RetryPolicy decides whether and when a failed request attempt is retried. ShouldRetry is
+// called with the number of previous retries of the request and either the response of a
+// request failed with a status code outside of 2xx, whose body can be read, or the error of a
+// request failed with a connection error or while reading the response body. It returns
+// whether the request is retried and the delay before the retry. Token refreshes and key
+// pool failovers are not subject to the retry policy.
+type RetryPolicy interface {
+	ShouldRetry(attempt int, res *http.Response, err error) (bool, time.Duration)
+}
+
+// RetryPolicyFunc is a function implementing the RetryPolicy interface.
+type RetryPolicyFunc func(attempt int, res *http.Response, err error) (bool, time.Duration)
+
+// ShouldRetry implements the RetryPolicy interface.
+func (fn RetryPolicyFunc) ShouldRetry(attempt int, res *http.Response, err error) (bool, time.Duration) {
+	return fn(attempt, res, err)
+}
+
+// UseRetryPolicy replaces the default retry behavior, i.e. the exponential backoff of
+// MaxRetries, BackoffMinDelay, BackoffMaxDelay and BackoffDelayFactor, RetryWrites,
+// TransientErrors and waiting for Retry-After headers, e.g.
+//
+//	client, _ := NewClient("abc123", UseRetryPolicy(RetryPolicyFunc(func(attempt int, res *http.Response, err error) (bool, time.Duration) {
+//		return attempt < 10 && (err != nil || res.StatusCode == 429), time.Second
+//	})))
+func UseRetryPolicy(policy RetryPolicy) func(*Client) {
+	return func(client *Client) {
+		client.RetryPolicy = policy
+	}
+}
+
+// UnlimitedRetries is the maximum number of retries retrying until the request succeeds, see MaxRateLimitRetries.
+const UnlimitedRetries int = -1
+
+// MaxRateLimitRetries modifies the maximum number of retries of rate limited (429) requests from
+// the default of 3. Rate limited requests are expected during bulk operations, therefore they do
+// not count against MaxRetries, which limits the retries of other failures. Use UnlimitedRetries
+// to retry rate limited requests persistently, possibly bounded by MaxElapsedTime, e.g.
+//
+//	client, _ := NewClient("abc123", MaxRateLimitRetries(UnlimitedRetries), MaxElapsedTime(time.Hour))
+func MaxRateLimitRetries(x int) func(*Client) {
+	return func(client *Client) {
+		client.MaxRateLimitRetries = x
+	}
+}
+
+// MaxElapsedTime bounds the total time of a request including all retries and backoff delays,
+// independent of MaxRetries. A retry is not started if its delay, e.g. a long Retry-After
+// header of a 429 response, would end after the deadline, the last error is returned instead.
+// Default value is 0, which disables the deadline.
+func MaxElapsedTime(x time.Duration) func(*Client) {
+	return func(client *Client) {
+		client.MaxElapsedTime = x
+	}
+}
+
+// retryDeadline returns the deadline of a request starting now, or the zero time if MaxElapsedTime is disabled.
+func (client *Client) retryDeadline() time.Time {
+	if client.MaxElapsedTime <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(client.MaxElapsedTime)
+}
+
+// exceedsDeadline reports whether waiting for a delay ends after a deadline.
+func exceedsDeadline(deadline time.Time, delay time.Duration) bool {
+	return !deadline.IsZero() && time.Now().Add(delay).After(deadline)
+}
+
+// retry reports whether a failed request attempt is retried for a cause after waiting
+// according to the retry policy, the Retry-After header of the response or the exponential
+// backoff, see MaxElapsedTime. Retried is the number of previous retries of the request, which
+// excludes attempts repeated after a token refresh or key failover. The reason is the error of
+// the failed attempt reported to OnRetry.
+func (client *Client) retry(ctx context.Context, req Req, retried int, retries RetryStats, deadline time.Time, res *http.Response, err error, cause RetryCause, reason error) bool {
+	var ok bool
+	var delay time.Duration
+	if client.RetryPolicy == nil {
+		if !client.canRetry(req, cause) {
+			return false
+		}
+		if cause == RetryRateLimited {
+			delay, ok = client.backoffDelay(retries.RateLimited, client.MaxRateLimitRetries)
+			if res != nil {
+				delay += client.parseRetryAfter(res.Header.Get("Retry-After"))
+			}
+		} else {
+			delay, ok = client.backoffDelay(retried-retries.RateLimited, client.MaxRetries)
+			// Wait as indicated by the server instead of the exponential backoff, e.g. for 503 responses
+			if res != nil && res.Header.Get("Retry-After") != "" {
+				delay = client.parseRetryAfter(res.Header.Get("Retry-After"))
+			}
+		}
+	} else {
+		ok, delay = client.RetryPolicy.ShouldRetry(retried, res, err)
+	}
+	if !ok {
+		return false
+	}
+	if exceedsDeadline(deadline, delay) {
+		client.logf("[ERROR] HTTP Request retry in %v exceeds MaxElapsedTime", delay.Round(time.Millisecond))
+		return false
+	}
+	if client.OnRetry != nil {
+		client.runHook("OnRetry", func() { client.OnRetry(retried+1, delay, reason) })
+	}
+	client.logf("[TRACE] Starting sleeping for %v", delay)
+	return sleep(ctx, delay) == nil
+}
+
+// parseRetryAfter returns the delay of a Retry-After header, which is either a number of seconds,
+// possibly fractional, or an HTTP-date. Delays of 0 or dates in the past wait one second,
+// missing or malformed headers wait DefaultRetryAfter with jitter.
+func (client *Client) parseRetryAfter(header string) time.Duration {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return jitter(DefaultRetryAfter)
+	}
+	var delay time.Duration
+	if seconds, err := strconv.ParseFloat(header, 64); err == nil {
+		// Reject negative values, NaN and values overflowing time.Duration
+		if !(seconds >= 0 && seconds <= (24*time.Hour).Seconds()) {
+			client.logf("[WARNING] Invalid Retry-After header: %s", header)
+			return jitter(DefaultRetryAfter)
+		}
+		delay = time.Duration(seconds * float64(time.Second))
+	} else if date, err := http.ParseTime(header); err == nil {
+		delay = time.Until(date)
+	} else {
+		client.logf("[WARNING] Invalid Retry-After header: %s", header)
+		return jitter(DefaultRetryAfter)
+	}
+	if delay <= 0 {
+		return time.Second
+	}
+	return delay
+}
+
+// jitter randomly adds or subtracts up to 25% of a delay.
+func jitter(delay time.Duration) time.Duration {
+	return time.Duration(float64(delay) * (0.75 + rand.Float64()/2))
+}
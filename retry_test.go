@@ -0,0 +1,293 @@
+package meraki
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestOnRetriesExhausted tests the OnRetriesExhausted modifier.
+func TestOnRetriesExhausted(t *testing.T) {
+	defer gock.Off()
+	var exhausted []RetryStats
+	client, _ := NewClient("abc123", MaxRetries(2), BackoffMinDelay(0), BackoffMaxDelay(0),
+		OnRetriesExhausted(func(req Req, retries RetryStats, err error) {
+			assert.Error(t, err)
+			exhausted = append(exhausted, retries)
+		}))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(client.BaseUrl).Get("/url").ReplyError(errors.New("fail"))
+	gock.New(client.BaseUrl).Get("/url").Reply(503)
+	gock.New(client.BaseUrl).Get("/url").Reply(502)
+	_, err := client.Get("/url")
+	assert.Error(t, err)
+	assert.Equal(t, []RetryStats{{Network: 1, ServerError: 1}}, exhausted)
+	assert.Equal(t, 2, exhausted[0].Total())
+
+	stats := client.Stats()
+	assert.Equal(t, int64(1), stats.NetworkRetries)
+	assert.Equal(t, int64(1), stats.ServerErrorRetries)
+	assert.Equal(t, int64(2), stats.Retries)
+
+	// Non-retryable errors do not call the hook
+	gock.New(client.BaseUrl).Get("/url").Reply(400)
+	_, err = client.Get("/url")
+	assert.Error(t, err)
+	assert.Len(t, exhausted, 1)
+
+	// Writes which are not retried do not call the hook
+	client.RetryWrites = false
+	gock.New(client.BaseUrl).Post("/url").Reply(503)
+	_, err = client.Post("/url", `{}`)
+	assert.Error(t, err)
+	assert.Len(t, exhausted, 1)
+	assert.True(t, gock.IsDone())
+}
+
+// TestRetryWrites tests the RetryWrites and IdempotentPaths modifiers.
+func TestRetryWrites(t *testing.T) {
+	defer gock.Off()
+	client, _ := NewClient("abc123", MaxRetries(1), BackoffMinDelay(0), BackoffMaxDelay(0), RetryWrites(false))
+	gock.InterceptClient(client.HttpClient)
+
+	// POST is not retried
+	gock.New(client.BaseUrl).Post("/networks/N_1/vlans").Reply(500)
+	gock.New(client.BaseUrl).Post("/networks/N_1/vlans").Reply(201)
+	_, err := client.Post("/networks/N_1/vlans", `{}`)
+	assert.Error(t, err)
+	gock.Off()
+
+	// Rate limited POST, PUT and idempotent POST are retried
+	gock.New(client.BaseUrl).Post("/networks/N_1/vlans").Reply(429).SetHeader("Retry-After", "0")
+	gock.New(client.BaseUrl).Post("/networks/N_1/vlans").Reply(201)
+	_, err = client.Post("/networks/N_1/vlans", `{}`)
+	assert.NoError(t, err)
+	gock.New(client.BaseUrl).Put("/networks/N_1").Reply(500)
+	gock.New(client.BaseUrl).Put("/networks/N_1").Reply(200)
+	_, err = client.Put("/networks/N_1", `{}`)
+	assert.NoError(t, err)
+	gock.New(client.BaseUrl).Post("/networks/N_1/devices/claim").Reply(502)
+	gock.New(client.BaseUrl).Post("/networks/N_1/devices/claim").Reply(200)
+	_, err = client.Post("/networks/N_1/devices/claim", `{"serials":["Q-1"]}`)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+// TestUseRetryPolicy tests the UseRetryPolicy modifier.
+func TestUseRetryPolicy(t *testing.T) {
+	defer gock.Off()
+	type call struct {
+		attempt int
+		status  int
+		body    string
+		err     bool
+	}
+	calls := make([]call, 0)
+	client, _ := NewClient("abc123", MaxRetries(0), UseRetryPolicy(RetryPolicyFunc(func(attempt int, res *http.Response, err error) (bool, time.Duration) {
+		c := call{attempt: attempt, err: err != nil}
+		if res != nil {
+			body, _ := io.ReadAll(res.Body)
+			c.status, c.body = res.StatusCode, string(body)
+		}
+		calls = append(calls, c)
+		return attempt < 3, time.Millisecond
+	})))
+	gock.InterceptClient(client.HttpClient)
+
+	// Retries 400 responses, ignoring MaxRetries
+	gock.New(client.BaseUrl).Get("/url").ReplyError(errors.New("fail"))
+	gock.New(client.BaseUrl).Get("/url").Reply(400).JSON(`{"errors":["invalid"]}`)
+	gock.New(client.BaseUrl).Get("/url").Reply(429)
+	gock.New(client.BaseUrl).Get("/url").Reply(200)
+	_, err := client.Get("/url")
+	assert.NoError(t, err)
+	assert.Equal(t, []call{{0, 0, "", true}, {1, 400, `{"errors":["invalid"]}`, false}, {2, 429, "", false}}, calls)
+	assert.Equal(t, int64(1), client.Stats().RateLimitRetries)
+
+	// Gives up
+	calls = calls[:0]
+	gock.New(client.BaseUrl).Get("/url").Times(4).Reply(503)
+	_, err = client.Get("/url")
+	assert.Error(t, err)
+	assert.Len(t, calls, 4)
+	assert.True(t, gock.IsDone())
+}
+
+// TestRetryOnStatus tests the RetryOnStatus modifier.
+func TestRetryOnStatus(t *testing.T) {
+	defer gock.Off()
+	client, _ := NewClient("abc123", MaxRetries(1), BackoffMinDelay(0), BackoffMaxDelay(0), RetryOnStatus(409, 502))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(client.BaseUrl).Get("/url").Reply(409)
+	gock.New(client.BaseUrl).Get("/url").Reply(200)
+	_, err := client.Get("/url")
+	assert.NoError(t, err)
+
+	gock.New(client.BaseUrl).Get("/url").Reply(502)
+	gock.New(client.BaseUrl).Get("/url").Reply(200)
+	_, err = client.Get("/url")
+	assert.NoError(t, err)
+
+	gock.New(client.BaseUrl).Get("/url").Reply(500)
+	_, err = client.Get("/url")
+	assert.Error(t, err)
+	assert.True(t, gock.IsDone())
+
+	stats := client.Stats()
+	assert.Equal(t, int64(1), stats.TransientRetries)
+	assert.Equal(t, int64(1), stats.ServerErrorRetries)
+
+	// No status codes
+	client, _ = NewClient("abc123", MaxRetries(1), BackoffMinDelay(0), BackoffMaxDelay(0), RetryOnStatus())
+	gock.InterceptClient(client.HttpClient)
+	gock.New(client.BaseUrl).Get("/url").Reply(503)
+	_, err = client.Get("/url")
+	assert.Error(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+// TestMaxElapsedTime tests the MaxElapsedTime modifier.
+func TestMaxElapsedTime(t *testing.T) {
+	defer gock.Off()
+	client, _ := NewClient("abc123", MaxRetries(5), BackoffMinDelay(0), BackoffMaxDelay(0), MaxElapsedTime(time.Second))
+	gock.InterceptClient(client.HttpClient)
+
+	// Retry-After exceeds the deadline
+	start := time.Now()
+	gock.New(client.BaseUrl).Get("/url").Reply(429).SetHeader("Retry-After", "60")
+	_, err := client.Get("/url")
+	assert.ErrorContains(t, err, "StatusCode 429")
+	assert.Less(t, time.Since(start), time.Second)
+
+	// Backoff delay exceeds the deadline
+	client.BackoffMinDelay, client.BackoffMaxDelay = 2, 2
+	gock.New(client.BaseUrl).Get("/url").Reply(503)
+	_, err = client.Get("/url")
+	assert.ErrorContains(t, err, "StatusCode 503")
+	assert.Less(t, time.Since(start), time.Second)
+
+	// Retries within the deadline
+	client.BackoffMinDelay, client.BackoffMaxDelay = 0, 0
+	gock.New(client.BaseUrl).Get("/url").Reply(503)
+	gock.New(client.BaseUrl).Get("/url").Reply(200)
+	_, err = client.Get("/url")
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+// TestMaxRateLimitRetries tests the MaxRateLimitRetries modifier.
+func TestMaxRateLimitRetries(t *testing.T) {
+	defer gock.Off()
+	client, _ := NewClient("abc123", MaxRetries(1), MaxRateLimitRetries(UnlimitedRetries), BackoffMinDelay(0), BackoffMaxDelay(0))
+	gock.InterceptClient(client.HttpClient)
+
+	// Rate limited retries do not count against MaxRetries
+	gock.New(client.BaseUrl).Get("/url").Times(5).Reply(429).SetHeader("Retry-After", "0.01")
+	gock.New(client.BaseUrl).Get("/url").Reply(503)
+	gock.New(client.BaseUrl).Get("/url").Reply(429).SetHeader("Retry-After", "0.01")
+	gock.New(client.BaseUrl).Get("/url").Reply(200)
+	_, err := client.Get("/url")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), client.Stats().RateLimitRetries)
+
+	// Other failures are still limited by MaxRetries
+	gock.New(client.BaseUrl).Get("/url").Reply(503)
+	gock.New(client.BaseUrl).Get("/url").Reply(429).SetHeader("Retry-After", "0.01")
+	gock.New(client.BaseUrl).Get("/url").Reply(503)
+	_, err = client.Get("/url")
+	assert.ErrorContains(t, err, "StatusCode 503")
+	assert.True(t, gock.IsDone())
+
+	// Limited rate limited retries
+	client.MaxRateLimitRetries = 1
+	gock.New(client.BaseUrl).Get("/url").Times(2).Reply(429).SetHeader("Retry-After", "0.01")
+	_, err = client.Get("/url")
+	assert.ErrorContains(t, err, "StatusCode 429")
+	assert.True(t, gock.IsDone())
+}
+
+// TestParseRetryAfter tests the Client::parseRetryAfter method.
+func TestParseRetryAfter(t *testing.T) {
+	client := testClient()
+	assert.Equal(t, 5*time.Second, client.parseRetryAfter("5"))
+	assert.Equal(t, 1500*time.Millisecond, client.parseRetryAfter("1.5"))
+	assert.Equal(t, time.Second, client.parseRetryAfter("0"))
+	assert.Equal(t, time.Second, client.parseRetryAfter(time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat)))
+	delay := client.parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	assert.InDelta(t, float64(time.Minute), float64(delay), float64(2*time.Second))
+	for _, header := range []string{"", "soon", "-5", "NaN", "1e12"} {
+		delay := client.parseRetryAfter(header)
+		assert.GreaterOrEqual(t, delay, DefaultRetryAfter*3/4, header)
+		assert.LessOrEqual(t, delay, DefaultRetryAfter*5/4, header)
+	}
+}
+
+// TestRetryAfterServerError tests waiting for the Retry-After header of 5xx responses.
+func TestRetryAfterServerError(t *testing.T) {
+	defer gock.Off()
+	client, _ := NewClient("abc123", MaxRetries(1), BackoffMinDelay(30), BackoffMaxDelay(30))
+	gock.InterceptClient(client.HttpClient)
+
+	start := time.Now()
+	gock.New(client.BaseUrl).Get("/url").Reply(503).SetHeader("Retry-After", "0.05")
+	gock.New(client.BaseUrl).Get("/url").Reply(200)
+	_, err := client.Get("/url")
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	assert.Less(t, time.Since(start), time.Second)
+	assert.True(t, gock.IsDone())
+}
+
+// TestOnRetry tests the OnRetry modifier.
+func TestOnRetry(t *testing.T) {
+	defer gock.Off()
+	attempts := make([]int, 0)
+	delays := make([]time.Duration, 0)
+	reasons := make([]error, 0)
+	client, _ := NewClient("abc123", MaxRetries(2), BackoffMinDelay(0), BackoffMaxDelay(0), OnRetry(func(attempt int, delay time.Duration, reason error) {
+		attempts = append(attempts, attempt)
+		delays = append(delays, delay)
+		reasons = append(reasons, reason)
+	}))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(client.BaseUrl).Get("/url").ReplyError(errors.New("fail"))
+	gock.New(client.BaseUrl).Get("/url").Reply(429).SetHeader("Retry-After", "0.05")
+	gock.New(client.BaseUrl).Get("/url").Reply(200)
+	_, err := client.Get("/url")
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+
+	assert.Equal(t, []int{1, 2}, attempts)
+	assert.Equal(t, 50*time.Millisecond, delays[1])
+	assert.ErrorContains(t, reasons[0], "fail")
+	apiErr := &ApiError{}
+	assert.ErrorAs(t, reasons[1], &apiErr)
+	assert.Equal(t, 429, apiErr.StatusCode)
+}
+
+// TestOnRetryNotRetryable tests that OnRetry is not called for responses which are not retried.
+func TestOnRetryNotRetryable(t *testing.T) {
+	defer gock.Off()
+	retries := 0
+	client, _ := NewClient("abc123", MaxRetries(2), AdaptiveBackoff(0), OnRetry(func(attempt int, delay time.Duration, reason error) {
+		retries++
+	}))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(client.BaseUrl).Get("/url").Reply(404).JSON(map[string]interface{}{"errors": []string{"Not found"}})
+	start := time.Now()
+	_, err := client.Get("/url")
+	assert.ErrorContains(t, err, "StatusCode 404")
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, 0, retries)
+	assert.Equal(t, 1.0, client.Stats().BackoffScale)
+	assert.Less(t, time.Since(start), time.Second)
+}
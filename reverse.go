@@ -0,0 +1,77 @@
+package meraki
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// GetReverse makes a GET request and walks the collection from newest to oldest by following
+// the 'prev' links (endingBefore), until stop returns true for an item, e.g. to fetch what
+// happened since the last run:
+//
+//	res, err := client.GetReverse("/organizations/123/configurationChanges",
+//		StopBefore("ts", lastRun), Query("perPage", "1000"))
+//
+// Items for which stop returns true are dropped and no further pages are fetched after the
+// current page. Therefore stop must return true for all items beyond the boundary, which is
+// the case for StopBefore and StopAtID. Without a boundary, stop may be nil. Pages are
+// returned in the order fetched, the order of items within a page is kept.
+func (client *Client) GetReverse(path string, stop func(item gjson.Result) bool, mods ...func(*Req)) (Res, error) {
+	r := "[]"
+	progress := Progress{}
+	for {
+		req := client.NewReq("GET", path, nil, mods...)
+		res, err := client.Do(req)
+		if err != nil {
+			return res, err
+		}
+		progress.update(res)
+		client.progress(req, progress)
+		items := res.Result
+		if res.Get("items").Exists() {
+			items = res.Get("items")
+		}
+		stopped := false
+		for _, item := range items.Array() {
+			if stop != nil && stop(item) {
+				stopped = true
+				continue
+			}
+			r, _ = sjson.SetRaw(r, "-1", item.Raw)
+		}
+		prev, foundPrev, err := client.linkPage(res.Header, "prev")
+		if err != nil {
+			return res, err
+		}
+		if stopped || !foundPrev {
+			return Res{Result: gjson.Parse(r), Header: res.Header, useNumber: client.PreserveNumbers}, nil
+		}
+		path = prev
+	}
+}
+
+// StopBefore returns a stop function for GetReverse, which stops at items with a timestamp
+// at key before t. Items without a valid RFC 3339 timestamp do not stop the walk.
+func StopBefore(key string, t time.Time) func(gjson.Result) bool {
+	return func(item gjson.Result) bool {
+		ts, err := time.Parse(time.RFC3339, item.Get(key).String())
+		return err == nil && ts.Before(t)
+	}
+}
+
+// StopAtID returns a stop function for GetReverse, which stops at the item with the given
+// numeric ID at key and all items with lower IDs, e.g. the last item seen in a previous run.
+func StopAtID(key, id string) func(gjson.Result) bool {
+	boundary, err := strconv.ParseInt(id, 10, 64)
+	return func(item gjson.Result) bool {
+		v := item.Get(key).String()
+		if err != nil {
+			return v == id
+		}
+		n, err := strconv.ParseInt(v, 10, 64)
+		return err == nil && n <= boundary
+	}
+}
@@ -0,0 +1,54 @@
+package meraki
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestClientGetReverse tests the Client::GetReverse method.
+func TestClientGetReverse(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Get("/changes").
+		Reply(200).
+		BodyString(`[{"id":"5","ts":"2024-01-05T00:00:00Z"},{"id":"4","ts":"2024-01-04T00:00:00Z"}]`).
+		AddHeader("Link", `<`+client.BaseUrl+`/changes?endingBefore=4>; rel="prev"`)
+	gock.New(client.BaseUrl).Get("/changes").MatchParam("endingBefore", "4").
+		Reply(200).
+		BodyString(`[{"id":"3","ts":"2024-01-03T00:00:00Z"},{"id":"2","ts":"2024-01-02T00:00:00Z"}]`).
+		AddHeader("Link", `<`+client.BaseUrl+`/changes?endingBefore=2>; rel="prev"`)
+	res, err := client.GetReverse("/changes", StopBefore("ts", time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"5", "4", "3"}, reverseIDs(res))
+	assert.True(t, gock.IsDone())
+
+	gock.New(client.BaseUrl).Get("/changes").
+		Reply(200).
+		BodyString(`[{"id":"5"},{"id":"4"}]`)
+	res, err = client.GetReverse("/changes", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"5", "4"}, reverseIDs(res))
+}
+
+// TestStopAtID tests the StopAtID function.
+func TestStopAtID(t *testing.T) {
+	stop := StopAtID("id", "10")
+	assert.True(t, stop(gjson.Parse(`{"id":"10"}`)))
+	assert.True(t, stop(gjson.Parse(`{"id":9}`)))
+	assert.False(t, stop(gjson.Parse(`{"id":"11"}`)))
+	assert.False(t, stop(gjson.Parse(`{}`)))
+	assert.True(t, StopAtID("id", "abc")(gjson.Parse(`{"id":"abc"}`)))
+}
+
+func reverseIDs(res Res) []string {
+	ids := make([]string, 0)
+	for _, item := range res.Array() {
+		ids = append(ids, item.Get("id").String())
+	}
+	return ids
+}
@@ -0,0 +1,177 @@
+package meraki
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/juju/ratelimit"
+)
+
+const DefaultSchedulerConcurrency int = 5
+const DefaultSchedulerMaxConnections int = 10
+const DefaultSchedulerOrgRequestPerSecond int = 10
+
+// Scheduler runs a workload across many organizations concurrently.
+// Use meraki.NewScheduler to initiate a scheduler.
+//
+// The Meraki rate limit applies per organization, therefore every organization
+// gets its own rate limiter bucket. The total number of concurrent connections
+// across all organizations is capped by MaxConnections.
+type Scheduler struct {
+	// Client is the client used for requests
+	Client *Client
+	// Maximum number of organizations processed concurrently
+	Concurrency int
+	// Maximum number of concurrent connections across all organizations
+	MaxConnections int
+	// Maximum number of requests per second and organization
+	OrgRequestPerSecond int
+	// OnProgress is called whenever the workload of an organization completed
+	OnProgress func(Progress)
+}
+
+// OrgResult is the result of a workload for a single organization.
+type OrgResult struct {
+	// Org is the organization
+	Org Org
+	// Value is the value returned by the workload
+	Value interface{}
+	// Err is the error returned by the workload, wrapped in an *OrgError
+	Err error
+}
+
+// OrgResults is a list of OrgResult in the order of the organizations.
+type OrgResults []OrgResult
+
+// Err returns all errors joined together or nil if all workloads succeeded.
+func (results OrgResults) Err() error {
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NewScheduler creates a new scheduler for a client.
+// Pass modifiers in to modify the behavior of the scheduler, e.g.
+//
+//	scheduler := NewScheduler(&client, Concurrency(10))
+func NewScheduler(client *Client, mods ...func(*Scheduler)) *Scheduler {
+	scheduler := Scheduler{
+		Client:              client,
+		Concurrency:         DefaultSchedulerConcurrency,
+		MaxConnections:      DefaultSchedulerMaxConnections,
+		OrgRequestPerSecond: DefaultSchedulerOrgRequestPerSecond,
+	}
+	for _, mod := range mods {
+		mod(&scheduler)
+	}
+	return &scheduler
+}
+
+// Concurrency modifies the maximum number of organizations processed concurrently. Default value is 5.
+func Concurrency(x int) func(*Scheduler) {
+	return func(scheduler *Scheduler) {
+		scheduler.Concurrency = x
+	}
+}
+
+// MaxConnections modifies the maximum number of concurrent connections across all organizations. Default value is 10.
+func MaxConnections(x int) func(*Scheduler) {
+	return func(scheduler *Scheduler) {
+		scheduler.MaxConnections = x
+	}
+}
+
+// OrgRequestPerSecond modifies the maximum number of requests per second and organization. Default value is 10.
+func OrgRequestPerSecond(x int) func(*Scheduler) {
+	return func(scheduler *Scheduler) {
+		scheduler.OrgRequestPerSecond = x
+	}
+}
+
+// ReportProgress registers a callback called whenever the workload of an organization completed.
+func ReportProgress(fn func(Progress)) func(*Scheduler) {
+	return func(scheduler *Scheduler) {
+		scheduler.OnProgress = fn
+	}
+}
+
+// Run lists all organizations and runs fn for each of them, see RunOrgs. The organization list
+// is requested with ctx.
+func (scheduler *Scheduler) Run(ctx context.Context, fn func(ctx context.Context, org Org) (interface{}, error)) (OrgResults, error) {
+	orgs, err := scheduler.Client.Orgs(Context(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return scheduler.RunOrgs(ctx, orgs, fn), nil
+}
+
+// RunOrgs runs fn for each organization concurrently and returns the results
+// in the order of orgs. Requests of the organization clients are made with ctx,
+// unless a request sets another context. Organizations not started before ctx
+// is cancelled return the context error.
+func (scheduler *Scheduler) RunOrgs(ctx context.Context, orgs []Org, fn func(ctx context.Context, org Org) (interface{}, error)) OrgResults {
+	results := make(OrgResults, len(orgs))
+	concurrency := scheduler.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	var connLimiter chan struct{}
+	if scheduler.MaxConnections > 0 {
+		connLimiter = make(chan struct{}, scheduler.MaxConnections)
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	progress := Progress{Total: len(orgs)}
+	for i, org := range orgs {
+		org.Client = scheduler.orgClient(ctx, connLimiter)
+		results[i].Org = org
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+		}
+		if err := ctx.Err(); err != nil {
+			results[i].Err = &OrgError{OrgID: org.ID, OrgName: org.Name, Err: err}
+			continue
+		}
+		wg.Add(1)
+		go func(i int, org Org) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			value, err := fn(ctx, org)
+			results[i].Value = value
+			if err != nil {
+				results[i].Err = &OrgError{OrgID: org.ID, OrgName: org.Name, Err: err}
+			}
+			if scheduler.OnProgress != nil {
+				mutex.Lock()
+				progress.Done++
+				scheduler.Client.runHook("OnProgress", func() { scheduler.OnProgress(progress) })
+				mutex.Unlock()
+			}
+		}(i, org)
+	}
+	wg.Wait()
+	return results
+}
+
+// orgClient returns a copy of the scheduler client with its own rate limiter
+// buckets, a shared connection limiter and ctx as default request context.
+func (scheduler *Scheduler) orgClient(ctx context.Context, connLimiter chan struct{}) *Client {
+	client := *scheduler.Client
+	client.DefaultReqMods = append(append([]func(*Req){}, client.DefaultReqMods...), Context(ctx))
+	if scheduler.OrgRequestPerSecond > 0 {
+		rps := int64(scheduler.OrgRequestPerSecond)
+		client.RateLimiterBucket = ratelimit.NewBucketWithQuantum(time.Second, rps, rps)
+		client.keyPool = client.newKeyPool()
+	}
+	client.connLimiter = connLimiter
+	return &client
+}
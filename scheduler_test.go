@@ -0,0 +1,78 @@
+package meraki
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestSchedulerRun tests the Scheduler::Run method.
+func TestSchedulerRun(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+	scheduler := NewScheduler(&client, Concurrency(2), MaxConnections(1), OrgRequestPerSecond(5))
+
+	gock.New(client.BaseUrl).Get("/organizations").
+		Reply(200).
+		BodyString(`[{"id":"1","name":"A"},{"id":"2","name":"B"},{"id":"3","name":"C"}]`)
+	gock.New(client.BaseUrl).Get("/organizations/1/devices").Reply(200).BodyString(`[{"serial":"a"}]`)
+	gock.New(client.BaseUrl).Get("/organizations/2/devices").Reply(200).BodyString(`[{"serial":"b"},{"serial":"c"}]`)
+	gock.New(client.BaseUrl).Get("/organizations/3/devices").Reply(400)
+
+	results, err := scheduler.Run(context.Background(), func(ctx context.Context, org Org) (interface{}, error) {
+		res, err := org.Get("/devices")
+		return len(res.Array()), err
+	})
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, 1, results[0].Value)
+	assert.Equal(t, 2, results[1].Value)
+	assert.NoError(t, results[0].Err)
+	assert.Error(t, results[2].Err)
+	assert.Error(t, results.Err())
+	assert.NotSame(t, results[0].Org.Client.RateLimiterBucket, results[1].Org.Client.RateLimiterBucket)
+}
+
+// TestSchedulerRunOrgsCancelled tests the Scheduler::RunOrgs method with a cancelled context.
+func TestSchedulerRunOrgsCancelled(t *testing.T) {
+	client := testClient()
+	scheduler := NewScheduler(&client)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := scheduler.RunOrgs(ctx, []Org{client.NewOrg("1")}, func(ctx context.Context, org Org) (interface{}, error) {
+		return nil, nil
+	})
+	assert.ErrorIs(t, results.Err(), context.Canceled)
+}
+
+// TestSchedulerRunContext tests that the requests of Scheduler::Run are made with its context.
+func TestSchedulerRunContext(t *testing.T) {
+	defer gock.Off()
+	var tenants []string
+	client, _ := NewClient("abc123", MaxRetries(0), OnRequest(func(req *http.Request) {
+		tenants = append(tenants, LabelsFromContext(req.Context())["tenant"])
+	}))
+	gock.InterceptClient(client.HttpClient)
+	scheduler := NewScheduler(&client, Concurrency(1))
+
+	gock.New(client.BaseUrl).Get("/organizations").Reply(200).BodyString(`[{"id":"1","name":"A"}]`)
+	gock.New(client.BaseUrl).Get("/organizations/1/devices").Reply(200).BodyString(`[]`)
+	_, err := scheduler.Run(WithLabels(context.Background(), "tenant", "a"), func(ctx context.Context, org Org) (interface{}, error) {
+		return org.Get("/devices")
+	})
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+	assert.Equal(t, []string{"a", "a"}, tenants)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = scheduler.Run(ctx, func(ctx context.Context, org Org) (interface{}, error) {
+		t.Fail()
+		return nil, nil
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,67 @@
+package meraki
+
+import (
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// Slog emits a structured log record for every HTTP request attempt via log/slog, in addition
+// to the free-form messages of the standard logger, e.g.
+//
+//	client, _ := NewClient("abc123", Slog(slog.New(slog.NewJSONHandler(os.Stderr, nil))))
+//
+// Records have the attributes method, url, status, attempt, duration and error, as well as
+// the request labels in the group labels, see WithLabels. Successful attempts are logged at
+// debug level and failed attempts at warning level.
+func Slog(logger *slog.Logger) func(*Client) {
+	return func(client *Client) {
+		client.Logger = logger
+	}
+}
+
+// logAttempt emits a structured log record of a request attempt.
+func (client *Client) logAttempt(req Req, attempt, statusCode int, duration time.Duration, err error) {
+	if client.Logger == nil {
+		return
+	}
+	level := slog.LevelDebug
+	if err != nil || statusCode < 200 || statusCode > 299 {
+		level = slog.LevelWarn
+	}
+	ctx := req.HttpReq.Context()
+	if !client.Logger.Enabled(ctx, level) {
+		return
+	}
+	u := req.HttpReq.URL.String()
+	if client.Anonymizer != nil {
+		u = client.Anonymizer.String(u)
+	}
+	attrs := []slog.Attr{
+		slog.String("method", req.HttpReq.Method),
+		slog.String("url", u),
+		slog.Int("status", statusCode),
+		slog.Int("attempt", attempt),
+		slog.Duration("duration", duration),
+	}
+	if err != nil {
+		msg := err.Error()
+		if client.Anonymizer != nil {
+			msg = client.Anonymizer.String(msg)
+		}
+		attrs = append(attrs, slog.String("error", msg))
+	}
+	if labels := req.Labels(); len(labels) > 0 {
+		keys := make([]string, 0, len(labels))
+		for key := range labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		group := make([]any, 0, len(keys))
+		for _, key := range keys {
+			group = append(group, slog.String(key, labels[key]))
+		}
+		attrs = append(attrs, slog.Group("labels", group...))
+	}
+	client.Logger.LogAttrs(ctx, level, "HTTP Request", attrs...)
+}
@@ -0,0 +1,47 @@
+package meraki
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestSlog tests the Slog modifier.
+func TestSlog(t *testing.T) {
+	defer gock.Off()
+	var buf bytes.Buffer
+	client, _ := NewClient("abc123", MaxRetries(1), BackoffMinDelay(0), BackoffMaxDelay(0),
+		Slog(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(client.BaseUrl).Get("/url").Reply(503)
+	gock.New(client.BaseUrl).Get("/url").Reply(200).BodyString(`{}`)
+	_, err := client.GetContext(WithLabels(context.Background(), "tenant", "a"), "/url")
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	records := make([]map[string]interface{}, 0)
+	for _, line := range lines {
+		record := make(map[string]interface{})
+		assert.NoError(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	assert.Equal(t, "WARN", records[0]["level"])
+	assert.Equal(t, float64(503), records[0]["status"])
+	assert.Equal(t, float64(0), records[0]["attempt"])
+	assert.Equal(t, "DEBUG", records[1]["level"])
+	assert.Equal(t, "HTTP Request", records[1]["msg"])
+	assert.Equal(t, "GET", records[1]["method"])
+	assert.Equal(t, client.BaseUrl+"/url", records[1]["url"])
+	assert.Equal(t, float64(200), records[1]["status"])
+	assert.Equal(t, float64(1), records[1]["attempt"])
+	assert.Contains(t, records[1], "duration")
+	assert.Equal(t, map[string]interface{}{"tenant": "a"}, records[1]["labels"])
+}
@@ -0,0 +1,152 @@
+package meraki
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// clientStats holds the counters of a client. It is shared by all copies of a client.
+type clientStats struct {
+	requests atomic.Int64
+	retries  atomic.Int64
+	failures atomic.Int64
+	waiting  atomic.Int64
+	inFlight atomic.Int64
+
+	rateLimitWaits atomic.Int64
+	rateLimitWait  atomic.Int64
+
+	rateLimitRetries   atomic.Int64
+	serverErrorRetries atomic.Int64
+	networkRetries     atomic.Int64
+	transientRetries   atomic.Int64
+
+	hookErrors atomic.Int64
+	hedges     atomic.Int64
+
+	deduplicated atomic.Int64
+}
+
+// Stats is a snapshot of the internal state of a client.
+type Stats struct {
+	// RequestPerSecond is the configured rate of the rate limiter bucket, summed up across all keys of a key pool
+	RequestPerSecond float64 `json:"requestPerSecond"`
+	// AvailableTokens is the number of rate limiter tokens currently available, summed up across all keys of a key pool
+	AvailableTokens int64 `json:"availableTokens"`
+	// QueueDepth is the number of requests waiting for a rate limiter token
+	QueueDepth int64 `json:"queueDepth"`
+	// InFlight is the number of HTTP requests currently in progress
+	InFlight int64 `json:"inFlight"`
+	// RateLimitWaits is the number of HTTP requests which waited for a rate limiter token
+	RateLimitWaits int64 `json:"rateLimitWaits"`
+	// RateLimitWait is the total time HTTP requests waited for rate limiter tokens, i.e. the
+	// time spent self-throttling as opposed to waiting for responses
+	RateLimitWait time.Duration `json:"rateLimitWait"`
+	// Requests is the total number of HTTP requests sent, including retries
+	Requests int64 `json:"requests"`
+	// Retries is the total number of retries
+	Retries int64 `json:"retries"`
+	// RateLimitRetries is the number of retries of rate limited (429) responses
+	RateLimitRetries int64 `json:"rateLimitRetries"`
+	// ServerErrorRetries is the number of retries of 5xx responses
+	ServerErrorRetries int64 `json:"serverErrorRetries"`
+	// NetworkRetries is the number of retries of connection errors
+	NetworkRetries int64 `json:"networkRetries"`
+	// TransientRetries is the number of retries of transient 4xx errors
+	TransientRetries int64 `json:"transientRetries"`
+	// Hedges is the number of hedged GET requests sent, see HedgeDelay
+	Hedges int64 `json:"hedges"`
+	// Deduplicated is the number of GET requests sharing the response of an identical concurrent request, see DeduplicateGets
+	Deduplicated int64 `json:"deduplicated"`
+	// Failures is the total number of requests that returned an error
+	Failures int64 `json:"failures"`
+	// BackoffScale is the factor applied to the minimum backoff delay, see AdaptiveBackoff
+	BackoffScale float64 `json:"backoffScale"`
+	// RateFactor is the fraction of the configured request rate in effect, see AdaptiveRateLimit
+	RateFactor float64 `json:"rateFactor"`
+	// HookErrors is the number of hooks and callbacks which panicked
+	HookErrors int64 `json:"hookErrors"`
+	// Latency are the latency percentiles per path pattern, see SlowRequestThreshold
+	Latency map[string]LatencyStats `json:"latency,omitempty"`
+	// CircuitState is the state of the circuit breaker, e.g. CircuitOpen, empty if disabled
+	CircuitState string `json:"circuitState,omitempty"`
+	// ETagCacheHits is the number of requests answered with 304 Not Modified and served from the ETag cache
+	ETagCacheHits int64 `json:"etagCacheHits"`
+	// LookupCacheHits is the number of requests served from the lookup cache
+	LookupCacheHits int64 `json:"lookupCacheHits"`
+	// LookupCacheMisses is the number of cacheable requests not found in the lookup cache
+	LookupCacheMisses int64 `json:"lookupCacheMisses"`
+	// ResponseCacheHits is the number of requests served from the response cache, see ResponseCache
+	ResponseCacheHits int64 `json:"responseCacheHits"`
+	// ResponseCacheMisses is the number of requests not found in the response cache or expired
+	ResponseCacheMisses int64 `json:"responseCacheMisses"`
+}
+
+// Stats returns a snapshot of the internal state of the client.
+func (client *Client) Stats() Stats {
+	stats := Stats{
+		RequestPerSecond:   client.RateLimiterBucket.Rate(),
+		AvailableTokens:    client.RateLimiterBucket.Available(),
+		QueueDepth:         client.stats.waiting.Load(),
+		InFlight:           client.stats.inFlight.Load(),
+		RateLimitWaits:     client.stats.rateLimitWaits.Load(),
+		RateLimitWait:      time.Duration(client.stats.rateLimitWait.Load()),
+		Requests:           client.stats.requests.Load(),
+		Retries:            client.stats.retries.Load(),
+		RateLimitRetries:   client.stats.rateLimitRetries.Load(),
+		ServerErrorRetries: client.stats.serverErrorRetries.Load(),
+		NetworkRetries:     client.stats.networkRetries.Load(),
+		TransientRetries:   client.stats.transientRetries.Load(),
+		Failures:           client.stats.failures.Load(),
+		HookErrors:         client.stats.hookErrors.Load(),
+		Hedges:             client.stats.hedges.Load(),
+		Deduplicated:       client.stats.deduplicated.Load(),
+		BackoffScale:       client.backoffScale(),
+		RateFactor:         client.rateFactor(),
+		CircuitState:       client.circuitState(),
+	}
+	if client.keyPool != nil {
+		stats.RequestPerSecond, stats.AvailableTokens = 0, 0
+		for _, key := range client.keyPool.keys {
+			stats.RequestPerSecond += key.bucket.Rate()
+			stats.AvailableTokens += key.bucket.Available()
+		}
+	}
+	if client.latency != nil {
+		stats.Latency = client.latency.stats()
+	}
+	if client.etagCache != nil {
+		stats.ETagCacheHits = client.etagCache.hits.Load()
+	}
+	if client.lookupCache != nil {
+		stats.LookupCacheHits = client.lookupCache.hits.Load()
+		stats.LookupCacheMisses = client.lookupCache.misses.Load()
+	}
+	if client.responseCache != nil {
+		stats.ResponseCacheHits = client.responseCache.hits.Load()
+		stats.ResponseCacheMisses = client.responseCache.misses.Load()
+	}
+	return stats
+}
+
+// PublishExpvar publishes the client stats as expvar variable with the given name,
+// which makes them available at /debug/vars when the expvar handler is served.
+// Like expvar.Publish, it panics if the name is already in use.
+func (client *Client) PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return client.Stats()
+	}))
+}
+
+// DebugHandler returns an http.Handler serving the client stats as JSON, e.g.
+//
+//	http.Handle("/debug/meraki", client.DebugHandler())
+func (client *Client) DebugHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(client.Stats())
+	})
+}
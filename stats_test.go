@@ -0,0 +1,62 @@
+package meraki
+
+import (
+	"expvar"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/juju/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestClientStats tests the Client::Stats method.
+func TestClientStats(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Get("/url").Reply(200)
+	gock.New(client.BaseUrl).Get("/url").Reply(404)
+	client.Get("/url")
+	client.Get("/url")
+
+	stats := client.Stats()
+	assert.Equal(t, int64(2), stats.Requests)
+	assert.Equal(t, int64(1), stats.Failures)
+	assert.Equal(t, int64(0), stats.QueueDepth)
+	assert.Equal(t, float64(10), stats.RequestPerSecond)
+}
+
+// TestRateLimitWait tests the accounting of rate limiter waits.
+func TestRateLimitWait(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+	client.RateLimiterBucket = ratelimit.NewBucketWithQuantum(50*time.Millisecond, 1, 1)
+	waits := make([]time.Duration, 0)
+	OnResponse(func(info ResponseInfo) { waits = append(waits, info.RateLimitWait) })(&client)
+
+	gock.New(client.BaseUrl).Get("/url").Times(2).Reply(200)
+	client.Get("/url")
+	client.Get("/url")
+
+	stats := client.Stats()
+	assert.Equal(t, int64(1), stats.RateLimitWaits)
+	assert.Greater(t, stats.RateLimitWait, 20*time.Millisecond)
+	assert.Equal(t, time.Duration(0), waits[0])
+	assert.Equal(t, stats.RateLimitWait, waits[1])
+}
+
+// TestClientDebugHandler tests the Client::DebugHandler and Client::PublishExpvar methods.
+func TestClientDebugHandler(t *testing.T) {
+	client := testClient()
+
+	rec := httptest.NewRecorder()
+	client.DebugHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/debug/meraki", nil))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, int64(10), gjson.Get(rec.Body.String(), "availableTokens").Int())
+
+	client.PublishExpvar("meraki_test")
+	assert.Equal(t, float64(10), gjson.Get(expvar.Get("meraki_test").String(), "requestPerSecond").Float())
+}
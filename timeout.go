@@ -0,0 +1,45 @@
+package meraki
+
+import (
+	"time"
+)
+
+// Unlike RequestTimeout, which limits the total duration of a request including reading the
+// response body, the following modifiers limit the individual phases of a request. They allow
+// catching hung connections quickly, e.g.
+//
+//	client, _ := NewClient("abc123", RequestTimeout(0), DialTimeout(5*time.Second), ResponseHeaderTimeout(30*time.Second))
+//
+// downloads slow but streaming responses without limit, while failing fast if Dashboard does not respond.
+
+// DialTimeout modifies the timeout of establishing a TCP connection from the default of 30 seconds.
+func DialTimeout(x time.Duration) func(*Client) {
+	return func(client *Client) {
+		if d := client.dialConfig(); d != nil {
+			d.dialer.Timeout = x
+		}
+	}
+}
+
+// TLSHandshakeTimeout modifies the timeout of the TLS handshake from the default of 10 seconds.
+func TLSHandshakeTimeout(x time.Duration) func(*Client) {
+	return func(client *Client) {
+		if transport := client.transport(); transport != nil {
+			transport.TLSHandshakeTimeout = x
+		} else {
+			client.logf("[WARNING] TLS handshake timeout ignored, HTTP client uses a custom transport")
+		}
+	}
+}
+
+// ResponseHeaderTimeout modifies the time to wait for the response headers after the request
+// has been written. Default value is 0, which means no limit other than RequestTimeout.
+func ResponseHeaderTimeout(x time.Duration) func(*Client) {
+	return func(client *Client) {
+		if transport := client.transport(); transport != nil {
+			transport.ResponseHeaderTimeout = x
+		} else {
+			client.logf("[WARNING] Response header timeout ignored, HTTP client uses a custom transport")
+		}
+	}
+}
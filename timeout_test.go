@@ -0,0 +1,53 @@
+package meraki
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTimeouts tests the DialTimeout, TLSHandshakeTimeout and ResponseHeaderTimeout modifiers.
+func TestTimeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), RequestTimeout(0),
+		DialTimeout(5*time.Second), TLSHandshakeTimeout(3*time.Second), ResponseHeaderTimeout(50*time.Millisecond))
+	transport := client.HttpClient.Transport.(*http.Transport)
+	assert.Equal(t, 5*time.Second, client.dial.dialer.Timeout)
+	assert.Equal(t, 3*time.Second, transport.TLSHandshakeTimeout)
+	assert.Equal(t, time.Duration(0), client.HttpClient.Timeout)
+
+	_, err := client.Get("/url")
+	assert.NoError(t, err)
+	_, err = client.Get("/slow")
+	assert.Error(t, err)
+}
+
+// TestTimeout tests the Timeout request modifier.
+func TestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), Transport(server.Client().Transport))
+	_, err := client.Get("/url", Timeout(20*time.Millisecond))
+	assert.Error(t, err)
+	_, err = client.Get("/url")
+	assert.NoError(t, err)
+
+	client.HttpClient.Timeout = 20 * time.Millisecond
+	_, err = client.Get("/url", Timeout(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 20*time.Millisecond, client.HttpClient.Timeout)
+}
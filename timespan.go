@@ -0,0 +1,139 @@
+package meraki
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// Timespan is the time range of a report or history request.
+// Either T0 (and optionally T1) or Duration is set, the zero value uses the API default.
+type Timespan struct {
+	// T0 is the beginning of the time range
+	T0 time.Time
+	// T1 is the end of the time range, defaults to now
+	T1 time.Time
+	// Duration is the length of the time range ending now
+	Duration time.Duration
+}
+
+// LastTimespan returns a timespan of the given duration ending now.
+func LastTimespan(d time.Duration) Timespan {
+	return Timespan{Duration: d}
+}
+
+// BetweenTimespan returns the timespan between t0 and t1.
+func BetweenTimespan(t0, t1 time.Time) Timespan {
+	return Timespan{T0: t0, T1: t1}
+}
+
+// IsZero reports whether the timespan is unset.
+func (ts Timespan) IsZero() bool {
+	return ts.T0.IsZero() && ts.T1.IsZero() && ts.Duration == 0
+}
+
+// Length returns the length of the timespan.
+func (ts Timespan) Length() time.Duration {
+	if ts.Duration != 0 {
+		return ts.Duration
+	}
+	if ts.T0.IsZero() {
+		return 0
+	}
+	t1 := ts.T1
+	if t1.IsZero() {
+		t1 = time.Now()
+	}
+	return t1.Sub(ts.T0)
+}
+
+// Validate returns an error if the timespan is invalid or longer than max. A max of 0 disables the length check.
+func (ts Timespan) Validate(max time.Duration) error {
+	if ts.Duration != 0 && !(ts.T0.IsZero() && ts.T1.IsZero()) {
+		return fmt.Errorf("Invalid timespan: duration and t0/t1 are mutually exclusive")
+	}
+	if ts.Duration < 0 || ts.Length() < 0 {
+		return fmt.Errorf("Invalid timespan: negative length %v", ts.Length())
+	}
+	if ts.T0.IsZero() && !ts.T1.IsZero() {
+		return fmt.Errorf("Invalid timespan: t1 requires t0")
+	}
+	if max > 0 && ts.Length() > max {
+		return fmt.Errorf("Invalid timespan: length %v exceeds maximum of %v", ts.Length(), max)
+	}
+	return nil
+}
+
+// Req returns a request modifier setting the t0, t1 and timespan query parameters.
+func (ts Timespan) Req() func(*Req) {
+	return func(req *Req) {
+		if ts.Duration != 0 {
+			Query("timespan", strconv.FormatInt(int64(ts.Duration.Seconds()), 10))(req)
+		}
+		if !ts.T0.IsZero() {
+			Query("t0", ts.T0.UTC().Format(time.RFC3339))(req)
+		}
+		if !ts.T1.IsZero() {
+			Query("t1", ts.T1.UTC().Format(time.RFC3339))(req)
+		}
+	}
+}
+
+// Split splits the timespan into consecutive windows of at most max length.
+// Timespans not longer than max are returned unchanged, longer ones are converted
+// into absolute windows.
+func (ts Timespan) Split(max time.Duration) []Timespan {
+	if max <= 0 || ts.Length() <= max {
+		return []Timespan{ts}
+	}
+	t0, t1 := ts.T0, ts.T1
+	if ts.Duration != 0 {
+		t1 = time.Now()
+		t0 = t1.Add(-ts.Duration)
+	} else if t1.IsZero() {
+		t1 = time.Now()
+	}
+	windows := make([]Timespan, 0)
+	for start := t0; start.Before(t1); start = start.Add(max) {
+		end := start.Add(max)
+		if end.After(t1) {
+			end = t1
+		}
+		windows = append(windows, BetweenTimespan(start, end))
+	}
+	return windows
+}
+
+// GetTimespan makes a GET request for a timespan longer than the maximum of an endpoint.
+// The timespan is split into consecutive windows of at most max length, which are requested
+// sequentially, throttled by the rate limiter of the client, e.g.
+//
+//	res, err := client.GetTimespan("/networks/N_123/events", LastTimespan(90*24*time.Hour), 31*24*time.Hour)
+//
+// The results are merged in chronological order of the windows: arrays are concatenated
+// and other results are added as array elements. The timespan is validated first.
+func (client *Client) GetTimespan(path string, ts Timespan, max time.Duration, mods ...func(*Req)) (Res, error) {
+	if err := ts.Validate(0); err != nil {
+		return Res{}, err
+	}
+	r := "[]"
+	var warnings []string
+	for _, window := range ts.Split(max) {
+		res, err := client.Get(path, append([]func(*Req){window.Req()}, mods...)...)
+		if err != nil {
+			return res, err
+		}
+		warnings = append(warnings, res.Warnings...)
+		if !res.IsArray() {
+			r, _ = sjson.SetRaw(r, "-1", res.Raw)
+			continue
+		}
+		for _, item := range res.Array() {
+			r, _ = sjson.SetRaw(r, "-1", item.Raw)
+		}
+	}
+	return Res{Result: gjson.Parse(r), Warnings: warnings, useNumber: client.PreserveNumbers}, nil
+}
@@ -0,0 +1,65 @@
+package meraki
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestTimespan tests the Timespan::Validate and Timespan::Req methods.
+func TestTimespan(t *testing.T) {
+	client, _ := NewClient("abc123")
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	ts := BetweenTimespan(t0, t0.Add(time.Hour))
+	assert.NoError(t, ts.Validate(time.Hour))
+	assert.Error(t, ts.Validate(time.Minute))
+	req := client.NewReq("GET", "/url", nil, ts.Req())
+	assert.Equal(t, "t0=2024-01-01T00%3A00%3A00Z&t1=2024-01-01T01%3A00%3A00Z", req.HttpReq.URL.RawQuery)
+
+	ts = LastTimespan(24 * time.Hour)
+	assert.NoError(t, ts.Validate(0))
+	req = client.NewReq("GET", "/url", nil, ts.Req())
+	assert.Equal(t, "timespan=86400", req.HttpReq.URL.RawQuery)
+
+	assert.Error(t, Timespan{T0: t0, Duration: time.Hour}.Validate(0))
+	assert.Error(t, Timespan{T1: t0}.Validate(0))
+	assert.True(t, Timespan{}.IsZero())
+}
+
+// TestTimespanSplit tests the Timespan::Split method.
+func TestTimespanSplit(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	windows := BetweenTimespan(t0, t0.Add(25*time.Hour)).Split(12 * time.Hour)
+	assert.Equal(t, []Timespan{
+		BetweenTimespan(t0, t0.Add(12*time.Hour)),
+		BetweenTimespan(t0.Add(12*time.Hour), t0.Add(24*time.Hour)),
+		BetweenTimespan(t0.Add(24*time.Hour), t0.Add(25*time.Hour)),
+	}, windows)
+
+	assert.Equal(t, []Timespan{LastTimespan(time.Hour)}, LastTimespan(time.Hour).Split(12*time.Hour))
+	assert.Len(t, LastTimespan(48*time.Hour).Split(12*time.Hour), 4)
+}
+
+// TestClientGetTimespan tests the Client::GetTimespan method.
+func TestClientGetTimespan(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	gock.New(client.BaseUrl).Get("/history").
+		MatchParam("t0", "2024-01-01T00:00:00Z").MatchParam("t1", "2024-01-02T00:00:00Z").
+		Reply(200).BodyString(`[{"a":1},{"a":2}]`)
+	gock.New(client.BaseUrl).Get("/history").
+		MatchParam("t0", "2024-01-02T00:00:00Z").MatchParam("t1", "2024-01-02T12:00:00Z").
+		Reply(200).BodyString(`{"a":3}`)
+	res, err := client.GetTimespan("/history", BetweenTimespan(t0, t0.Add(36*time.Hour)), 24*time.Hour)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"a":1},{"a":2},{"a":3}]`, res.Raw)
+	assert.True(t, gock.IsDone())
+
+	_, err = client.GetTimespan("/history", Timespan{T1: t0}, 24*time.Hour)
+	assert.Error(t, err)
+}
@@ -0,0 +1,106 @@
+package meraki
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// TLSConfig replaces the TLS configuration of the HTTP transport, e.g. to set a minimum version
+// or custom root CAs. The configuration is cloned, so later modifiers do not modify cfg.
+func TLSConfig(cfg *tls.Config) func(*Client) {
+	return func(client *Client) {
+		if transport := client.transport(); transport != nil {
+			transport.TLSClientConfig = cfg.Clone()
+		} else {
+			client.logf("[WARNING] TLS config ignored, HTTP client uses a custom transport")
+		}
+	}
+}
+
+// RootCAs replaces the root CAs used to verify the API server certificate, e.g. of a
+// TLS-intercepting proxy or a private mirror of api.meraki.com.
+func RootCAs(pool *x509.CertPool) func(*Client) {
+	return func(client *Client) {
+		if cfg := client.tlsConfig(); cfg != nil {
+			cfg.RootCAs = pool
+		}
+	}
+}
+
+// CABundle adds the PEM encoded CA certificates of a file to the system root CAs used to
+// verify the API server certificate, e.g.
+//
+//	client, err := NewClient("abc123", CABundle("/etc/ssl/proxy-ca.pem"))
+//
+// NewClient fails if the file cannot be read or contains no certificates.
+func CABundle(pemFile string) func(*Client) {
+	return func(client *Client) {
+		data, err := os.ReadFile(pemFile)
+		if err != nil {
+			client.fail(fmt.Errorf("failed to read CA bundle: %w", err))
+			return
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(data) {
+			client.fail(fmt.Errorf("no certificates found in CA bundle %s", pemFile))
+			return
+		}
+		RootCAs(pool)(client)
+	}
+}
+
+// ClientCertificate loads a PEM encoded client certificate and key from files and presents
+// it to servers requesting mutual TLS, e.g. egress gateways. NewClient fails if the files
+// cannot be loaded.
+func ClientCertificate(certFile, keyFile string) func(*Client) {
+	return func(client *Client) {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			client.fail(fmt.Errorf("failed to load client certificate: %w", err))
+			return
+		}
+		ClientTLSCertificate(cert)(client)
+	}
+}
+
+// ClientTLSCertificate presents an in-memory client certificate to servers requesting mutual TLS.
+func ClientTLSCertificate(cert tls.Certificate) func(*Client) {
+	return func(client *Client) {
+		if cfg := client.tlsConfig(); cfg != nil {
+			cfg.Certificates = append(cfg.Certificates, cert)
+		}
+	}
+}
+
+// Insecure disables the verification of the API server certificate.
+//
+// This is UNSAFE and makes connections vulnerable to man-in-the-middle attacks, including
+// theft of the API token. Only use it in lab environments, e.g. with BaseUrl pointing to
+// a test server with a self-signed certificate, and prefer CABundle otherwise.
+func Insecure() func(*Client) {
+	return func(client *Client) {
+		if cfg := client.tlsConfig(); cfg != nil {
+			client.logf("[WARNING] TLS certificate verification disabled")
+			cfg.InsecureSkipVerify = true
+		}
+	}
+}
+
+// tlsConfig returns the TLS configuration of the HTTP transport, creating it if needed.
+// It returns nil if the client uses a custom transport, which is not an *http.Transport.
+func (client *Client) tlsConfig() *tls.Config {
+	transport := client.transport()
+	if transport == nil {
+		client.logf("[WARNING] TLS settings ignored, HTTP client uses a custom transport")
+		return nil
+	}
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	return transport.TLSClientConfig
+}
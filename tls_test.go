@@ -0,0 +1,98 @@
+package meraki
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestCABundle tests the CABundle, RootCAs, TLSConfig and Insecure modifiers.
+func TestCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient("abc123", BaseUrl(server.URL), MaxRetries(0))
+	assert.NoError(t, err)
+	_, err = client.Get("/url")
+	assert.Error(t, err)
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	os.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o644)
+	client, err = NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), TLSConfig(&tls.Config{MinVersion: tls.VersionTLS12}), CABundle(bundle))
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS12), client.HttpClient.Transport.(*http.Transport).TLSClientConfig.MinVersion)
+	_, err = client.Get("/url")
+	assert.NoError(t, err)
+
+	client, _ = NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), Insecure())
+	_, err = client.Get("/url")
+	assert.NoError(t, err)
+
+	_, err = NewClient("abc123", CABundle(filepath.Join(t.TempDir(), "missing.pem")))
+	assert.Error(t, err)
+	os.WriteFile(bundle, []byte("no certificate"), 0o644)
+	_, err = NewClient("abc123", CABundle(bundle))
+	assert.ErrorContains(t, err, "no certificates found")
+}
+
+// testClientCertificate writes a self-signed client certificate and key to dir.
+func testClientCertificate(t *testing.T, dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "go-meraki"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	certFile, keyFile := filepath.Join(dir, "client.pem"), filepath.Join(dir, "client.key")
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600)
+	return certFile, keyFile
+}
+
+// TestClientCertificate tests the ClientCertificate modifier.
+func TestClientCertificate(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"cn":"` + r.TLS.PeerCertificates[0].Subject.CommonName + `"}`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), RootCAs(pool))
+	_, err := client.Get("/url")
+	assert.Error(t, err)
+
+	certFile, keyFile := testClientCertificate(t, t.TempDir())
+	client, err = NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), RootCAs(pool), ClientCertificate(certFile, keyFile))
+	assert.NoError(t, err)
+	res, err := client.Get("/url")
+	assert.NoError(t, err)
+	assert.Equal(t, "go-meraki", res.Get("cn").String())
+
+	_, err = NewClient("abc123", ClientCertificate(keyFile, certFile))
+	assert.ErrorContains(t, err, "failed to load client certificate")
+}
@@ -0,0 +1,139 @@
+package meraki
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// Authentication schemes, see AuthScheme.
+const (
+	// AuthSchemeBearer sends the token in the Authorization header as bearer token
+	AuthSchemeBearer string = "Bearer"
+	// AuthSchemeApiKey sends the token in the legacy X-Cisco-Meraki-API-Key header
+	AuthSchemeApiKey string = "X-Cisco-Meraki-API-Key"
+)
+
+// TokenProvider provides the token attached to requests, e.g. to rotate API keys of long
+// running services without recreating clients. GetToken is called before every request
+// attempt and must be safe for concurrent use. If a request fails with 401 and the provider
+// has an Invalidate method, it is called and the request is retried once.
+type TokenProvider interface {
+	GetToken(ctx context.Context) (string, error)
+}
+
+// StaticToken is a TokenProvider always returning the same token. Clients without a token
+// provider behave like using a StaticToken of their API token.
+type StaticToken string
+
+// GetToken implements the TokenProvider interface.
+func (token StaticToken) GetToken(ctx context.Context) (string, error) {
+	return string(token), nil
+}
+
+// ApiTokenProvider attaches the tokens of a provider to requests instead of the API token, e.g.
+//
+//	client, _ := NewClient("", ApiTokenProvider(vault)) // vault implements GetToken(ctx)
+//
+// In key pool mode, the provider replaces the tokens of all keys, while the rate limiter
+// buckets of the keys are still used.
+func ApiTokenProvider(provider TokenProvider) func(*Client) {
+	return func(client *Client) {
+		client.TokenProvider = provider
+	}
+}
+
+// AuthScheme modifies the authentication scheme. Default value is AuthSchemeBearer. Use
+// AuthSchemeApiKey for proxies and older tooling expecting the legacy X-Cisco-Meraki-API-Key
+// header, e.g.
+//
+//	client, _ := NewClient("abc123", AuthScheme(AuthSchemeApiKey))
+func AuthScheme(x string) func(*Client) {
+	return func(client *Client) {
+		if x != AuthSchemeBearer && x != AuthSchemeApiKey {
+			client.fail(fmt.Errorf("invalid authentication scheme '%s'", x))
+			return
+		}
+		client.AuthScheme = x
+	}
+}
+
+// authenticate adds the token to a request according to the authentication scheme.
+func (client *Client) authenticate(req *http.Request, token string) {
+	if client.AuthScheme == AuthSchemeApiKey {
+		req.Header.Del("Authorization")
+		req.Header.Set(AuthSchemeApiKey, token)
+		return
+	}
+	req.Header.Del(AuthSchemeApiKey)
+	req.Header.Set("Authorization", "Bearer "+token)
+}
+
+// accessToken returns the token of the token provider or the given API token if no token provider is configured.
+func (client *Client) accessToken(ctx context.Context, token string) (string, error) {
+	if client.TokenProvider == nil {
+		return token, nil
+	}
+	return client.TokenProvider.GetToken(context.WithValue(ctx, tokenClientKey{}, client))
+}
+
+// tokenClientKey is the context key of the client requesting a token from a token provider,
+// e.g. to log with the client, see Client.logf.
+type tokenClientKey struct{}
+
+// invalidateToken invalidates the token of the token provider after a 401 response and
+// reports whether the request should be retried.
+func (client *Client) invalidateToken(statusCode int) bool {
+	if client.TokenProvider == nil || statusCode != 401 {
+		return false
+	}
+	provider, ok := client.TokenProvider.(interface{ Invalidate() })
+	if ok {
+		provider.Invalidate()
+	}
+	return ok
+}
+
+// tokenState is the API token set by SetToken.
+type tokenState struct {
+	mutex sync.RWMutex
+	token string
+	set   bool
+}
+
+// SetToken replaces the API token of the client and all its copies, e.g. after a key rotation.
+// It is safe to call while requests are in flight, requests already sent are not affected and
+// request attempts after the call use the new token, including retries. In key pool mode the
+// API token of the client is replaced and its failure history is reset, the additional keys
+// of ApiKeys are not changed. Tokens of a TokenProvider take precedence over the API token.
+func (client *Client) SetToken(token string) {
+	if client.token == nil {
+		client.token = &tokenState{}
+	}
+	client.token.mutex.Lock()
+	client.token.token = token
+	client.token.set = true
+	client.token.mutex.Unlock()
+	if client.keyPool != nil {
+		client.keyPool.mutex.Lock()
+		key := client.keyPool.keys[0]
+		key.token = token
+		key.failures = 0
+		key.unhealthy = false
+		client.keyPool.mutex.Unlock()
+	}
+}
+
+// currentToken returns the API token set by SetToken or ApiToken if SetToken was never called.
+func (client *Client) currentToken() string {
+	if client.token == nil {
+		return client.ApiToken
+	}
+	client.token.mutex.RLock()
+	defer client.token.mutex.RUnlock()
+	if !client.token.set {
+		return client.ApiToken
+	}
+	return client.token.token
+}
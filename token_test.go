@@ -0,0 +1,119 @@
+package meraki
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+type rotatingToken struct {
+	mutex sync.Mutex
+	token string
+}
+
+func (p *rotatingToken) GetToken(ctx context.Context) (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.token == "" {
+		return "", errors.New("no token")
+	}
+	return p.token, nil
+}
+
+func (p *rotatingToken) set(token string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.token = token
+}
+
+// TestApiTokenProvider tests the ApiTokenProvider modifier.
+func TestApiTokenProvider(t *testing.T) {
+	defer gock.Off()
+	provider := &rotatingToken{token: "key1"}
+	client, _ := NewClient("", MaxRetries(0), ApiTokenProvider(provider))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(client.BaseUrl).Get("/url").MatchHeader("Authorization", "Bearer key1").Reply(200)
+	_, err := client.Get("/url")
+	assert.NoError(t, err)
+
+	provider.set("key2")
+	gock.New(client.BaseUrl).Get("/url").MatchHeader("Authorization", "Bearer key2").Reply(200)
+	_, err = client.Get("/url")
+	assert.NoError(t, err)
+
+	provider.set("")
+	_, err = client.Get("/url")
+	assert.ErrorContains(t, err, "no token")
+
+	// Static token
+	client, _ = NewClient("", MaxRetries(0), ApiTokenProvider(StaticToken("static")))
+	gock.InterceptClient(client.HttpClient)
+	gock.New(client.BaseUrl).Get("/url").MatchHeader("Authorization", "Bearer static").Reply(200)
+	_, err = client.Get("/url")
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+// TestSetToken tests changing the API token while requests are in flight.
+func TestSetToken(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+	copied := client
+
+	gock.New(client.BaseUrl).Get("/url").Times(20).Reply(200)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			client.Get("/url")
+		}()
+		go func() {
+			defer wg.Done()
+			copied.SetToken("rotated")
+		}()
+	}
+	wg.Wait()
+
+	gock.New(client.BaseUrl).Get("/url").MatchHeader("Authorization", "Bearer rotated").Reply(200)
+	_, err := client.Get("/url")
+	assert.NoError(t, err)
+
+	// Key pool
+	client, _ = NewClient("abc123", MaxRetries(0), ApiKeys("def456"), KeyFailureThreshold(1))
+	gock.InterceptClient(client.HttpClient)
+	gock.New(client.BaseUrl).Get("/url").MatchHeader("Authorization", "Bearer abc123").Reply(401)
+	gock.New(client.BaseUrl).Get("/url").MatchHeader("Authorization", "Bearer def456").Reply(200)
+	_, err = client.Get("/url")
+	assert.NoError(t, err)
+	client.SetToken("rotated")
+	assert.Equal(t, "rotated", client.keyPool.keys[0].token)
+	assert.False(t, client.keyPool.keys[0].unhealthy)
+	assert.Equal(t, 0, client.keyPool.keys[0].failures)
+}
+
+// TestAuthScheme tests the AuthScheme modifier.
+func TestAuthScheme(t *testing.T) {
+	defer gock.Off()
+	client, _ := NewClient("abc123", MaxRetries(0), AuthScheme(AuthSchemeApiKey))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(client.BaseUrl).Get("/url").
+		MatchHeader("X-Cisco-Meraki-API-Key", "abc123").
+		AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+			return req.Header.Get("Authorization") == "", nil
+		}).
+		Reply(200)
+	_, err := client.Get("/url")
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+
+	_, err = NewClient("abc123", AuthScheme("Basic"))
+	assert.ErrorContains(t, err, "invalid authentication scheme")
+}
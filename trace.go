@@ -0,0 +1,172 @@
+package meraki
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Attributes of the spans created by a Tracer, following the OpenTelemetry semantic conventions
+// where applicable.
+const (
+	// TraceAttrMethod is the HTTP method of a request
+	TraceAttrMethod string = "http.request.method"
+	// TraceAttrPath is the sanitized path of a request, see RoutePath
+	TraceAttrPath string = "url.template"
+	// TraceAttrStatusCode is the status code of the last response, omitted for connection errors
+	TraceAttrStatusCode string = "http.response.status_code"
+	// TraceAttrAttempt is the number of previous attempts of a request attempt
+	TraceAttrAttempt string = "http.request.resend_count"
+	// TraceAttrAttempts is the number of attempts of an API call
+	TraceAttrAttempts string = "meraki.attempts"
+)
+
+// Tracer creates spans, e.g. backed by an OpenTelemetry tracer, see Tracing.
+// Implementations must be safe for concurrent use.
+type Tracer interface {
+	// Start creates a span as child of the span of the context and returns a context containing it
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a span created by a Tracer.
+type Span interface {
+	// SetAttribute sets an attribute of the span, the value is a string or an int
+	SetAttribute(key string, value interface{})
+	// RecordError records the error of the span
+	RecordError(err error)
+	// End completes the span
+	End()
+}
+
+// Tracing creates a span per API call, starting from the context of the request, and a child
+// span per request attempt, including retries. The context of the attempt span is the context of
+// the HTTP request, so instrumented transports and OnRequest hooks can propagate it. The span
+// of an API call records its error, attempt spans record connection errors. An adapter for
+// OpenTelemetry looks like:
+//
+//	type otelTracer struct{ trace.Tracer }
+//	type otelSpan struct{ trace.Span }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string) (context.Context, meraki.Span) {
+//		ctx, span := t.Tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+//		return ctx, otelSpan{span}
+//	}
+//
+//	func (s otelSpan) SetAttribute(key string, value interface{}) {
+//		switch v := value.(type) {
+//		case int:
+//			s.SetAttributes(attribute.Int(key, v))
+//		case string:
+//			s.SetAttributes(attribute.String(key, v))
+//		}
+//	}
+//
+//	func (s otelSpan) RecordError(err error) {
+//		s.Span.RecordError(err)
+//		s.SetStatus(codes.Error, err.Error())
+//	}
+//
+//	func (s otelSpan) End() { s.Span.End() }
+//
+//	client, _ := meraki.NewClient("abc123", meraki.Tracing(otelTracer{otel.Tracer("meraki")}))
+func Tracing(t Tracer) func(*Client) {
+	return func(client *Client) {
+		client.Tracer = t
+	}
+}
+
+// callSpanKey and attemptSpanKey are the context keys of the spans of an API call and an attempt.
+type callSpanKey struct{}
+type attemptSpanKey struct{}
+
+// callSpan is the span of an API call.
+type callSpan struct {
+	span     Span
+	name     string
+	attempts atomic.Int64
+}
+
+// startTrace starts the span of an API call and returns a function ending it. The context of
+// the HTTP request is replaced with the context of the span until the span ends. Panics of
+// the tracer and its spans are recovered like panics of hooks, see HookError.
+func (client *Client) startTrace(req Req) func(statusCode int, err error) {
+	if client.Tracer == nil {
+		return func(int, error) {}
+	}
+	method := req.HttpReq.Method
+	path := RoutePath(client.relPath(req.HttpReq.URL))
+	parent := req.HttpReq.Context()
+	s := &callSpan{name: method + " " + path}
+	ctx, span := client.startSpan(parent, s.name)
+	if span == nil {
+		return func(int, error) {}
+	}
+	s.span = span
+	client.runHook("Tracer", func() {
+		span.SetAttribute(TraceAttrMethod, method)
+		span.SetAttribute(TraceAttrPath, path)
+	})
+	*req.HttpReq = *req.HttpReq.WithContext(context.WithValue(ctx, callSpanKey{}, s))
+	return func(statusCode int, err error) {
+		*req.HttpReq = *req.HttpReq.WithContext(parent)
+		client.endSpan(span, statusCode, TraceAttrAttempts, int(s.attempts.Load()), err)
+	}
+}
+
+// startAttempt starts the span of a request attempt as child of the span of the API call
+// and sets its context as context of the HTTP request.
+func (client *Client) startAttempt(ctx context.Context, req Req, attempt int) {
+	s, ok := ctx.Value(callSpanKey{}).(*callSpan)
+	if !ok {
+		return
+	}
+	s.attempts.Add(1)
+	ctx, span := client.startSpan(ctx, s.name+" attempt")
+	if span == nil {
+		return
+	}
+	client.runHook("Tracer", func() {
+		span.SetAttribute(TraceAttrMethod, req.HttpReq.Method)
+		span.SetAttribute(TraceAttrPath, RoutePath(client.relPath(req.HttpReq.URL)))
+		span.SetAttribute(TraceAttrAttempt, attempt)
+	})
+	*req.HttpReq = *req.HttpReq.WithContext(context.WithValue(ctx, attemptSpanKey{}, span))
+}
+
+// endAttempt ends the span of a request attempt.
+func (client *Client) endAttempt(req Req, statusCode int, err error) {
+	span, ok := req.HttpReq.Context().Value(attemptSpanKey{}).(Span)
+	if !ok {
+		return
+	}
+	client.endSpan(span, statusCode, "", nil, err)
+}
+
+// startSpan starts a span, the span is nil if the tracer panicked.
+func (client *Client) startSpan(parent context.Context, name string) (context.Context, Span) {
+	var ctx context.Context
+	var span Span
+	if err := client.runHook("Tracer", func() { ctx, span = client.Tracer.Start(parent, name) }); err != nil || span == nil {
+		return parent, nil
+	}
+	if ctx == nil {
+		ctx = parent
+	}
+	return ctx, span
+}
+
+// endSpan sets the status code, an optional attribute and the error of a span and ends it.
+// The span is ended even if setting the attributes panicked.
+func (client *Client) endSpan(span Span, statusCode int, key string, value interface{}, err error) {
+	client.runHook("Tracer", func() {
+		if statusCode != 0 {
+			span.SetAttribute(TraceAttrStatusCode, statusCode)
+		}
+		if key != "" {
+			span.SetAttribute(key, value)
+		}
+		if err != nil {
+			span.RecordError(err)
+		}
+	})
+	client.runHook("Tracer", span.End)
+}
@@ -0,0 +1,122 @@
+package meraki
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+type testSpan struct {
+	name       string
+	parent     *testSpan
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (s *testSpan) SetAttribute(key string, value interface{}) { s.attributes[key] = value }
+func (s *testSpan) RecordError(err error)                      { s.err = err }
+func (s *testSpan) End()                                       { s.ended = true }
+
+type testTracer struct {
+	mutex sync.Mutex
+	spans []*testSpan
+}
+
+type testSpanKey struct{}
+
+func (t *testTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	parent, _ := ctx.Value(testSpanKey{}).(*testSpan)
+	span := &testSpan{name: name, parent: parent, attributes: make(map[string]interface{})}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, testSpanKey{}, span), span
+}
+
+// TestTracing tests the Tracing modifier.
+func TestTracing(t *testing.T) {
+	defer gock.Off()
+	tracer := &testTracer{}
+	var propagated []*testSpan
+	client, _ := NewClient("abc123", MaxRetries(1), BackoffMinDelay(0), BackoffMaxDelay(0), Tracing(tracer),
+		OnRequest(func(req *http.Request) {
+			span, _ := req.Context().Value(testSpanKey{}).(*testSpan)
+			propagated = append(propagated, span)
+		}))
+	gock.InterceptClient(client.HttpClient)
+
+	root := &testSpan{name: "root", attributes: make(map[string]interface{})}
+	ctx := context.WithValue(context.Background(), testSpanKey{}, root)
+	gock.New(client.BaseUrl).Get("/networks/N_1/clients").ReplyError(errors.New("fail"))
+	gock.New(client.BaseUrl).Get("/networks/N_1/clients").Reply(404)
+	_, err := client.GetContext(ctx, "/networks/N_1/clients")
+	assert.Error(t, err)
+	assert.True(t, gock.IsDone())
+
+	assert.Len(t, tracer.spans, 3)
+	call := tracer.spans[0]
+	assert.Equal(t, "GET /networks/{id}/clients", call.name)
+	assert.Equal(t, root, call.parent)
+	assert.Equal(t, "GET", call.attributes[TraceAttrMethod])
+	assert.Equal(t, "/networks/{id}/clients", call.attributes[TraceAttrPath])
+	assert.Equal(t, 404, call.attributes[TraceAttrStatusCode])
+	assert.Equal(t, 2, call.attributes[TraceAttrAttempts])
+	assert.Error(t, call.err)
+	assert.True(t, call.ended)
+
+	for i, attempt := range tracer.spans[1:] {
+		assert.Equal(t, "GET /networks/{id}/clients attempt", attempt.name)
+		assert.Equal(t, call, attempt.parent)
+		assert.Equal(t, i, attempt.attributes[TraceAttrAttempt])
+		assert.Equal(t, attempt, propagated[i])
+		assert.True(t, attempt.ended)
+	}
+	assert.Error(t, tracer.spans[1].err)
+	assert.Nil(t, tracer.spans[1].attributes[TraceAttrStatusCode])
+	assert.NoError(t, tracer.spans[2].err)
+	assert.Equal(t, 404, tracer.spans[2].attributes[TraceAttrStatusCode])
+}
+
+type panicTracer struct {
+	start bool
+	ended int
+}
+
+type panicSpan struct{ tracer *panicTracer }
+
+func (s panicSpan) SetAttribute(key string, value interface{}) { panic("attribute") }
+func (s panicSpan) RecordError(err error)                      { panic("error") }
+func (s panicSpan) End()                                       { s.tracer.ended++ }
+
+func (t *panicTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	if t.start {
+		panic("start")
+	}
+	return ctx, panicSpan{t}
+}
+
+// TestTracingPanic tests that panics of tracers and spans do not fail requests.
+func TestTracingPanic(t *testing.T) {
+	defer gock.Off()
+	tracer := &panicTracer{}
+	client, _ := NewClient("abc123", MaxRetries(0), Tracing(tracer))
+	gock.InterceptClient(client.HttpClient)
+
+	gock.New(client.BaseUrl).Get("/url").Reply(200)
+	_, err := client.Get("/url")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, tracer.ended)
+	assert.Equal(t, int64(4), client.Stats().HookErrors)
+
+	tracer.start = true
+	gock.New(client.BaseUrl).Get("/url").Reply(200)
+	_, err = client.Get("/url")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), client.Stats().HookErrors)
+}
@@ -0,0 +1,169 @@
+package typed
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	meraki "github.com/netascode/go-meraki"
+)
+
+const (
+	AdminAccessFull       string = "full"
+	AdminAccessReadOnly   string = "read-only"
+	AdminAccessEnterprise string = "enterprise"
+	AdminAccessNone       string = "none"
+)
+
+// AdminTag is a tag based privilege of an administrator.
+type AdminTag struct {
+	Tag    string `json:"tag"`
+	Access string `json:"access"`
+}
+
+// AdminNetwork is a network privilege of an administrator.
+type AdminNetwork struct {
+	ID     string `json:"id"`
+	Access string `json:"access"`
+}
+
+// Admin is a dashboard administrator of an organization.
+type Admin struct {
+	ID                   string         `json:"id,omitempty"`
+	Name                 string         `json:"name"`
+	Email                string         `json:"email"`
+	OrgAccess            string         `json:"orgAccess"`
+	AuthenticationMethod string         `json:"authenticationMethod,omitempty"`
+	AccountStatus        string         `json:"accountStatus,omitempty"`
+	TwoFactorAuthEnabled bool           `json:"twoFactorAuthEnabled,omitempty"`
+	HasApiKey            bool           `json:"hasApiKey,omitempty"`
+	LastActive           string         `json:"lastActive,omitempty"`
+	Tags                 []AdminTag     `json:"tags"`
+	Networks             []AdminNetwork `json:"networks"`
+}
+
+// body returns the JSON body for create or update requests, excluding read-only attributes.
+func (admin Admin) body(create bool) string {
+	body := meraki.Body{}.
+		Set("name", admin.Name).
+		Set("orgAccess", admin.OrgAccess)
+	if create {
+		body = body.Set("email", admin.Email)
+		if admin.AuthenticationMethod != "" {
+			body = body.Set("authenticationMethod", admin.AuthenticationMethod)
+		}
+	}
+	tags, _ := json.Marshal(nonNil(admin.Tags))
+	networks, _ := json.Marshal(nonNil(admin.Networks))
+	return body.SetRaw("tags", string(tags)).SetRaw("networks", string(networks)).Str
+}
+
+// equal reports whether the configurable attributes of two administrators are equal,
+// ignoring the order of privileges.
+func (admin Admin) equal(other Admin) bool {
+	if admin.Name != other.Name || admin.OrgAccess != other.OrgAccess ||
+		len(admin.Tags) != len(other.Tags) || len(admin.Networks) != len(other.Networks) {
+		return false
+	}
+	a, b := sortedAdminTags(admin.Tags), sortedAdminTags(other.Tags)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	c, d := sortedAdminNetworks(admin.Networks), sortedAdminNetworks(other.Networks)
+	for i := range c {
+		if c[i] != d[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Admins returns all administrators of an organization.
+func (client Client) Admins(orgID string, mods ...func(*meraki.Req)) ([]Admin, error) {
+	res, err := client.NewOrg(orgID).Get("/admins", mods...)
+	if err != nil {
+		return nil, err
+	}
+	admins := make([]Admin, 0)
+	err = res.Unmarshal(&admins)
+	return admins, err
+}
+
+// CreateAdmin creates an administrator and returns the created administrator.
+func (client Client) CreateAdmin(orgID string, admin Admin, mods ...func(*meraki.Req)) (Admin, error) {
+	res, err := client.NewOrg(orgID).Post("/admins", admin.body(true), mods...)
+	if err != nil {
+		return Admin{}, err
+	}
+	created := Admin{}
+	err = res.Unmarshal(&created)
+	return created, err
+}
+
+// UpdateAdmin updates the name and privileges of an administrator identified by admin.ID.
+func (client Client) UpdateAdmin(orgID string, admin Admin, mods ...func(*meraki.Req)) (Admin, error) {
+	res, err := client.NewOrg(orgID).Put("/admins/"+admin.ID, admin.body(false), mods...)
+	if err != nil {
+		return Admin{}, err
+	}
+	updated := Admin{}
+	err = res.Unmarshal(&updated)
+	return updated, err
+}
+
+// DeleteAdmin deletes an administrator.
+func (client Client) DeleteAdmin(orgID, adminID string, mods ...func(*meraki.Req)) error {
+	_, err := client.NewOrg(orgID).Delete("/admins/"+adminID, mods...)
+	return err
+}
+
+// EnsureAdmin makes sure an administrator with the given email address exists with
+// the given name and privileges. The administrator is created if missing and updated
+// if different. It returns the resulting administrator and whether anything changed, e.g.
+//
+//	admin, changed, err := client.EnsureAdmin("123", typed.Admin{
+//		Name:      "Jane",
+//		Email:     "jane@example.com",
+//		OrgAccess: typed.AdminAccessReadOnly,
+//	})
+func (client Client) EnsureAdmin(orgID string, admin Admin, mods ...func(*meraki.Req)) (Admin, bool, error) {
+	admins, err := client.Admins(orgID, mods...)
+	if err != nil {
+		return Admin{}, false, err
+	}
+	for _, existing := range admins {
+		if !strings.EqualFold(existing.Email, admin.Email) {
+			continue
+		}
+		if existing.equal(admin) {
+			return existing, false, nil
+		}
+		admin.ID = existing.ID
+		updated, err := client.UpdateAdmin(orgID, admin, mods...)
+		return updated, err == nil, err
+	}
+	created, err := client.CreateAdmin(orgID, admin, mods...)
+	return created, err == nil, err
+}
+
+func sortedAdminTags(tags []AdminTag) []AdminTag {
+	s := append([]AdminTag{}, tags...)
+	sort.Slice(s, func(i, j int) bool { return s[i].Tag+s[i].Access < s[j].Tag+s[j].Access })
+	return s
+}
+
+func sortedAdminNetworks(networks []AdminNetwork) []AdminNetwork {
+	s := append([]AdminNetwork{}, networks...)
+	sort.Slice(s, func(i, j int) bool { return s[i].ID+s[i].Access < s[j].ID+s[j].Access })
+	return s
+}
+
+// nonNil returns an empty slice instead of nil, so it is marshalled as [] instead of null.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
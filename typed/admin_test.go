@@ -0,0 +1,60 @@
+package typed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestClientEnsureAdmin tests the Client::EnsureAdmin method.
+func TestClientEnsureAdmin(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+	existing := `[{"id":"1","name":"Jane","email":"jane@example.com","orgAccess":"none","tags":[],"networks":[{"id":"N_1","access":"full"},{"id":"N_2","access":"read-only"}]}]`
+	admin := Admin{
+		Name:      "Jane",
+		Email:     "Jane@example.com",
+		OrgAccess: AdminAccessNone,
+		Networks:  []AdminNetwork{{ID: "N_2", Access: "read-only"}, {ID: "N_1", Access: "full"}},
+	}
+
+	// Unchanged
+	gock.New(client.BaseUrl).Get("/organizations/123/admins").Reply(200).BodyString(existing)
+	result, changed, err := client.EnsureAdmin("123", admin)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, "1", result.ID)
+
+	// Update
+	admin.OrgAccess = AdminAccessReadOnly
+	gock.New(client.BaseUrl).Get("/organizations/123/admins").Reply(200).BodyString(existing)
+	gock.New(client.BaseUrl).Put("/organizations/123/admins/1").
+		JSON(`{"name":"Jane","orgAccess":"read-only","tags":[],"networks":[{"id":"N_2","access":"read-only"},{"id":"N_1","access":"full"}]}`).
+		Reply(200).
+		BodyString(`{"id":"1","orgAccess":"read-only"}`)
+	result, changed, err = client.EnsureAdmin("123", admin)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, AdminAccessReadOnly, result.OrgAccess)
+
+	// Create
+	gock.New(client.BaseUrl).Get("/organizations/123/admins").Reply(200).BodyString(`[]`)
+	gock.New(client.BaseUrl).Post("/organizations/123/admins").
+		JSON(`{"name":"Jane","orgAccess":"read-only","email":"Jane@example.com","tags":[],"networks":[{"id":"N_2","access":"read-only"},{"id":"N_1","access":"full"}]}`).
+		Reply(201).
+		BodyString(`{"id":"2"}`)
+	result, changed, err = client.EnsureAdmin("123", admin)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, "2", result.ID)
+}
+
+// TestClientDeleteAdmin tests the Client::DeleteAdmin method.
+func TestClientDeleteAdmin(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Delete("/organizations/123/admins/1").Reply(204)
+	assert.NoError(t, client.DeleteAdmin("123", "1"))
+}
@@ -0,0 +1,236 @@
+package typed
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	meraki "github.com/netascode/go-meraki"
+)
+
+const (
+	AlertTypeApplianceDown      string = "applianceDown"
+	AlertTypeGatewayDown        string = "gatewayDown"
+	AlertTypeRepeaterDown       string = "repeaterDown"
+	AlertTypeSwitchDown         string = "switchDown"
+	AlertTypeCameraDown         string = "cameraDown"
+	AlertTypePortDown           string = "portDown"
+	AlertTypeUsageAlert         string = "usageAlert"
+	AlertTypeClientConnectivity string = "clientConnectivity"
+	AlertTypeSettingsChanged    string = "settingsChanged"
+	AlertTypeVpnConnectivity    string = "vpnConnectivityChange"
+	AlertTypeIPConflict         string = "ipConflict"
+	AlertTypeRogueAp            string = "rogueAp"
+	AlertTypeRogueDhcp          string = "rogueDhcp"
+	AlertTypeNewDhcpServer      string = "newDhcpServer"
+	AlertTypeDhcpNoLeases       string = "dhcpNoLeases"
+	AlertTypeFailoverEvent      string = "failoverEvent"
+	AlertTypeAmpMalwareDetected string = "ampMalwareDetected"
+	AlertTypeAmpMalwareBlocked  string = "ampMalwareBlocked"
+	AlertTypeUplinkIP6Conflict  string = "uplinkIp6Conflict"
+	AlertTypePowerSupplyDown    string = "powerSupplyDown"
+	AlertTypeUdldError          string = "udldError"
+	AlertTypeCellularUpDown     string = "cellularUpDown"
+	AlertTypeWeeklyPresence     string = "weeklyPresence"
+	AlertTypeWeeklyUmbrella     string = "weeklyUmbrella"
+)
+
+// Kinds of alert filter values.
+const (
+	alertFilterNumber  string = "number"
+	alertFilterString  string = "string"
+	alertFilterStrings string = "strings"
+)
+
+// alertFilterSchemas are the filters supported per alert type. Alert types without an entry
+// do not support any filters, alert types not listed in AlertTypes are not validated.
+var alertFilterSchemas = map[string]map[string]string{
+	AlertTypeApplianceDown:      {"timeout": alertFilterNumber},
+	AlertTypeGatewayDown:        {"timeout": alertFilterNumber},
+	AlertTypeRepeaterDown:       {"timeout": alertFilterNumber},
+	AlertTypeSwitchDown:         {"timeout": alertFilterNumber},
+	AlertTypeCameraDown:         {"timeout": alertFilterNumber},
+	AlertTypePortDown:           {"timeout": alertFilterNumber, "selector": alertFilterString},
+	AlertTypeUsageAlert:         {"period": alertFilterNumber, "threshold": alertFilterNumber},
+	AlertTypeClientConnectivity: {"clients": alertFilterStrings},
+}
+
+// AlertTypes returns the alert types validated by AlertSetting.Validate in alphabetical order.
+func AlertTypes() []string {
+	types := []string{
+		AlertTypeApplianceDown, AlertTypeGatewayDown, AlertTypeRepeaterDown, AlertTypeSwitchDown,
+		AlertTypeCameraDown, AlertTypePortDown, AlertTypeUsageAlert, AlertTypeClientConnectivity,
+		AlertTypeSettingsChanged, AlertTypeVpnConnectivity, AlertTypeIPConflict, AlertTypeRogueAp,
+		AlertTypeRogueDhcp, AlertTypeNewDhcpServer, AlertTypeDhcpNoLeases, AlertTypeFailoverEvent,
+		AlertTypeAmpMalwareDetected, AlertTypeAmpMalwareBlocked, AlertTypeUplinkIP6Conflict,
+		AlertTypePowerSupplyDown, AlertTypeUdldError, AlertTypeCellularUpDown, AlertTypeWeeklyPresence,
+		AlertTypeWeeklyUmbrella,
+	}
+	sort.Strings(types)
+	return types
+}
+
+// ErrInvalidAlertFilter is the error of alert filters not matching the schema of the alert type.
+var ErrInvalidAlertFilter = errors.New("invalid alert filter")
+
+// AlertDestinations are the recipients of alerts.
+type AlertDestinations struct {
+	Emails        []string `json:"emails"`
+	AllAdmins     bool     `json:"allAdmins"`
+	Snmp          bool     `json:"snmp"`
+	HttpServerIDs []string `json:"httpServerIds"`
+}
+
+// AlertSetting is the configuration of a single alert type of a network.
+type AlertSetting struct {
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+	// AlertDestinations are sent to the default destinations if nil
+	AlertDestinations *AlertDestinations     `json:"alertDestinations,omitempty"`
+	Filters           map[string]interface{} `json:"filters,omitempty"`
+}
+
+// AlertSettings are the alert settings of a network.
+type AlertSettings struct {
+	DefaultDestinations AlertDestinations `json:"defaultDestinations"`
+	Alerts              []AlertSetting    `json:"alerts"`
+}
+
+// Validate checks the filters of an alert setting against the schema of its alert type,
+// e.g. a timeout of a device down alert must be a positive number. Unknown alert types are
+// not validated.
+func (setting AlertSetting) Validate() error {
+	schema, known := alertFilterSchemas[setting.Type]
+	if !known {
+		if !slices.Contains(AlertTypes(), setting.Type) {
+			return nil
+		}
+		schema = map[string]string{}
+	}
+	keys := make([]string, 0, len(setting.Filters))
+	for key := range setting.Filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		kind, ok := schema[key]
+		if !ok {
+			return fmt.Errorf("%w: %s does not support filter '%s'", ErrInvalidAlertFilter, setting.Type, key)
+		}
+		if !validAlertFilter(kind, setting.Filters[key]) {
+			return fmt.Errorf("%w: filter '%s' of %s must be a %s", ErrInvalidAlertFilter, key, setting.Type, alertFilterKinds[kind])
+		}
+	}
+	return nil
+}
+
+var alertFilterKinds = map[string]string{
+	alertFilterNumber:  "positive number",
+	alertFilterString:  "string",
+	alertFilterStrings: "list of strings",
+}
+
+func validAlertFilter(kind string, value interface{}) bool {
+	switch kind {
+	case alertFilterNumber:
+		var f float64
+		switch v := value.(type) {
+		case int:
+			f = float64(v)
+		case int64:
+			f = float64(v)
+		case float64:
+			f = v
+		case json.Number:
+			var err error
+			if f, err = v.Float64(); err != nil {
+				return false
+			}
+		default:
+			return false
+		}
+		return f > 0
+	case alertFilterString:
+		_, ok := value.(string)
+		return ok
+	case alertFilterStrings:
+		switch v := value.(type) {
+		case []string:
+			return true
+		case []interface{}:
+			for _, e := range v {
+				if _, ok := e.(string); !ok {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// AlertSettings returns the alert settings of a network.
+func (client Client) AlertSettings(networkID string, mods ...func(*meraki.Req)) (AlertSettings, error) {
+	res, err := client.Get("/networks/"+networkID+"/alerts/settings", mods...)
+	if err != nil {
+		return AlertSettings{}, err
+	}
+	settings := AlertSettings{}
+	err = res.Unmarshal(&settings)
+	return settings, err
+}
+
+// ConfigureAlerts updates the given alert types of a network, other alert types are not changed.
+// The filters of all alerts are validated before sending the request, e.g.
+//
+//	err := client.ConfigureAlerts("N_123", []typed.AlertSetting{{
+//		Type:    typed.AlertTypeGatewayDown,
+//		Enabled: true,
+//		Filters: map[string]interface{}{"timeout": 10},
+//	}})
+func (client Client) ConfigureAlerts(networkID string, alerts []AlertSetting, mods ...func(*meraki.Req)) error {
+	for _, alert := range alerts {
+		if err := alert.Validate(); err != nil {
+			return err
+		}
+	}
+	raw, err := json.Marshal(alerts)
+	if err != nil {
+		return err
+	}
+	_, err = client.Put("/networks/"+networkID+"/alerts/settings", meraki.Body{}.SetRaw("alerts", string(raw)).Str, mods...)
+	return err
+}
+
+// EnableAlerts enables alert types of a network, sent to the default destinations.
+func (client Client) EnableAlerts(networkID string, alertTypes []string, mods ...func(*meraki.Req)) error {
+	return client.ConfigureAlerts(networkID, alertSettings(alertTypes, true), mods...)
+}
+
+// DisableAlerts disables alert types of a network.
+func (client Client) DisableAlerts(networkID string, alertTypes []string, mods ...func(*meraki.Req)) error {
+	return client.ConfigureAlerts(networkID, alertSettings(alertTypes, false), mods...)
+}
+
+// SetAlertDestinations sets the default alert recipients of a network, i.e. email addresses,
+// all administrators, SNMP and webhook HTTP servers.
+func (client Client) SetAlertDestinations(networkID string, destinations AlertDestinations, mods ...func(*meraki.Req)) error {
+	destinations.Emails = nonNil(destinations.Emails)
+	destinations.HttpServerIDs = nonNil(destinations.HttpServerIDs)
+	raw, err := json.Marshal(destinations)
+	if err != nil {
+		return err
+	}
+	_, err = client.Put("/networks/"+networkID+"/alerts/settings", meraki.Body{}.SetRaw("defaultDestinations", string(raw)).Str, mods...)
+	return err
+}
+
+func alertSettings(alertTypes []string, enabled bool) []AlertSetting {
+	alerts := make([]AlertSetting, 0, len(alertTypes))
+	for _, alertType := range alertTypes {
+		alerts = append(alerts, AlertSetting{Type: alertType, Enabled: enabled})
+	}
+	return alerts
+}
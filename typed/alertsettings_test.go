@@ -0,0 +1,68 @@
+package typed
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestAlertSettingValidate tests the AlertSetting::Validate method.
+func TestAlertSettingValidate(t *testing.T) {
+	valid := []AlertSetting{
+		{Type: AlertTypeGatewayDown, Filters: map[string]interface{}{"timeout": 5}},
+		{Type: AlertTypeUsageAlert, Filters: map[string]interface{}{"period": json.Number("1200"), "threshold": 104857600.0}},
+		{Type: AlertTypePortDown, Filters: map[string]interface{}{"timeout": 5, "selector": "any port"}},
+		{Type: AlertTypeClientConnectivity, Filters: map[string]interface{}{"clients": []interface{}{"aa:bb:cc:dd:ee:ff"}}},
+		{Type: AlertTypeSettingsChanged},
+		{Type: "unknownType", Filters: map[string]interface{}{"x": true}},
+	}
+	for _, setting := range valid {
+		assert.NoError(t, setting.Validate(), setting.Type)
+	}
+	invalid := []AlertSetting{
+		{Type: AlertTypeGatewayDown, Filters: map[string]interface{}{"timeout": "5"}},
+		{Type: AlertTypeGatewayDown, Filters: map[string]interface{}{"timeout": 0}},
+		{Type: AlertTypeGatewayDown, Filters: map[string]interface{}{"period": 5}},
+		{Type: AlertTypeClientConnectivity, Filters: map[string]interface{}{"clients": "aa:bb:cc:dd:ee:ff"}},
+		{Type: AlertTypeSettingsChanged, Filters: map[string]interface{}{"timeout": 5}},
+	}
+	for _, setting := range invalid {
+		assert.True(t, errors.Is(setting.Validate(), ErrInvalidAlertFilter), setting.Type)
+	}
+}
+
+// TestClientConfigureAlerts tests the Client::ConfigureAlerts, Client::EnableAlerts and Client::SetAlertDestinations methods.
+func TestClientConfigureAlerts(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Put("/networks/N_1/alerts/settings").
+		JSON(`{"alerts":[{"type":"gatewayDown","enabled":true,"filters":{"timeout":10}}]}`).
+		Reply(200)
+	err := client.ConfigureAlerts("N_1", []AlertSetting{{Type: AlertTypeGatewayDown, Enabled: true, Filters: map[string]interface{}{"timeout": 10}}})
+	assert.NoError(t, err)
+
+	err = client.ConfigureAlerts("N_1", []AlertSetting{{Type: AlertTypeGatewayDown, Filters: map[string]interface{}{"timeout": -1}}})
+	assert.ErrorIs(t, err, ErrInvalidAlertFilter)
+
+	gock.New(client.BaseUrl).Put("/networks/N_1/alerts/settings").
+		JSON(`{"alerts":[{"type":"rogueAp","enabled":false},{"type":"ipConflict","enabled":false}]}`).
+		Reply(200)
+	assert.NoError(t, client.DisableAlerts("N_1", []string{AlertTypeRogueAp, AlertTypeIPConflict}))
+
+	gock.New(client.BaseUrl).Put("/networks/N_1/alerts/settings").
+		JSON(`{"defaultDestinations":{"emails":["noc@example.com"],"allAdmins":false,"snmp":false,"httpServerIds":[]}}`).
+		Reply(200)
+	assert.NoError(t, client.SetAlertDestinations("N_1", AlertDestinations{Emails: []string{"noc@example.com"}}))
+	assert.True(t, gock.IsDone())
+
+	gock.New(client.BaseUrl).Get("/networks/N_1/alerts/settings").Reply(200).
+		BodyString(`{"defaultDestinations":{"emails":[],"allAdmins":true},"alerts":[{"type":"gatewayDown","enabled":true,"alertDestinations":{"emails":[]},"filters":{"timeout":60}}]}`)
+	settings, err := client.AlertSettings("N_1")
+	assert.NoError(t, err)
+	assert.True(t, settings.DefaultDestinations.AllAdmins)
+	assert.NoError(t, settings.Alerts[0].Validate())
+}
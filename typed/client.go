@@ -0,0 +1,23 @@
+// Package typed provides typed helpers for Meraki Dashboard API endpoints on top of the raw
+// client of github.com/netascode/go-meraki. It is a separate Go module, so consumers of the
+// raw client do not pull the typed layer into their builds.
+package typed
+
+import (
+	meraki "github.com/netascode/go-meraki"
+)
+
+// Client is a typed client wrapping a raw *meraki.Client. All methods of the raw client are
+// available as well, e.g.
+//
+//	raw, _ := meraki.NewClient("abc123")
+//	client := typed.New(&raw)
+//	admins, err := client.Admins("123")
+type Client struct {
+	*meraki.Client
+}
+
+// New creates a new typed client for a raw client.
+func New(client *meraki.Client) Client {
+	return Client{Client: client}
+}
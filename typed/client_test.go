@@ -0,0 +1,12 @@
+package typed
+
+import (
+	meraki "github.com/netascode/go-meraki"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func testClient() Client {
+	client, _ := meraki.NewClient("abc123", meraki.MaxRetries(0))
+	gock.InterceptClient(client.HttpClient)
+	return New(&client)
+}
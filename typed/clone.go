@@ -0,0 +1,240 @@
+package typed
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	meraki "github.com/netascode/go-meraki"
+	"github.com/tidwall/gjson"
+)
+
+// Resources cloned by CloneOrganization, in the order they are created.
+const (
+	CloneResourcePolicyObjects      string = "policyObjects"
+	CloneResourcePolicyObjectGroups string = "policyObjectGroups"
+	CloneResourceConfigTemplates    string = "configTemplates"
+	CloneResourceNetworks           string = "networks"
+	CloneResourceAdmins             string = "admins"
+)
+
+// cloneResources are all resources cloned by default, in dependency order.
+var cloneResources = []string{
+	CloneResourcePolicyObjects,
+	CloneResourcePolicyObjectGroups,
+	CloneResourceConfigTemplates,
+	CloneResourceNetworks,
+	CloneResourceAdmins,
+}
+
+// CloneStep is the creation of a single resource in the destination organization.
+type CloneStep struct {
+	// Resource is the kind of resource, e.g. CloneResourceNetworks
+	Resource string
+	// Name is the name of the resource, or the email address of an administrator
+	Name string
+	// SourceID is the ID of the resource in the source organization
+	SourceID string
+	// TargetID is the ID of the created resource, empty for dry runs and if the creation failed
+	TargetID string
+	// Err is the error of the step, nil if it succeeded
+	Err error
+	// source is the resource as returned by the API
+	source gjson.Result
+}
+
+// CloneReport is the result of CloneOrganization.
+type CloneReport struct {
+	// Steps are the planned or executed steps in execution order
+	Steps []CloneStep
+	// Mapping maps the IDs of the source organization to the IDs of the destination organization
+	Mapping map[string]string
+}
+
+// Err returns the errors of all failed steps joined together or nil if all steps succeeded.
+func (report CloneReport) Err() error {
+	var errs []error
+	for _, step := range report.Steps {
+		if step.Err != nil {
+			errs = append(errs, fmt.Errorf("%s %s (%s): %w", step.Resource, step.Name, step.SourceID, step.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CloneOptions modify the behavior of CloneOrganization.
+type CloneOptions struct {
+	// DryRun only plans the steps without creating any resources
+	DryRun bool
+	// Resources are the cloned resources, default is all resources
+	Resources []string
+	// OnStep is called after every executed step
+	OnStep func(step CloneStep, done, total int)
+	// Mods are the request modifiers of all requests
+	Mods []func(*meraki.Req)
+}
+
+// CloneDryRun only plans the steps of a clone without creating any resources.
+func CloneDryRun(options *CloneOptions) {
+	options.DryRun = true
+}
+
+// CloneOnly restricts a clone to the given resources, e.g. CloneResourceNetworks.
+func CloneOnly(resources ...string) func(*CloneOptions) {
+	return func(options *CloneOptions) {
+		options.Resources = append(options.Resources, resources...)
+	}
+}
+
+// CloneOnStep registers a callback called after every executed step of a clone.
+func CloneOnStep(fn func(step CloneStep, done, total int)) func(*CloneOptions) {
+	return func(options *CloneOptions) {
+		options.OnStep = fn
+	}
+}
+
+// CloneReqMods adds request modifiers to all requests of a clone.
+func CloneReqMods(mods ...func(*meraki.Req)) func(*CloneOptions) {
+	return func(options *CloneOptions) {
+		options.Mods = append(options.Mods, mods...)
+	}
+}
+
+// CloneOrganization replicates the structure of an organization into another organization,
+// i.e. its policy objects and groups, configuration templates, networks including their
+// template bindings and administrators with their network privileges. Device configuration,
+// claimed devices and licenses are not cloned, as the API does not allow copying them across
+// organizations.
+//
+// A failed step does not abort the clone, dependent steps fail instead. Existing
+// administrators of the destination organization are updated, see EnsureAdmin. Use
+// CloneDryRun to preview the steps, e.g.
+//
+//	plan, err := client.CloneOrganization("123", "456", typed.CloneDryRun)
+//	report, err := client.CloneOrganization("123", "456")
+//	newNetworkID := report.Mapping["N_1"]
+func (client Client) CloneOrganization(srcOrgID, dstOrgID string, mods ...func(*CloneOptions)) (CloneReport, error) {
+	options := CloneOptions{}
+	for _, mod := range mods {
+		mod(&options)
+	}
+	if len(options.Resources) == 0 {
+		options.Resources = cloneResources
+	}
+	report := CloneReport{Steps: make([]CloneStep, 0), Mapping: make(map[string]string)}
+	src := client.NewOrg(srcOrgID)
+	for _, resource := range cloneResources {
+		if !slices.Contains(options.Resources, resource) {
+			continue
+		}
+		res, err := src.Get(clonePaths[resource], options.Mods...)
+		if err != nil {
+			return report, err
+		}
+		for _, item := range res.Array() {
+			step := CloneStep{Resource: resource, Name: item.Get("name").String(), SourceID: item.Get("id").String(), source: item}
+			if resource == CloneResourceAdmins {
+				step.Name = item.Get("email").String()
+			}
+			report.Steps = append(report.Steps, step)
+		}
+	}
+	if options.DryRun {
+		return report, nil
+	}
+	for i := range report.Steps {
+		step := &report.Steps[i]
+		step.TargetID, step.Err = client.cloneStep(dstOrgID, *step, report.Mapping, options.Mods)
+		if step.TargetID != "" {
+			report.Mapping[step.SourceID] = step.TargetID
+		}
+		if options.OnStep != nil {
+			options.OnStep(*step, i+1, len(report.Steps))
+		}
+	}
+	return report, report.Err()
+}
+
+// clonePaths are the organization relative paths of the cloned resources.
+var clonePaths = map[string]string{
+	CloneResourcePolicyObjects:      "/policyObjects",
+	CloneResourcePolicyObjectGroups: "/policyObjects/groups",
+	CloneResourceConfigTemplates:    "/configTemplates",
+	CloneResourceNetworks:           "/networks",
+	CloneResourceAdmins:             "/admins",
+}
+
+// cloneStep creates a resource in the destination organization and returns its ID.
+func (client Client) cloneStep(dstOrgID string, step CloneStep, mapping map[string]string, mods []func(*meraki.Req)) (string, error) {
+	dst := client.NewOrg(dstOrgID)
+	src := step.source
+	var body meraki.Body
+	switch step.Resource {
+	case CloneResourcePolicyObjects:
+		body = copyKeys(src, "name", "category", "type", "cidr", "fqdn", "mask", "ip")
+	case CloneResourcePolicyObjectGroups:
+		body = copyKeys(src, "name", "category")
+		ids, err := mapIDs(src.Get("objectIds"), mapping)
+		if err != nil {
+			return "", err
+		}
+		body = body.Set("objectIds", ids)
+	case CloneResourceConfigTemplates:
+		body = copyKeys(src, "name", "timeZone")
+	case CloneResourceNetworks:
+		body = copyKeys(src, "name", "productTypes", "tags", "timeZone", "notes")
+	case CloneResourceAdmins:
+		admin := Admin{}
+		if err := (meraki.Res{Result: src}).Unmarshal(&admin); err != nil {
+			return "", err
+		}
+		networks := make([]AdminNetwork, 0, len(admin.Networks))
+		for _, network := range admin.Networks {
+			id, ok := mapping[network.ID]
+			if !ok {
+				return "", fmt.Errorf("network %s of administrator not cloned", network.ID)
+			}
+			networks = append(networks, AdminNetwork{ID: id, Access: network.Access})
+		}
+		admin.Networks = networks
+		created, _, err := client.EnsureAdmin(dstOrgID, admin, mods...)
+		return created.ID, err
+	}
+	res, err := dst.Post(clonePaths[step.Resource], body.Str, mods...)
+	if err != nil {
+		return "", err
+	}
+	id := res.Get("id").String()
+	if step.Resource == CloneResourceNetworks && src.Get("isBoundToConfigTemplate").Bool() {
+		templateID, ok := mapping[src.Get("configTemplateId").String()]
+		if !ok {
+			return id, fmt.Errorf("configuration template %s of network not cloned", src.Get("configTemplateId").String())
+		}
+		_, err = client.Post("/networks/"+id+"/bind", meraki.Body{}.Set("configTemplateId", templateID).Str, mods...)
+	}
+	return id, err
+}
+
+// copyKeys returns a body with the existing top-level keys of a JSON object.
+func copyKeys(src gjson.Result, keys ...string) meraki.Body {
+	body := meraki.Body{}
+	for _, key := range keys {
+		if value := src.Get(key); value.Exists() {
+			body = body.SetRaw(key, value.Raw)
+		}
+	}
+	return body
+}
+
+// mapIDs maps an array of source IDs to destination IDs.
+func mapIDs(ids gjson.Result, mapping map[string]string) ([]string, error) {
+	mapped := make([]string, 0)
+	for _, id := range ids.Array() {
+		target, ok := mapping[id.String()]
+		if !ok {
+			return nil, fmt.Errorf("%s not cloned", id.String())
+		}
+		mapped = append(mapped, target)
+	}
+	return mapped, nil
+}
@@ -0,0 +1,70 @@
+package typed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func mockCloneSource(client Client) {
+	gock.New(client.BaseUrl).Get("/organizations/1/policyObjects$").Reply(200).
+		BodyString(`[{"id":"P_1","name":"web","category":"network","type":"cidr","cidr":"10.0.0.0/24","groupIds":["G_1"]}]`)
+	gock.New(client.BaseUrl).Get("/organizations/1/policyObjects/groups").Reply(200).
+		BodyString(`[{"id":"G_1","name":"servers","category":"NetworkObjectGroup","objectIds":["P_1"]}]`)
+	gock.New(client.BaseUrl).Get("/organizations/1/configTemplates").Reply(200).
+		BodyString(`[{"id":"T_1","name":"branch","timeZone":"Europe/Zurich"}]`)
+	gock.New(client.BaseUrl).Get("/organizations/1/networks").Reply(200).
+		BodyString(`[{"id":"N_1","name":"hq","productTypes":["switch"],"tags":[],"timeZone":"Europe/Zurich","isBoundToConfigTemplate":true,"configTemplateId":"T_1"}]`)
+	gock.New(client.BaseUrl).Get("/organizations/1/admins").Reply(200).
+		BodyString(`[{"id":"A_1","name":"Jane","email":"jane@example.com","orgAccess":"none","tags":[],"networks":[{"id":"N_1","access":"full"}]}]`)
+}
+
+// TestClientCloneOrganization tests the Client::CloneOrganization method.
+func TestClientCloneOrganization(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	// Dry run
+	mockCloneSource(client)
+	plan, err := client.CloneOrganization("1", "2", CloneDryRun)
+	assert.NoError(t, err)
+	assert.Len(t, plan.Steps, 5)
+	assert.Equal(t, "jane@example.com", plan.Steps[4].Name)
+	assert.Empty(t, plan.Mapping)
+	assert.True(t, gock.IsDone())
+
+	// Clone
+	mockCloneSource(client)
+	gock.New(client.BaseUrl).Post("/organizations/2/policyObjects$").
+		JSON(`{"name":"web","category":"network","type":"cidr","cidr":"10.0.0.0/24"}`).Reply(201).BodyString(`{"id":"P_2"}`)
+	gock.New(client.BaseUrl).Post("/organizations/2/policyObjects/groups").
+		JSON(`{"name":"servers","category":"NetworkObjectGroup","objectIds":["P_2"]}`).Reply(201).BodyString(`{"id":"G_2"}`)
+	gock.New(client.BaseUrl).Post("/organizations/2/configTemplates").
+		JSON(`{"name":"branch","timeZone":"Europe/Zurich"}`).Reply(201).BodyString(`{"id":"T_2"}`)
+	gock.New(client.BaseUrl).Post("/organizations/2/networks").
+		JSON(`{"name":"hq","productTypes":["switch"],"tags":[],"timeZone":"Europe/Zurich"}`).Reply(201).BodyString(`{"id":"N_2"}`)
+	gock.New(client.BaseUrl).Post("/networks/N_2/bind").JSON(`{"configTemplateId":"T_2"}`).Reply(200)
+	gock.New(client.BaseUrl).Get("/organizations/2/admins").Reply(200).BodyString(`[]`)
+	gock.New(client.BaseUrl).Post("/organizations/2/admins").
+		JSON(`{"name":"Jane","orgAccess":"none","email":"jane@example.com","tags":[],"networks":[{"id":"N_2","access":"full"}]}`).
+		Reply(201).BodyString(`{"id":"A_2"}`)
+	steps := 0
+	report, err := client.CloneOrganization("1", "2", CloneOnStep(func(step CloneStep, done, total int) {
+		steps++
+		assert.Equal(t, 5, total)
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, 5, steps)
+	assert.Equal(t, map[string]string{"P_1": "P_2", "G_1": "G_2", "T_1": "T_2", "N_1": "N_2", "A_1": "A_2"}, report.Mapping)
+	assert.True(t, gock.IsDone())
+
+	// Failed dependencies
+	gock.New(client.BaseUrl).Get("/organizations/1/networks").Reply(200).
+		BodyString(`[{"id":"N_1","name":"hq","isBoundToConfigTemplate":true,"configTemplateId":"T_1"}]`)
+	gock.New(client.BaseUrl).Post("/organizations/2/networks").Reply(201).BodyString(`{"id":"N_2"}`)
+	report, err = client.CloneOrganization("1", "2", CloneOnly(CloneResourceNetworks))
+	assert.Error(t, err)
+	assert.Equal(t, "N_2", report.Steps[0].TargetID)
+	assert.Error(t, report.Steps[0].Err)
+}
@@ -0,0 +1,23 @@
+module github.com/netascode/go-meraki/typed
+
+go 1.22
+
+require (
+	github.com/netascode/go-meraki v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.9.0
+	github.com/tidwall/gjson v1.17.3
+	gopkg.in/h2non/gock.v1 v1.1.2
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/h2non/parth v0.0.0-20190131123155-b4df798d6542 // indirect
+	github.com/juju/ratelimit v1.0.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/tidwall/match v1.1.1 // indirect
+	github.com/tidwall/pretty v1.2.1 // indirect
+	github.com/tidwall/sjson v1.2.5 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/netascode/go-meraki => ../
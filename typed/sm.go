@@ -0,0 +1,127 @@
+package typed
+
+import (
+	"errors"
+	"fmt"
+
+	meraki "github.com/netascode/go-meraki"
+)
+
+// SmBatchSize is the maximum number of devices per Systems Manager bulk request.
+const SmBatchSize int = 100
+
+const (
+	SmTagsAdd    string = "add"
+	SmTagsDelete string = "delete"
+	SmTagsUpdate string = "update"
+)
+
+// ErrSmDeviceNotAffected is the error of devices missing in the response of a Systems Manager command.
+var ErrSmDeviceNotAffected = errors.New("device not affected by command")
+
+// SmDeviceResult is the result of a Systems Manager command for a single device.
+type SmDeviceResult struct {
+	// ID is the Systems Manager device ID
+	ID string
+	// Err is the error of the request or ErrSmDeviceNotAffected, nil if the command succeeded
+	Err error
+}
+
+// SmDeviceResults is a list of SmDeviceResult in the order of the device IDs.
+type SmDeviceResults []SmDeviceResult
+
+// Err returns all errors joined together or nil if the command succeeded for all devices.
+func (results SmDeviceResults) Err() error {
+	var errs []error
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SmCheckinDevices forces a check-in of Systems Manager devices.
+//
+// The Systems Manager helpers address devices by ID only. Unlike the API, where an
+// empty selector with a scope may target all devices of a network, an empty list of
+// IDs sends no request. IDs are sent in batches of SmBatchSize and devices missing
+// in the response are reported with ErrSmDeviceNotAffected.
+func (client Client) SmCheckinDevices(networkID string, ids []string, mods ...func(*meraki.Req)) SmDeviceResults {
+	return client.smBulk(networkID, "checkin", ids, meraki.Body{}, mods...)
+}
+
+// SmLockDevices locks Systems Manager devices. A pin of 0 omits the pin, which is only used by macOS devices.
+func (client Client) SmLockDevices(networkID string, ids []string, pin int, mods ...func(*meraki.Req)) SmDeviceResults {
+	body := meraki.Body{}
+	if pin != 0 {
+		body = body.Set("pin", pin)
+	}
+	return client.smBulk(networkID, "lock", ids, body, mods...)
+}
+
+// SmWipeDevices wipes Systems Manager devices. A pin of 0 omits the pin, which is only used by macOS devices.
+// The wipe endpoint accepts a single device, therefore one request per device is sent.
+func (client Client) SmWipeDevices(networkID string, ids []string, pin int, mods ...func(*meraki.Req)) SmDeviceResults {
+	results := make(SmDeviceResults, 0, len(ids))
+	for _, id := range ids {
+		body := meraki.Body{}.Set("id", id)
+		if pin != 0 {
+			body = body.Set("pin", pin)
+		}
+		res, err := client.Post("/networks/"+networkID+"/sm/devices/wipe", body.Str, mods...)
+		if err == nil && res.Get("id").Exists() && res.Get("id").String() != id {
+			err = fmt.Errorf("%w: %s", ErrSmDeviceNotAffected, id)
+		}
+		results = append(results, SmDeviceResult{ID: id, Err: err})
+	}
+	return results
+}
+
+// SmMoveDevices moves Systems Manager devices to another network.
+func (client Client) SmMoveDevices(networkID, newNetworkID string, ids []string, mods ...func(*meraki.Req)) SmDeviceResults {
+	return client.smBulk(networkID, "move", ids, meraki.Body{}.Set("newNetwork", newNetworkID), mods...)
+}
+
+// SmModifyDeviceTags adds, deletes or replaces tags of Systems Manager devices, where
+// action is one of SmTagsAdd, SmTagsDelete or SmTagsUpdate.
+func (client Client) SmModifyDeviceTags(networkID string, ids []string, action string, tags []string, mods ...func(*meraki.Req)) SmDeviceResults {
+	body := meraki.Body{}.Set("tags", nonNil(tags)).Set("updateAction", action)
+	return client.smBulk(networkID, "modifyTags", ids, body, mods...)
+}
+
+// smBulk sends a Systems Manager command in batches of SmBatchSize device IDs and
+// reports the result of every device.
+func (client Client) smBulk(networkID, command string, ids []string, body meraki.Body, mods ...func(*meraki.Req)) SmDeviceResults {
+	results := make(SmDeviceResults, 0, len(ids))
+	for start := 0; start < len(ids); start += SmBatchSize {
+		batch := ids[start:min(start+SmBatchSize, len(ids))]
+		res, err := client.Post("/networks/"+networkID+"/sm/devices/"+command, body.Set("ids", batch).Str, mods...)
+		affected := smAffectedIDs(res)
+		for _, id := range batch {
+			result := SmDeviceResult{ID: id, Err: err}
+			if err == nil && affected != nil && !affected[id] {
+				result.Err = fmt.Errorf("%w: %s", ErrSmDeviceNotAffected, id)
+			}
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// smAffectedIDs returns the IDs of the devices affected by a command, which are either
+// returned as list of IDs or as list of devices. It returns nil if the response has no IDs.
+func smAffectedIDs(res meraki.Res) map[string]bool {
+	ids := res.Get("ids")
+	if res.IsArray() {
+		ids = res.Get("#.id")
+	}
+	if !ids.IsArray() {
+		return nil
+	}
+	affected := make(map[string]bool)
+	for _, id := range ids.Array() {
+		affected[id.String()] = true
+	}
+	return affected
+}
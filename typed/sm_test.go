@@ -0,0 +1,80 @@
+package typed
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestClientSmCheckinDevices tests the Client::SmCheckinDevices method.
+func TestClientSmCheckinDevices(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Post("/networks/N_1/sm/devices/checkin").JSON(`{"ids":["1","2"]}`).Reply(200).BodyString(`{"ids":["1"]}`)
+	results := client.SmCheckinDevices("N_1", []string{"1", "2"})
+	assert.Len(t, results, 2)
+	assert.NoError(t, results[0].Err)
+	assert.True(t, errors.Is(results[1].Err, ErrSmDeviceNotAffected))
+	assert.Error(t, results.Err())
+
+	// No IDs sends no request
+	results = client.SmCheckinDevices("N_1", nil)
+	assert.Len(t, results, 0)
+	assert.NoError(t, results.Err())
+	assert.True(t, gock.IsDone())
+}
+
+// TestClientSmLockDevices tests the batching of the Client::SmLockDevices method.
+func TestClientSmLockDevices(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	ids := make([]string, SmBatchSize+1)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i)
+	}
+	gock.New(client.BaseUrl).Post("/networks/N_1/sm/devices/lock").Reply(200).BodyString(`{}`)
+	gock.New(client.BaseUrl).Post("/networks/N_1/sm/devices/lock").JSON(`{"pin":123456,"ids":["100"]}`).Reply(400).BodyString(`{"errors":["Invalid pin"]}`)
+	results := client.SmLockDevices("N_1", ids, 123456)
+	assert.Len(t, results, SmBatchSize+1)
+	assert.NoError(t, results[0].Err)
+	assert.Error(t, results[SmBatchSize].Err)
+	assert.True(t, gock.IsDone())
+}
+
+// TestClientSmWipeDevices tests the Client::SmWipeDevices method.
+func TestClientSmWipeDevices(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Post("/networks/N_1/sm/devices/wipe").JSON(`{"id":"1"}`).Reply(200).BodyString(`{"id":"1"}`)
+	gock.New(client.BaseUrl).Post("/networks/N_1/sm/devices/wipe").JSON(`{"id":"2"}`).Reply(200).BodyString(`{"id":"2"}`)
+	results := client.SmWipeDevices("N_1", []string{"1", "2"}, 0)
+	assert.NoError(t, results.Err())
+	assert.True(t, gock.IsDone())
+}
+
+// TestClientSmModifyDeviceTags tests the Client::SmModifyDeviceTags and Client::SmMoveDevices methods.
+func TestClientSmModifyDeviceTags(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Post("/networks/N_1/sm/devices/modifyTags").
+		JSON(`{"tags":["a"],"updateAction":"add","ids":["1"]}`).
+		Reply(200).
+		BodyString(`[{"id":"1","tags":["a"]}]`)
+	results := client.SmModifyDeviceTags("N_1", []string{"1"}, SmTagsAdd, []string{"a"})
+	assert.NoError(t, results.Err())
+
+	gock.New(client.BaseUrl).Post("/networks/N_1/sm/devices/move").
+		JSON(`{"newNetwork":"N_2","ids":["1"]}`).
+		Reply(200).
+		BodyString(`{"ids":["1"],"newNetwork":"N_2"}`)
+	results = client.SmMoveDevices("N_1", "N_2", []string{"1"})
+	assert.NoError(t, results.Err())
+	assert.True(t, gock.IsDone())
+}
@@ -0,0 +1,75 @@
+package typed
+
+import (
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+
+	meraki "github.com/netascode/go-meraki"
+)
+
+// SplashAsset is an asset of a splash page theme, e.g. an image or a stylesheet.
+type SplashAsset struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// SplashTheme is a custom splash page theme of an organization.
+type SplashTheme struct {
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	ThemeAssets []SplashAsset `json:"themeAssets"`
+}
+
+// SplashThemes returns the splash page themes of an organization.
+func (client Client) SplashThemes(orgID string, mods ...func(*meraki.Req)) ([]SplashTheme, error) {
+	res, err := client.NewOrg(orgID).Get("/splash/themes", mods...)
+	if err != nil {
+		return nil, err
+	}
+	themes := make([]SplashTheme, 0)
+	err = res.Unmarshal(&themes)
+	return themes, err
+}
+
+// UploadSplashAsset uploads the content of r as asset of a splash page theme and returns
+// the created asset. The API expects the file content base64 encoded within a JSON body,
+// which is taken care of, e.g.
+//
+//	f, _ := os.Open("logo.png")
+//	asset, err := client.UploadSplashAsset("123", "abc", "logo.png", f)
+func (client Client) UploadSplashAsset(orgID, themeID, name string, r io.Reader, mods ...func(*meraki.Req)) (SplashAsset, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return SplashAsset{}, err
+	}
+	body := meraki.Body{}.
+		Set("name", name).
+		Set("content", base64.StdEncoding.EncodeToString(content))
+	// do not log the encoded file content
+	mods = append([]func(*meraki.Req){meraki.NoLogPayload}, mods...)
+	res, err := client.NewOrg(orgID).Post("/splash/themes/"+themeID+"/assets", body.Str, mods...)
+	if err != nil {
+		return SplashAsset{}, err
+	}
+	asset := SplashAsset{}
+	err = res.Unmarshal(&asset)
+	return asset, err
+}
+
+// UploadSplashAssetFile uploads a file as asset of a splash page theme, using the file name as asset name.
+func (client Client) UploadSplashAssetFile(orgID, themeID, path string, mods ...func(*meraki.Req)) (SplashAsset, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return SplashAsset{}, err
+	}
+	defer f.Close()
+	return client.UploadSplashAsset(orgID, themeID, filepath.Base(path), f, mods...)
+}
+
+// DeleteSplashAsset deletes an asset of a splash page theme.
+func (client Client) DeleteSplashAsset(orgID, assetID string, mods ...func(*meraki.Req)) error {
+	_, err := client.NewOrg(orgID).Delete("/splash/assets/"+assetID, mods...)
+	return err
+}
@@ -0,0 +1,56 @@
+package typed
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestClientUploadSplashAsset tests the Client::UploadSplashAsset and Client::UploadSplashAssetFile methods.
+func TestClientUploadSplashAsset(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Post("/organizations/123/splash/themes/abc/assets").
+		JSON(`{"name":"style.css","content":"Ym9keSB7fQ=="}`).
+		Reply(201).
+		BodyString(`{"id":"1","name":"style.css"}`)
+	asset, err := client.UploadSplashAsset("123", "abc", "style.css", strings.NewReader("body {}"))
+	assert.NoError(t, err)
+	assert.Equal(t, SplashAsset{ID: "1", Name: "style.css"}, asset)
+
+	path := filepath.Join(t.TempDir(), "logo.png")
+	assert.NoError(t, os.WriteFile(path, []byte("png"), 0o600))
+	gock.New(client.BaseUrl).Post("/organizations/123/splash/themes/abc/assets").
+		JSON(`{"name":"logo.png","content":"cG5n"}`).
+		Reply(201).
+		BodyString(`{"id":"2","name":"logo.png"}`)
+	asset, err = client.UploadSplashAssetFile("123", "abc", path)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", asset.ID)
+
+	_, err = client.UploadSplashAssetFile("123", "abc", filepath.Join(t.TempDir(), "missing.png"))
+	assert.Error(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+// TestClientSplashThemes tests the Client::SplashThemes and Client::DeleteSplashAsset methods.
+func TestClientSplashThemes(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Get("/organizations/123/splash/themes").
+		Reply(200).
+		BodyString(`[{"id":"abc","name":"Theme","themeAssets":[{"id":"1","name":"style.css"}]}]`)
+	themes, err := client.SplashThemes("123")
+	assert.NoError(t, err)
+	assert.Equal(t, "style.css", themes[0].ThemeAssets[0].Name)
+
+	gock.New(client.BaseUrl).Delete("/organizations/123/splash/assets/1").Reply(204)
+	assert.NoError(t, client.DeleteSplashAsset("123", "1"))
+	assert.True(t, gock.IsDone())
+}
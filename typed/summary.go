@@ -0,0 +1,96 @@
+package typed
+
+import (
+	"time"
+
+	meraki "github.com/netascode/go-meraki"
+)
+
+// SummaryMaxTimespan is the maximum timespan of the organization summary endpoints.
+const SummaryMaxTimespan time.Duration = 31 * 24 * time.Hour
+
+// SummaryNetwork is the network of a summary entry.
+type SummaryNetwork struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// SummaryUsage is the data usage of a summary entry in megabytes.
+type SummaryUsage struct {
+	Sent       float64 `json:"sent"`
+	Recv       float64 `json:"recv"`
+	Total      float64 `json:"total"`
+	Percentage float64 `json:"percentage"`
+}
+
+// TopClient is an entry of the top clients by usage summary.
+type TopClient struct {
+	ID      string         `json:"id"`
+	Name    string         `json:"name"`
+	Mac     string         `json:"mac"`
+	Network SummaryNetwork `json:"network"`
+	Usage   SummaryUsage   `json:"usage"`
+}
+
+// TopDevice is an entry of the top devices by usage summary.
+type TopDevice struct {
+	Name        string         `json:"name"`
+	Model       string         `json:"model"`
+	Serial      string         `json:"serial"`
+	Mac         string         `json:"mac"`
+	ProductType string         `json:"productType"`
+	Network     SummaryNetwork `json:"network"`
+	Usage       SummaryUsage   `json:"usage"`
+	Clients     struct {
+		Counts struct {
+			Total int `json:"total"`
+		} `json:"counts"`
+	} `json:"clients"`
+}
+
+// TopAppliance is an entry of the top appliances by utilization summary.
+type TopAppliance struct {
+	Name        string         `json:"name"`
+	Model       string         `json:"model"`
+	Serial      string         `json:"serial"`
+	Mac         string         `json:"mac"`
+	Network     SummaryNetwork `json:"network"`
+	Utilization struct {
+		Average struct {
+			Percentage float64 `json:"percentage"`
+		} `json:"average"`
+	} `json:"utilization"`
+}
+
+// TopClientsByUsage returns the top clients by data usage of an organization.
+func (client Client) TopClientsByUsage(orgID string, ts meraki.Timespan, mods ...func(*meraki.Req)) ([]TopClient, error) {
+	result := make([]TopClient, 0)
+	err := client.summary(orgID, "/summary/top/clients/byUsage", ts, &result, mods...)
+	return result, err
+}
+
+// TopDevicesByUsage returns the top devices by data usage of an organization.
+func (client Client) TopDevicesByUsage(orgID string, ts meraki.Timespan, mods ...func(*meraki.Req)) ([]TopDevice, error) {
+	result := make([]TopDevice, 0)
+	err := client.summary(orgID, "/summary/top/devices/byUsage", ts, &result, mods...)
+	return result, err
+}
+
+// TopAppliancesByUtilization returns the top appliances by utilization of an organization.
+func (client Client) TopAppliancesByUtilization(orgID string, ts meraki.Timespan, mods ...func(*meraki.Req)) ([]TopAppliance, error) {
+	result := make([]TopAppliance, 0)
+	err := client.summary(orgID, "/summary/top/appliances/byUtilization", ts, &result, mods...)
+	return result, err
+}
+
+// summary fetches an organization summary endpoint and unmarshals the result into v.
+func (client Client) summary(orgID, path string, ts meraki.Timespan, v interface{}, mods ...func(*meraki.Req)) error {
+	if err := ts.Validate(SummaryMaxTimespan); err != nil {
+		return err
+	}
+	res, err := client.NewOrg(orgID).Get(path, append([]func(*meraki.Req){ts.Req()}, mods...)...)
+	if err != nil {
+		return err
+	}
+	return res.Unmarshal(v)
+}
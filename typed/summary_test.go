@@ -0,0 +1,52 @@
+package typed
+
+import (
+	"testing"
+	"time"
+
+	meraki "github.com/netascode/go-meraki"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestClientTopClientsByUsage tests the Client::TopClientsByUsage method.
+func TestClientTopClientsByUsage(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Get("/organizations/123/summary/top/clients/byUsage").
+		MatchParam("timespan", "3600").
+		Reply(200).
+		BodyString(`[{"id":"k1","name":"laptop","network":{"id":"N_1"},"usage":{"total":12.5}}]`)
+
+	clients, err := client.TopClientsByUsage("123", meraki.LastTimespan(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, "laptop", clients[0].Name)
+	assert.Equal(t, "N_1", clients[0].Network.ID)
+	assert.Equal(t, 12.5, clients[0].Usage.Total)
+
+	// Timespan too long
+	_, err = client.TopClientsByUsage("123", meraki.LastTimespan(60*24*time.Hour))
+	assert.Error(t, err)
+}
+
+// TestClientTopDevicesByUsage tests the Client::TopDevicesByUsage and Client::TopAppliancesByUtilization methods.
+func TestClientTopDevicesByUsage(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Get("/organizations/123/summary/top/devices/byUsage").
+		Reply(200).
+		BodyString(`[{"serial":"Q-1","clients":{"counts":{"total":5}}}]`)
+	gock.New(client.BaseUrl).Get("/organizations/123/summary/top/appliances/byUtilization").
+		Reply(200).
+		BodyString(`[{"serial":"Q-2","utilization":{"average":{"percentage":40.5}}}]`)
+
+	devices, err := client.TopDevicesByUsage("123", meraki.Timespan{})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, devices[0].Clients.Counts.Total)
+
+	appliances, err := client.TopAppliancesByUtilization("123", meraki.Timespan{})
+	assert.NoError(t, err)
+	assert.Equal(t, 40.5, appliances[0].Utilization.Average.Percentage)
+}
@@ -0,0 +1,301 @@
+package typed
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	meraki "github.com/netascode/go-meraki"
+	"github.com/tidwall/gjson"
+)
+
+// TopologyNode is a node of a topology graph, i.e. a device or a discovered neighbor.
+type TopologyNode struct {
+	// ID is the unique ID of the node, the serial for Meraki devices or the derived ID otherwise
+	ID string
+	// Serial is the serial number, empty for non-Meraki devices
+	Serial string
+	// Name is the name of the device or the discovered system name
+	Name string
+	// Model is the device model
+	Model string
+	// Mac is the MAC address
+	Mac string
+	// NetworkID is the ID of the network the node was discovered in
+	NetworkID string
+}
+
+// TopologyLink is a link between two nodes of a topology graph.
+type TopologyLink struct {
+	// From is the ID of the first node
+	From string
+	// FromPort is the port of the first node
+	FromPort string
+	// To is the ID of the second node
+	To string
+	// ToPort is the port of the second node
+	ToPort string
+	// Protocol is the discovery protocol, e.g. "lldp" or "cdp"
+	Protocol string
+}
+
+// key returns a direction independent identifier of the link.
+func (link TopologyLink) key() string {
+	a, b := link.From+"|"+link.FromPort, link.To+"|"+link.ToPort
+	if a > b {
+		a, b = b, a
+	}
+	return a + "|" + b
+}
+
+// Topology is a graph of devices and their links.
+type Topology struct {
+	// Nodes is the list of nodes sorted by ID
+	Nodes []TopologyNode
+	// Links is the list of links
+	Links []TopologyLink
+}
+
+// Node returns the node with the given ID.
+func (topology Topology) Node(id string) (TopologyNode, bool) {
+	for _, node := range topology.Nodes {
+		if node.ID == id {
+			return node, true
+		}
+	}
+	return TopologyNode{}, false
+}
+
+// Merge returns a topology containing the nodes and links of both topologies.
+// Duplicate nodes and links are removed.
+func (topology Topology) Merge(other Topology) Topology {
+	b := newTopologyBuilder()
+	for _, t := range []Topology{topology, other} {
+		for _, node := range t.Nodes {
+			b.addNode(node)
+		}
+		for _, link := range t.Links {
+			b.addLink(link)
+		}
+	}
+	return b.topology()
+}
+
+// DOT returns the topology in Graphviz DOT format.
+func (topology Topology) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("graph topology {\n")
+	for _, node := range topology.Nodes {
+		label := node.Name
+		if label == "" {
+			label = node.ID
+		}
+		if node.Model != "" {
+			label += "\\n" + node.Model
+		}
+		fmt.Fprintf(&sb, "  %q [label=%q];\n", node.ID, label)
+	}
+	for _, link := range topology.Links {
+		fmt.Fprintf(&sb, "  %q -- %q [taillabel=%q, headlabel=%q];\n", link.From, link.To, link.FromPort, link.ToPort)
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// NetworkTopology returns the link layer topology of a network.
+func (client Client) NetworkTopology(networkID string, mods ...func(*meraki.Req)) (Topology, error) {
+	res, err := client.Get("/networks/"+networkID+"/topology/linkLayer", mods...)
+	if err != nil {
+		return Topology{}, err
+	}
+	b := newTopologyBuilder()
+	ids := make(map[string]string)
+	for _, n := range res.Get("nodes").Array() {
+		node := topologyNode(n, networkID)
+		ids[n.Get("derivedId").String()] = node.ID
+		b.addNode(node)
+	}
+	for _, l := range res.Get("links").Array() {
+		ends := l.Get("ends").Array()
+		if len(ends) != 2 {
+			continue
+		}
+		link := TopologyLink{}
+		for i, end := range ends {
+			id := ids[end.Get("node.derivedId").String()]
+			if id == "" {
+				id = topologyNode(end, networkID).ID
+			}
+			port, protocol := discoveredPort(end.Get("discovered"))
+			if i == 0 {
+				link.From, link.FromPort = id, port
+			} else {
+				link.To, link.ToPort = id, port
+			}
+			if protocol != "" {
+				link.Protocol = protocol
+			}
+		}
+		b.addLink(link)
+	}
+	return b.topology(), nil
+}
+
+// NetworkNeighborTopology returns the topology of a network based on the LLDP and CDP
+// neighbors reported by each device of the network.
+func (client Client) NetworkNeighborTopology(networkID string, mods ...func(*meraki.Req)) (Topology, error) {
+	devices, err := client.Get("/networks/"+networkID+"/devices", mods...)
+	if err != nil {
+		return Topology{}, err
+	}
+	b := newTopologyBuilder()
+	macs := make(map[string]string)
+	for _, d := range devices.Array() {
+		node := topologyNode(d, networkID)
+		b.addNode(node)
+		if node.Mac != "" {
+			macs[normalizeMac(node.Mac)] = node.ID
+		}
+	}
+	for _, d := range devices.Array() {
+		serial := d.Get("serial").String()
+		res, err := client.Get("/devices/"+serial+"/lldpCdp", mods...)
+		if err != nil {
+			return Topology{}, err
+		}
+		res.Get("ports").ForEach(func(port, neighbors gjson.Result) bool {
+			for _, protocol := range []string{"lldp", "cdp"} {
+				n := neighbors.Get(protocol)
+				if !n.Exists() {
+					continue
+				}
+				name := n.Get("systemName").String()
+				if name == "" {
+					name = n.Get("deviceId").String()
+				}
+				mac := n.Get("chassisId").String()
+				if mac == "" {
+					mac = n.Get("deviceId").String()
+				}
+				id, ok := macs[normalizeMac(mac)]
+				if !ok {
+					id = name
+					b.addNode(TopologyNode{ID: id, Name: name, NetworkID: networkID})
+				}
+				b.addLink(TopologyLink{
+					From:     serial,
+					FromPort: port.String(),
+					To:       id,
+					ToPort:   n.Get("portId").String(),
+					Protocol: protocol,
+				})
+				break
+			}
+			return true
+		})
+	}
+	return b.topology(), nil
+}
+
+// OrgTopology returns the merged link layer topology of all networks of an organization.
+func (client Client) OrgTopology(orgID string, mods ...func(*meraki.Req)) (Topology, error) {
+	networks, err := client.Get("/organizations/"+orgID+"/networks", mods...)
+	if err != nil {
+		return Topology{}, err
+	}
+	topology := Topology{}
+	for _, network := range networks.Array() {
+		t, err := client.NetworkTopology(network.Get("id").String(), mods...)
+		if err != nil {
+			return Topology{}, err
+		}
+		topology = topology.Merge(t)
+	}
+	return topology, nil
+}
+
+// topologyNode creates a node from a device or link layer node object.
+func topologyNode(n gjson.Result, networkID string) TopologyNode {
+	device := n
+	if n.Get("device").Exists() {
+		device = n.Get("device")
+	}
+	node := TopologyNode{
+		Serial:    device.Get("serial").String(),
+		Name:      device.Get("name").String(),
+		Model:     device.Get("model").String(),
+		Mac:       n.Get("mac").String(),
+		NetworkID: networkID,
+	}
+	if node.Mac == "" {
+		node.Mac = device.Get("mac").String()
+	}
+	node.ID = node.Serial
+	if node.ID == "" {
+		node.ID = n.Get("derivedId").String()
+	}
+	if node.ID == "" {
+		node.ID = n.Get("node.derivedId").String()
+	}
+	if node.Name == "" {
+		node.Name = n.Get("discovered.lldp.systemName").String()
+	}
+	if node.Name == "" {
+		node.Name = n.Get("discovered.cdp.deviceId").String()
+	}
+	return node
+}
+
+// discoveredPort returns the port and protocol of a discovered link end.
+func discoveredPort(discovered gjson.Result) (string, string) {
+	for _, protocol := range []string{"lldp", "cdp"} {
+		if port := discovered.Get(protocol + ".portId").String(); port != "" {
+			return port, protocol
+		}
+	}
+	return "", ""
+}
+
+func normalizeMac(mac string) string {
+	return strings.ToLower(strings.NewReplacer(":", "", "-", "", ".", "").Replace(mac))
+}
+
+type topologyBuilder struct {
+	nodes map[string]TopologyNode
+	links []TopologyLink
+	seen  map[string]bool
+}
+
+func newTopologyBuilder() *topologyBuilder {
+	return &topologyBuilder{nodes: make(map[string]TopologyNode), seen: make(map[string]bool)}
+}
+
+func (b *topologyBuilder) addNode(node TopologyNode) {
+	if node.ID == "" {
+		return
+	}
+	if existing, ok := b.nodes[node.ID]; ok && existing.Serial != "" && node.Serial == "" {
+		return
+	}
+	b.nodes[node.ID] = node
+}
+
+func (b *topologyBuilder) addLink(link TopologyLink) {
+	if link.From == "" || link.To == "" || b.seen[link.key()] {
+		return
+	}
+	b.seen[link.key()] = true
+	b.links = append(b.links, link)
+}
+
+func (b *topologyBuilder) topology() Topology {
+	topology := Topology{Nodes: make([]TopologyNode, 0, len(b.nodes)), Links: b.links}
+	for _, node := range b.nodes {
+		topology.Nodes = append(topology.Nodes, node)
+	}
+	sort.Slice(topology.Nodes, func(i, j int) bool { return topology.Nodes[i].ID < topology.Nodes[j].ID })
+	if topology.Links == nil {
+		topology.Links = make([]TopologyLink, 0)
+	}
+	return topology
+}
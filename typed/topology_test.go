@@ -0,0 +1,66 @@
+package typed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestClientNetworkTopology tests the Client::NetworkTopology method.
+func TestClientNetworkTopology(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Get("/networks/N_1/topology/linkLayer").
+		Reply(200).
+		BodyString(`{
+			"nodes": [
+				{"derivedId": "1", "mac": "aa:aa", "device": {"serial": "Q-A", "name": "sw1", "model": "MS120"}},
+				{"derivedId": "2", "mac": "bb:bb", "discovered": {"lldp": {"systemName": "core"}}}
+			],
+			"links": [
+				{"ends": [
+					{"node": {"derivedId": "1"}, "discovered": {"lldp": {"portId": "1"}}},
+					{"node": {"derivedId": "2"}, "discovered": {"lldp": {"portId": "Gi1/0/1"}}}
+				]},
+				{"ends": [
+					{"node": {"derivedId": "2"}, "discovered": {"lldp": {"portId": "Gi1/0/1"}}},
+					{"node": {"derivedId": "1"}, "discovered": {"lldp": {"portId": "1"}}}
+				]}
+			]
+		}`)
+
+	topology, err := client.NetworkTopology("N_1")
+	assert.NoError(t, err)
+	assert.Len(t, topology.Nodes, 2)
+	assert.Equal(t, []TopologyLink{{From: "Q-A", FromPort: "1", To: "2", ToPort: "Gi1/0/1", Protocol: "lldp"}}, topology.Links)
+	node, ok := topology.Node("2")
+	assert.True(t, ok)
+	assert.Equal(t, "core", node.Name)
+	assert.Contains(t, topology.DOT(), `"Q-A" -- "2" [taillabel="1", headlabel="Gi1/0/1"];`)
+}
+
+// TestClientNetworkNeighborTopology tests the Client::NetworkNeighborTopology method.
+func TestClientNetworkNeighborTopology(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Get("/networks/N_1/devices").
+		Reply(200).
+		BodyString(`[{"serial":"Q-A","name":"sw1","mac":"aa:aa:aa:aa:aa:aa"},{"serial":"Q-B","name":"sw2","mac":"bb:bb:bb:bb:bb:bb"}]`)
+	gock.New(client.BaseUrl).Get("/devices/Q-A/lldpCdp").
+		Reply(200).
+		BodyString(`{"ports":{"1":{"lldp":{"systemName":"sw2","chassisId":"BB:BB:BB:BB:BB:BB","portId":"2"}},"2":{"cdp":{"deviceId":"router","portId":"Gi0/1"}}}}`)
+	gock.New(client.BaseUrl).Get("/devices/Q-B/lldpCdp").
+		Reply(200).
+		BodyString(`{"ports":{"2":{"lldp":{"systemName":"sw1","chassisId":"aa:aa:aa:aa:aa:aa","portId":"1"}}}}`)
+
+	topology, err := client.NetworkNeighborTopology("N_1")
+	assert.NoError(t, err)
+	assert.Len(t, topology.Nodes, 3)
+	assert.Equal(t, []TopologyLink{
+		{From: "Q-A", FromPort: "1", To: "Q-B", ToPort: "2", Protocol: "lldp"},
+		{From: "Q-A", FromPort: "2", To: "router", ToPort: "Gi0/1", Protocol: "cdp"},
+	}, topology.Links)
+}
@@ -0,0 +1,175 @@
+package typed
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	meraki "github.com/netascode/go-meraki"
+)
+
+// UplinkUsageMaxTimespan is the maximum timespan of a single uplink usage history request.
+const UplinkUsageMaxTimespan time.Duration = 14 * 24 * time.Hour
+
+// LossAndLatencyMaxTimespan is the maximum timespan of a single loss and latency history request.
+const LossAndLatencyMaxTimespan time.Duration = 31 * 24 * time.Hour
+
+// DefaultHistoryMaxPoints is the maximum number of data points targeted by the automatic resolution selection.
+const DefaultHistoryMaxPoints int = 1440
+
+// UplinkUsageResolutions are the valid resolutions of the uplink usage history endpoint.
+var UplinkUsageResolutions = []time.Duration{time.Minute, 5 * time.Minute, 10 * time.Minute, 30 * time.Minute, time.Hour, 24 * time.Hour}
+
+// LossAndLatencyResolutions are the valid resolutions of the loss and latency history endpoint.
+var LossAndLatencyResolutions = []time.Duration{time.Minute, 10 * time.Minute, time.Hour, 24 * time.Hour}
+
+// UplinkUsage is the usage of a single uplink in bytes.
+type UplinkUsage struct {
+	Interface string `json:"interface"`
+	Sent      int64  `json:"sent"`
+	Received  int64  `json:"received"`
+}
+
+// UplinkUsagePoint is a data point of the uplink usage history.
+type UplinkUsagePoint struct {
+	StartTime   time.Time     `json:"startTime"`
+	EndTime     time.Time     `json:"endTime"`
+	ByInterface []UplinkUsage `json:"byInterface"`
+}
+
+// LossAndLatencyPoint is a data point of the loss and latency history.
+type LossAndLatencyPoint struct {
+	StartTs     time.Time `json:"startTs"`
+	EndTs       time.Time `json:"endTs"`
+	LossPercent *float64  `json:"lossPercent"`
+	LatencyMs   *float64  `json:"latencyMs"`
+	Goodput     *float64  `json:"goodput"`
+	Jitter      *float64  `json:"jitter"`
+}
+
+// SelectResolution returns the smallest of the resolutions, which results in at most
+// maxPoints data points for the timespan, or the largest resolution otherwise.
+func SelectResolution(ts meraki.Timespan, resolutions []time.Duration, maxPoints int) time.Duration {
+	length := ts.Length()
+	if length == 0 {
+		length = 24 * time.Hour
+	}
+	for _, r := range resolutions {
+		if int(length/r) <= maxPoints {
+			return r
+		}
+	}
+	return resolutions[len(resolutions)-1]
+}
+
+// UplinkUsageHistory returns the uplink usage history of an appliance network.
+// A resolution of 0 selects the resolution automatically. Timespans longer than the
+// maximum of the endpoint are split into multiple requests.
+func (client Client) UplinkUsageHistory(networkID string, ts meraki.Timespan, resolution time.Duration, mods ...func(*meraki.Req)) ([]UplinkUsagePoint, error) {
+	if resolution == 0 {
+		resolution = SelectResolution(ts, UplinkUsageResolutions, DefaultHistoryMaxPoints)
+	}
+	m := append([]func(*meraki.Req){meraki.Query("resolution", strconv.Itoa(int(resolution.Seconds())))}, mods...)
+	res, err := client.GetTimespan("/networks/"+networkID+"/appliance/uplinks/usageHistory", ts, UplinkUsageMaxTimespan, m...)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]UplinkUsagePoint, 0)
+	err = res.Unmarshal(&points)
+	return points, err
+}
+
+// LossAndLatencyHistory returns the uplink loss and latency history of a device to a destination IP.
+// A resolution of 0 selects the resolution automatically. Timespans longer than the
+// maximum of the endpoint are split into multiple requests.
+func (client Client) LossAndLatencyHistory(serial, ip string, ts meraki.Timespan, resolution time.Duration, mods ...func(*meraki.Req)) ([]LossAndLatencyPoint, error) {
+	if resolution == 0 {
+		resolution = SelectResolution(ts, LossAndLatencyResolutions, DefaultHistoryMaxPoints)
+	}
+	m := append([]func(*meraki.Req){meraki.Query("ip", ip), meraki.Query("resolution", strconv.Itoa(int(resolution.Seconds())))}, mods...)
+	res, err := client.GetTimespan("/devices/"+serial+"/lossAndLatencyHistory", ts, LossAndLatencyMaxTimespan, m...)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]LossAndLatencyPoint, 0)
+	err = res.Unmarshal(&points)
+	return points, err
+}
+
+// DownsampleUplinkUsage aggregates uplink usage data points into fixed buckets,
+// summing up the usage per interface.
+func DownsampleUplinkUsage(points []UplinkUsagePoint, bucket time.Duration) []UplinkUsagePoint {
+	result := make([]UplinkUsagePoint, 0)
+	index := make(map[int64]int)
+	for _, p := range points {
+		start := p.StartTime.Truncate(bucket)
+		i, ok := index[start.Unix()]
+		if !ok {
+			i = len(result)
+			index[start.Unix()] = i
+			result = append(result, UplinkUsagePoint{StartTime: start, EndTime: start.Add(bucket)})
+		}
+		for _, u := range p.ByInterface {
+			found := false
+			for j := range result[i].ByInterface {
+				if result[i].ByInterface[j].Interface == u.Interface {
+					result[i].ByInterface[j].Sent += u.Sent
+					result[i].ByInterface[j].Received += u.Received
+					found = true
+				}
+			}
+			if !found {
+				result[i].ByInterface = append(result[i].ByInterface, u)
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].StartTime.Before(result[j].StartTime) })
+	return result
+}
+
+// DownsampleLossAndLatency aggregates loss and latency data points into fixed buckets,
+// averaging the values. Missing values are ignored.
+func DownsampleLossAndLatency(points []LossAndLatencyPoint, bucket time.Duration) []LossAndLatencyPoint {
+	type sums struct {
+		start  time.Time
+		values [4]float64
+		counts [4]int
+	}
+	buckets := make([]*sums, 0)
+	index := make(map[int64]*sums)
+	for _, p := range points {
+		start := p.StartTs.Truncate(bucket)
+		s, ok := index[start.Unix()]
+		if !ok {
+			s = &sums{start: start}
+			index[start.Unix()] = s
+			buckets = append(buckets, s)
+		}
+		for i, v := range []*float64{p.LossPercent, p.LatencyMs, p.Goodput, p.Jitter} {
+			if v != nil {
+				s.values[i] += *v
+				s.counts[i]++
+			}
+		}
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].start.Before(buckets[j].start) })
+	result := make([]LossAndLatencyPoint, 0, len(buckets))
+	for _, s := range buckets {
+		var avg [4]*float64
+		for i := range s.values {
+			if s.counts[i] > 0 {
+				v := s.values[i] / float64(s.counts[i])
+				avg[i] = &v
+			}
+		}
+		result = append(result, LossAndLatencyPoint{
+			StartTs:     s.start,
+			EndTs:       s.start.Add(bucket),
+			LossPercent: avg[0],
+			LatencyMs:   avg[1],
+			Goodput:     avg[2],
+			Jitter:      avg[3],
+		})
+	}
+	return result
+}
@@ -0,0 +1,70 @@
+package typed
+
+import (
+	"testing"
+	"time"
+
+	meraki "github.com/netascode/go-meraki"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestSelectResolution tests the SelectResolution function.
+func TestSelectResolution(t *testing.T) {
+	assert.Equal(t, time.Minute, SelectResolution(meraki.LastTimespan(time.Hour), UplinkUsageResolutions, 1440))
+	assert.Equal(t, 30*time.Minute, SelectResolution(meraki.LastTimespan(14*24*time.Hour), UplinkUsageResolutions, 1440))
+	assert.Equal(t, 24*time.Hour, SelectResolution(meraki.LastTimespan(3650*24*time.Hour), UplinkUsageResolutions, 1440))
+}
+
+// TestClientUplinkUsageHistory tests the Client::UplinkUsageHistory method.
+func TestClientUplinkUsageHistory(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	gock.New(client.BaseUrl).Get("/networks/N_1/appliance/uplinks/usageHistory").
+		MatchParam("t0", "2024-01-01T00:00:00Z").
+		MatchParam("resolution", "1800").
+		Reply(200).
+		BodyString(`[{"startTime":"2024-01-01T00:00:00Z","endTime":"2024-01-01T01:00:00Z","byInterface":[{"interface":"wan1","sent":1,"received":2}]}]`)
+	gock.New(client.BaseUrl).Get("/networks/N_1/appliance/uplinks/usageHistory").
+		MatchParam("t0", "2024-01-15T00:00:00Z").
+		Reply(200).
+		BodyString(`[{"startTime":"2024-01-15T00:00:00Z","endTime":"2024-01-15T01:00:00Z","byInterface":[{"interface":"wan1","sent":3,"received":4}]}]`)
+
+	points, err := client.UplinkUsageHistory("N_1", meraki.BetweenTimespan(t0, t0.Add(20*24*time.Hour)), 0)
+	assert.NoError(t, err)
+	assert.Len(t, points, 2)
+	assert.Equal(t, int64(3), points[1].ByInterface[0].Sent)
+
+	downsampled := DownsampleUplinkUsage(points, 30*24*time.Hour)
+	assert.Len(t, downsampled, 1)
+	assert.Equal(t, UplinkUsage{Interface: "wan1", Sent: 4, Received: 6}, downsampled[0].ByInterface[0])
+}
+
+// TestClientLossAndLatencyHistory tests the Client::LossAndLatencyHistory method.
+func TestClientLossAndLatencyHistory(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Get("/devices/Q-1/lossAndLatencyHistory").
+		MatchParam("ip", "8.8.8.8").
+		MatchParam("timespan", "3600").
+		MatchParam("resolution", "60").
+		Reply(200).
+		BodyString(`[
+			{"startTs":"2024-01-01T00:00:00Z","endTs":"2024-01-01T00:01:00Z","lossPercent":0,"latencyMs":10},
+			{"startTs":"2024-01-01T00:01:00Z","endTs":"2024-01-01T00:02:00Z","lossPercent":null,"latencyMs":20}
+		]`)
+
+	points, err := client.LossAndLatencyHistory("Q-1", "8.8.8.8", meraki.LastTimespan(time.Hour), 0)
+	assert.NoError(t, err)
+	assert.Len(t, points, 2)
+	assert.Nil(t, points[1].LossPercent)
+
+	downsampled := DownsampleLossAndLatency(points, 5*time.Minute)
+	assert.Len(t, downsampled, 1)
+	assert.Equal(t, 15.0, *downsampled[0].LatencyMs)
+	assert.Equal(t, 0.0, *downsampled[0].LossPercent)
+	assert.Nil(t, downsampled[0].Jitter)
+}
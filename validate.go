@@ -0,0 +1,75 @@
+package meraki
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidID is the error of requests with a malformed serial, network ID or organization ID, see ValidateIDs.
+var ErrInvalidID = errors.New("invalid ID")
+
+var (
+	serialRegexp    = regexp.MustCompile(`^[A-Z0-9]{4}-[A-Z0-9]{4}-[A-Z0-9]{4}$`)
+	macRegexp       = regexp.MustCompile(`^[0-9a-fA-F]{2}(?:[:-][0-9a-fA-F]{2}){5}$`)
+	networkIDRegexp = regexp.MustCompile(`^[LN]_[0-9]+$`)
+	orgIDRegexp     = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// ValidSerial reports whether s is a well-formed device serial number, e.g. Q2XX-XXXX-XXXX.
+func ValidSerial(s string) bool {
+	return serialRegexp.MatchString(s)
+}
+
+// ValidMAC reports whether s is a well-formed MAC address, e.g. 00:18:0a:12:34:56 or 00-18-0A-12-34-56.
+func ValidMAC(s string) bool {
+	return macRegexp.MatchString(s) && strings.Count(s, s[2:3]) == 5
+}
+
+// ValidNetworkID reports whether s is a well-formed network or configuration template ID, e.g. N_123 or L_123.
+func ValidNetworkID(s string) bool {
+	return networkIDRegexp.MatchString(s)
+}
+
+// ValidOrgID reports whether s is a well-formed organization ID, e.g. 123456.
+func ValidOrgID(s string) bool {
+	return orgIDRegexp.MatchString(s)
+}
+
+// ValidateIDs validates the serial, network ID or organization ID of request paths
+// like /devices/{serial}, /networks/{id} and /organizations/{id}, including requests
+// of Org scoped clients, before sending requests. Malformed IDs fail immediately with
+// ErrInvalidID instead of a 404 response, which does not use up the rate limit.
+func ValidateIDs() func(*Client) {
+	return func(client *Client) {
+		client.ValidateIDs = true
+	}
+}
+
+// validatePath returns an ErrInvalidID error if the ID of a request path is malformed.
+func (client *Client) validatePath(req Req) error {
+	if !client.ValidateIDs {
+		return nil
+	}
+	segments := strings.SplitN(strings.TrimPrefix(client.relPath(req.HttpReq.URL), "/"), "/", 3)
+	if len(segments) < 2 {
+		return nil
+	}
+	id := segments[1]
+	switch segments[0] {
+	case "devices":
+		if !ValidSerial(id) {
+			return fmt.Errorf("%w: serial '%s'", ErrInvalidID, id)
+		}
+	case "networks":
+		if !ValidNetworkID(id) {
+			return fmt.Errorf("%w: network ID '%s'", ErrInvalidID, id)
+		}
+	case "organizations":
+		if !ValidOrgID(id) {
+			return fmt.Errorf("%w: organization ID '%s'", ErrInvalidID, id)
+		}
+	}
+	return nil
+}
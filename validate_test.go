@@ -0,0 +1,46 @@
+package meraki
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestValidators tests the ValidSerial, ValidMAC, ValidNetworkID and ValidOrgID functions.
+func TestValidators(t *testing.T) {
+	assert.True(t, ValidSerial("Q2XX-AB12-CD34"))
+	assert.False(t, ValidSerial("Q2XX-AB12-CD3"))
+	assert.False(t, ValidSerial("q2xx-ab12-cd34"))
+	assert.True(t, ValidMAC("00:18:0a:12:34:56"))
+	assert.True(t, ValidMAC("00-18-0A-12-34-56"))
+	assert.False(t, ValidMAC("00:18-0a:12:34:56"))
+	assert.False(t, ValidMAC("00:18:0a:12:34"))
+	assert.True(t, ValidNetworkID("N_123"))
+	assert.True(t, ValidNetworkID("L_123"))
+	assert.False(t, ValidNetworkID("123"))
+	assert.True(t, ValidOrgID("123456"))
+	assert.False(t, ValidOrgID("abc"))
+}
+
+// TestValidateIDs tests the ValidateIDs modifier.
+func TestValidateIDs(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+	ValidateIDs()(&client)
+
+	for _, path := range []string{"/devices/Q2XX-AB12", "/networks/123/clients", "/organizations/N_1"} {
+		_, err := client.Get(path)
+		assert.ErrorIs(t, err, ErrInvalidID, path)
+	}
+	_, err := client.NewOrg("O_1").Get("/networks")
+	assert.ErrorIs(t, err, ErrInvalidID)
+
+	gock.New(client.BaseUrl).Get("/organizations/123/devices/statuses").Reply(200).BodyString(`[]`)
+	gock.New(client.BaseUrl).Get("/organizations").Reply(200).BodyString(`[]`)
+	_, err = client.NewOrg("123").Get("/devices/statuses")
+	assert.NoError(t, err)
+	_, err = client.Get("/organizations")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), client.Stats().Requests)
+}
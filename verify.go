@@ -0,0 +1,68 @@
+package meraki
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+const DefaultVerifyTimeout time.Duration = 30 * time.Second
+
+// ErrVerifyTimeout is returned by PutAndVerify if the change is not visible before the VerifyTimeout.
+var ErrVerifyTimeout = errors.New("change not visible before verify timeout")
+
+// VerifyTimeout modifies the maximum time PutAndVerify waits for a change to become visible. Default value is 30 seconds.
+func VerifyTimeout(x time.Duration) func(*Client) {
+	return func(client *Client) {
+		client.VerifyTimeout = x
+	}
+}
+
+// PutAndVerify makes a PUT request and polls the resource with GET requests until verify
+// returns true. Dashboard reads sometimes lag behind writes, therefore the resource is
+// polled with the backoff delays of the client until the change is visible or the
+// VerifyTimeout expired, e.g.
+//
+//	res, err := client.PutAndVerify("/networks/N_123", `{"name":"New"}`, func(res Res) bool {
+//		return res.Get("name").String() == "New"
+//	})
+//
+// Waiting stops when the context of the requests is done, see Context. It returns the last
+// GET response, or the PUT response if the PUT request failed.
+func (client *Client) PutAndVerify(path, body string, verify func(Res) bool, mods ...func(*Req)) (Res, error) {
+	res, err := client.Put(path, body, mods...)
+	if err != nil {
+		return res, err
+	}
+	ctx := client.NewReq("GET", path, nil, mods...).HttpReq.Context()
+	deadline := time.Now().Add(client.VerifyTimeout)
+	for attempts := 0; ; attempts++ {
+		res, err = client.Get(path, append(mods, NoCache)...)
+		if err != nil {
+			return res, err
+		}
+		if verify(res) {
+			return res, nil
+		}
+		delay := client.verifyDelay(attempts)
+		if remaining := time.Until(deadline); remaining <= 0 {
+			return res, fmt.Errorf("%w: %s", ErrVerifyTimeout, path)
+		} else if delay > remaining {
+			delay = remaining
+		}
+		client.logf("[DEBUG] Change of %s not visible yet, verifying again in %v", path, delay)
+		if err := sleep(ctx, delay); err != nil {
+			return res, err
+		}
+	}
+}
+
+// verifyDelay returns the delay before the next verify attempt, following the backoff settings without jitter.
+func (client *Client) verifyDelay(attempts int) time.Duration {
+	delay := float64(time.Duration(client.BackoffMinDelay)*time.Second) * math.Pow(client.BackoffDelayFactor, float64(attempts))
+	if maxDelay := float64(time.Duration(client.BackoffMaxDelay) * time.Second); delay > maxDelay {
+		delay = maxDelay
+	}
+	return time.Duration(delay)
+}
@@ -0,0 +1,48 @@
+package meraki
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestClientPutAndVerify tests the Client::PutAndVerify method.
+func TestClientPutAndVerify(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+	BackoffMinDelay(0)(&client)
+	BackoffMaxDelay(0)(&client)
+	verify := func(res Res) bool { return res.Get("name").Str == "New" }
+
+	gock.New(client.BaseUrl).Put("/networks/N_1").Reply(200).BodyString(`{"name":"New"}`)
+	gock.New(client.BaseUrl).Get("/networks/N_1").Reply(200).BodyString(`{"name":"Old"}`)
+	gock.New(client.BaseUrl).Get("/networks/N_1").Reply(200).BodyString(`{"name":"New"}`)
+	res, err := client.PutAndVerify("/networks/N_1", `{"name":"New"}`, verify)
+	assert.NoError(t, err)
+	assert.Equal(t, "New", res.Get("name").Str)
+	assert.True(t, gock.IsDone())
+
+	// Change never visible
+	VerifyTimeout(10 * time.Millisecond)(&client)
+	BackoffMaxDelay(1)(&client)
+	BackoffMinDelay(1)(&client)
+	gock.New(client.BaseUrl).Put("/networks/N_1").Reply(200).BodyString(`{"name":"New"}`)
+	gock.New(client.BaseUrl).Get("/networks/N_1").Persist().Reply(200).BodyString(`{"name":"Old"}`)
+	res, err = client.PutAndVerify("/networks/N_1", `{"name":"New"}`, verify)
+	assert.True(t, errors.Is(err, ErrVerifyTimeout))
+	assert.Equal(t, "Old", res.Get("name").Str)
+
+	// Waiting stops when the context is done
+	VerifyTimeout(time.Minute)(&client)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	gock.New(client.BaseUrl).Put("/networks/N_1").Reply(200).BodyString(`{"name":"New"}`)
+	start := time.Now()
+	_, err = client.PutAndVerify("/networks/N_1", `{"name":"New"}`, verify, Context(ctx))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
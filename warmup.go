@@ -0,0 +1,59 @@
+package meraki
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Warmup pre-establishes the connection to the API, so the first real request does not pay
+// for DNS resolution and the TCP and TLS handshakes. Without organization IDs, a single
+// HEAD request is sent to the base URL, which is not rate limited and its status is ignored.
+// With organization IDs, each organization is fetched, which also follows any redirect to
+// the organization shard and stores its cookies, e.g.
+//
+//	err := client.Warmup(ctx, "123")
+func (client *Client) Warmup(ctx context.Context, orgIDs ...string) error {
+	if len(orgIDs) == 0 {
+		req, err := http.NewRequestWithContext(ctx, "HEAD", client.BaseUrl, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Add("User-Agent", client.UserAgent)
+		res, err := client.HttpClient.Do(req)
+		if err != nil {
+			return err
+		}
+		io.Copy(io.Discard, res.Body)
+		return res.Body.Close()
+	}
+	for _, id := range orgIDs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := client.Get("/organizations/"+id, NoCache, Context(ctx)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// KeepWarm calls Warmup without organization IDs periodically in the background until ctx is
+// cancelled, so idle connections are not closed between infrequent requests.
+func (client *Client) KeepWarm(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := client.Warmup(ctx); err != nil && ctx.Err() == nil {
+					client.logf("[DEBUG] Keeping connection warm failed: %s", err)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,58 @@
+package meraki
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestClientWarmup tests the Client::Warmup method.
+func TestClientWarmup(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+
+	gock.New(client.BaseUrl).Head("").Reply(404)
+	assert.NoError(t, client.Warmup(context.Background()))
+
+	gock.New(client.BaseUrl).Get("/organizations/123").Reply(200).BodyString(`{"id":"123"}`)
+	assert.NoError(t, client.Warmup(context.Background(), "123"))
+	assert.True(t, gock.IsDone())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, client.Warmup(ctx, "123"))
+
+	// The context also cancels organization requests in flight
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+	client, _ = NewClient("abc123", BaseUrl(server.URL), Transport(server.Client().Transport), MaxRetries(0))
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, client.Warmup(ctx, "123"), context.DeadlineExceeded)
+}
+
+// TestClientKeepWarm tests the Client::KeepWarm method.
+func TestClientKeepWarm(t *testing.T) {
+	var count atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "HEAD", r.Method)
+		count.Add(1)
+	}))
+	defer server.Close()
+	client, _ := NewClient("abc123", BaseUrl(server.URL))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client.KeepWarm(ctx, 10*time.Millisecond)
+	assert.Eventually(t, func() bool { return count.Load() >= 2 }, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,196 @@
+package meraki
+
+import (
+	"context"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+const DefaultWatchInterval time.Duration = 60 * time.Second
+const DefaultWatchMaxBackoff time.Duration = 15 * time.Minute
+
+const (
+	// DeviceStatuses is the device statuses endpoint of an organization
+	DeviceStatuses string = "/devices/statuses"
+	// DeviceAvailabilities is the device availabilities endpoint of an organization
+	DeviceAvailabilities string = "/devices/availabilities"
+)
+
+// DeviceStatusEvent is emitted by a DeviceWatcher when the status of a device changes.
+type DeviceStatusEvent struct {
+	// Serial is the serial number of the device
+	Serial string
+	// OldStatus is the last known status, empty if the device is new
+	OldStatus string
+	// NewStatus is the current status, empty if the device has been removed
+	NewStatus string
+	// Device is the current device object, or the last known one if removed
+	Device gjson.Result
+	// Time is the time of the poll which detected the change
+	Time time.Time
+}
+
+// DeviceWatcher polls the device statuses of an organization and emits an event for every change.
+// Use meraki.NewDeviceWatcher to initiate a watcher.
+type DeviceWatcher struct {
+	// Org is the watched organization
+	Org Org
+	// Endpoint is the polled endpoint, relative to the organization
+	Endpoint string
+	// Interval between two polls
+	Interval time.Duration
+	// Maximum interval between two polls after failures
+	MaxBackoff time.Duration
+	// EmitInitial emits an event for every device on the first poll
+	EmitInitial bool
+	// OnError is called when a poll fails
+	OnError func(err error)
+	// Last known devices by serial
+	state map[string]gjson.Result
+}
+
+// NewDeviceWatcher creates a new device status watcher for an organization.
+// Pass modifiers in to modify the behavior of the watcher, e.g.
+//
+//	watcher := NewDeviceWatcher(&client, "123456", WatchInterval(30*time.Second))
+//	for event := range watcher.Watch(ctx) {
+//		log.Printf("%s: %s -> %s", event.Serial, event.OldStatus, event.NewStatus)
+//	}
+func NewDeviceWatcher(client *Client, orgID string, mods ...func(*DeviceWatcher)) *DeviceWatcher {
+	watcher := DeviceWatcher{
+		Org:        client.NewOrg(orgID),
+		Endpoint:   DeviceStatuses,
+		Interval:   DefaultWatchInterval,
+		MaxBackoff: DefaultWatchMaxBackoff,
+	}
+	for _, mod := range mods {
+		mod(&watcher)
+	}
+	return &watcher
+}
+
+// WatchInterval modifies the interval between two polls. Default value is 60 seconds.
+func WatchInterval(x time.Duration) func(*DeviceWatcher) {
+	return func(watcher *DeviceWatcher) {
+		watcher.Interval = x
+	}
+}
+
+// WatchMaxBackoff modifies the maximum interval between two polls after failures. Default value is 15 minutes.
+func WatchMaxBackoff(x time.Duration) func(*DeviceWatcher) {
+	return func(watcher *DeviceWatcher) {
+		watcher.MaxBackoff = x
+	}
+}
+
+// WatchAvailabilities polls the device availabilities endpoint instead of device statuses.
+func WatchAvailabilities(watcher *DeviceWatcher) {
+	watcher.Endpoint = DeviceAvailabilities
+}
+
+// WatchEmitInitial emits an event for every device on the first poll.
+func WatchEmitInitial(watcher *DeviceWatcher) {
+	watcher.EmitInitial = true
+}
+
+// Poll fetches the current device statuses once and returns the changes since the last poll.
+func (watcher *DeviceWatcher) Poll() ([]DeviceStatusEvent, error) {
+	return watcher.PollContext(context.Background())
+}
+
+// PollContext is like Poll and cancels the request when ctx is done.
+func (watcher *DeviceWatcher) PollContext(ctx context.Context) ([]DeviceStatusEvent, error) {
+	res, err := watcher.Org.Get(watcher.Endpoint, Context(ctx))
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	initial := watcher.state == nil
+	state := make(map[string]gjson.Result)
+	events := make([]DeviceStatusEvent, 0)
+	for _, device := range res.Array() {
+		serial := device.Get("serial").String()
+		if serial == "" {
+			continue
+		}
+		state[serial] = device
+		status := device.Get("status").String()
+		old, known := watcher.state[serial]
+		if (initial && !watcher.EmitInitial) || (known && old.Get("status").String() == status) {
+			continue
+		}
+		events = append(events, DeviceStatusEvent{
+			Serial:    serial,
+			OldStatus: old.Get("status").String(),
+			NewStatus: status,
+			Device:    device,
+			Time:      now,
+		})
+	}
+	for serial, old := range watcher.state {
+		if _, ok := state[serial]; !ok {
+			events = append(events, DeviceStatusEvent{
+				Serial:    serial,
+				OldStatus: old.Get("status").String(),
+				Device:    old,
+				Time:      now,
+			})
+		}
+	}
+	watcher.state = state
+	return events, nil
+}
+
+// Watch polls the device statuses until ctx is cancelled and emits events through the returned channel.
+// The channel is closed once ctx is cancelled. Failed polls are retried with exponential backoff.
+// The requests are bound to ctx and panics of OnError are recovered, see HookError.
+func (watcher *DeviceWatcher) Watch(ctx context.Context) <-chan DeviceStatusEvent {
+	events := make(chan DeviceStatusEvent)
+	go func() {
+		defer close(events)
+		failures := 0
+		for {
+			delay := watcher.Interval
+			changes, err := watcher.PollContext(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				failures++
+				watcher.Org.Client.logf("[ERROR] Device status poll failed: %s, failures: %v", err, failures)
+				if watcher.OnError != nil {
+					watcher.Org.Client.runHook("OnError", func() { watcher.OnError(err) })
+				}
+				delay = watchBackoff(watcher.Interval, watcher.MaxBackoff, failures)
+			} else {
+				failures = 0
+			}
+			for _, change := range changes {
+				select {
+				case events <- change:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events
+}
+
+// watchBackoff returns the poll interval after a number of consecutive failures.
+func watchBackoff(interval, maxBackoff time.Duration, failures int) time.Duration {
+	delay := interval
+	for i := 0; i < failures && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,89 @@
+package meraki
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+// TestDeviceWatcherPoll tests the DeviceWatcher::Poll method.
+func TestDeviceWatcherPoll(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+	watcher := NewDeviceWatcher(&client, "123")
+
+	gock.New(client.BaseUrl).Get("/organizations/123/devices/statuses").
+		Reply(200).
+		BodyString(`[{"serial":"A","status":"online"},{"serial":"B","status":"online"}]`)
+	gock.New(client.BaseUrl).Get("/organizations/123/devices/statuses").
+		Reply(200).
+		BodyString(`[{"serial":"A","status":"offline"},{"serial":"C","status":"online"}]`)
+
+	events, err := watcher.Poll()
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+
+	events, err = watcher.Poll()
+	assert.NoError(t, err)
+	assert.Len(t, events, 3)
+	assert.Equal(t, "A", events[0].Serial)
+	assert.Equal(t, "online", events[0].OldStatus)
+	assert.Equal(t, "offline", events[0].NewStatus)
+	assert.Equal(t, "", events[1].OldStatus)
+	assert.Equal(t, "B", events[2].Serial)
+	assert.Equal(t, "", events[2].NewStatus)
+}
+
+// TestDeviceWatcherWatch tests the DeviceWatcher::Watch method.
+func TestDeviceWatcherWatch(t *testing.T) {
+	defer gock.Off()
+	client := testClient()
+	watcher := NewDeviceWatcher(&client, "123", WatchAvailabilities, WatchEmitInitial, WatchInterval(time.Millisecond))
+
+	gock.New(client.BaseUrl).Get("/organizations/123/devices/availabilities").
+		Reply(200).
+		BodyString(`[{"serial":"A","status":"online"}]`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	event := <-watcher.Watch(ctx)
+	cancel()
+	assert.Equal(t, "A", event.Serial)
+	assert.Equal(t, "online", event.NewStatus)
+}
+
+// TestDeviceWatcherWatchContext tests that DeviceWatcher::Watch binds requests to the context and recovers panics of OnError.
+func TestDeviceWatcherWatchContext(t *testing.T) {
+	var count atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if count.Add(1) == 1 {
+			w.WriteHeader(500)
+			return
+		}
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), Transport(server.Client().Transport))
+	watcher := NewDeviceWatcher(&client, "123", WatchInterval(time.Millisecond), WatchMaxBackoff(time.Millisecond))
+	watcher.OnError = func(err error) { panic("OnError") }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	for range watcher.Watch(ctx) {
+	}
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, int64(2), count.Load())
+	assert.Equal(t, int64(1), client.Stats().HookErrors)
+}
+
+// TestWatchBackoff tests the watchBackoff function.
+func TestWatchBackoff(t *testing.T) {
+	assert.Equal(t, 4*time.Second, watchBackoff(time.Second, time.Minute, 2))
+	assert.Equal(t, time.Minute, watchBackoff(time.Second, time.Minute, 10))
+}
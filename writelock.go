@@ -0,0 +1,77 @@
+package meraki
+
+import (
+	"strings"
+	"sync"
+)
+
+// writeLocks are the write locks per network, organization or device. It is shared by all
+// copies of a client.
+type writeLocks struct {
+	mutex sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// writeScopes are the collections of the API whose resources are serialized independently, see
+// SerializeWritesPerNetwork.
+var writeScopes = []string{"networks", "organizations", "devices"}
+
+// SerializeWritesPerNetwork serializes DELETE/POST/PUT requests per network instead of across
+// all API paths, so writes to unrelated networks proceed in parallel while conflicting updates of
+// the same network are still sent one at a time, e.g.
+//
+//	client, _ := NewClient("abc123", SerializeWritesPerNetwork())
+//
+// The network is parsed from the path, e.g. /networks/N_123/appliance/vlans is serialized with
+// other writes to N_123. Writes to /organizations/{id} and /devices/{serial} paths are serialized
+// per organization and device, all other writes across all paths not scoped to a network,
+// organization or device.
+func SerializeWritesPerNetwork() func(*Client) {
+	return func(client *Client) {
+		client.SerializeWrites = true
+		client.writeLocks = &writeLocks{locks: make(map[string]*sync.Mutex)}
+	}
+}
+
+// writeScope returns the network, organization or device of a path, e.g. /networks/N_123,
+// or an empty string if the path is not scoped to any of them.
+func writeScope(path string) string {
+	segments := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 3)
+	if len(segments) < 2 || segments[1] == "" {
+		return ""
+	}
+	for _, scope := range writeScopes {
+		if segments[0] == scope {
+			return "/" + scope + "/" + segments[1]
+		}
+	}
+	return ""
+}
+
+// writeLock returns the write lock of a path, which is the lock of its network in
+// SerializeWritesPerNetwork mode and the client write lock otherwise.
+func (client *Client) writeLock(path string) *sync.Mutex {
+	scope := writeScope(path)
+	if client.writeLocks == nil || scope == "" {
+		return client.mutex
+	}
+	client.writeLocks.mutex.Lock()
+	defer client.writeLocks.mutex.Unlock()
+	lock, ok := client.writeLocks.locks[scope]
+	if !ok {
+		lock = &sync.Mutex{}
+		client.writeLocks.locks[scope] = lock
+	}
+	return lock
+}
+
+// lockWrite acquires the write lock for an attempt of a DELETE/POST/PUT request and returns it,
+// or nil if the request is not serialized, see SerializeWrites.
+func (client *Client) lockWrite(req Req) *sync.Mutex {
+	if req.HttpReq.Method == "GET" || req.writeLocked || !client.SerializeWrites {
+		return nil
+	}
+	lock := client.writeLock(client.relPath(req.HttpReq.URL))
+	lock.Lock()
+	return lock
+}
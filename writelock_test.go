@@ -0,0 +1,61 @@
+package meraki
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestWriteScope tests the writeScope function.
+func TestWriteScope(t *testing.T) {
+	assert.Equal(t, "/networks/N_1", writeScope("/networks/N_1/appliance/vlans"))
+	assert.Equal(t, "/networks/N_1", writeScope("/networks/N_1"))
+	assert.Equal(t, "/organizations/123", writeScope("/organizations/123/networks"))
+	assert.Equal(t, "/devices/Q2XX-AB12-CD34", writeScope("/devices/Q2XX-AB12-CD34/switch/ports/1"))
+	assert.Equal(t, "", writeScope("/organizations"))
+	assert.Equal(t, "", writeScope("/networks/"))
+	assert.Equal(t, "", writeScope("/administered/identities/me"))
+}
+
+// TestSerializeWritesPerNetwork tests the SerializeWritesPerNetwork modifier.
+func TestSerializeWritesPerNetwork(t *testing.T) {
+	var mutex sync.Mutex
+	active := make(map[string]int)
+	peak := make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		network := strings.Split(r.URL.Path, "/")[2]
+		mutex.Lock()
+		active[network]++
+		active[""]++
+		peak[network] = max(peak[network], active[network])
+		peak[""] = max(peak[""], active[""])
+		mutex.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		mutex.Lock()
+		active[network]--
+		active[""]--
+		mutex.Unlock()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, _ := NewClient("abc123", BaseUrl(server.URL), MaxRetries(0), Transport(server.Client().Transport), SerializeWritesPerNetwork())
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			client.Put(fmt.Sprintf("/networks/N_%d", i%2), `{}`)
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 1, peak["N_0"])
+	assert.Equal(t, 1, peak["N_1"])
+	assert.Equal(t, 2, peak[""])
+}
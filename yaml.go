@@ -0,0 +1,63 @@
+package meraki
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/tidwall/gjson"
+	"gopkg.in/yaml.v3"
+)
+
+// ToYAML renders the result as YAML document, preserving the order of object keys, e.g.
+//
+//	res, _ := client.Get("/networks/N_123/appliance/vlans")
+//	out, _ := meraki.Normalize(res).ToYAML()
+func (res Res) ToYAML() (string, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(yamlNode(res.Result)); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// yamlNode converts a GJSON result into a YAML node.
+func yamlNode(r gjson.Result) *yaml.Node {
+	switch {
+	case r.IsObject():
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		r.ForEach(func(k, v gjson.Result) bool {
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: k.Str}, yamlNode(v))
+			return true
+		})
+		return node
+	case r.IsArray():
+		node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, v := range r.Array() {
+			node.Content = append(node.Content, yamlNode(v))
+		}
+		return node
+	case r.Type == gjson.String:
+		node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: r.Str}
+		// quote YAML 1.1 booleans for compatibility with older parsers
+		switch strings.ToLower(r.Str) {
+		case "y", "yes", "n", "no", "on", "off":
+			node.Style = yaml.DoubleQuotedStyle
+		}
+		return node
+	case r.Type == gjson.Number:
+		tag := "!!float"
+		if _, ok := canonicalNumber(r.Raw); ok && !bytes.ContainsAny([]byte(r.Raw), ".eE") {
+			tag = "!!int"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: r.Raw}
+	case r.Type == gjson.True || r.Type == gjson.False:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: r.Raw}
+	default:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	}
+}
@@ -0,0 +1,25 @@
+package meraki
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestResToYAML tests the Res::ToYAML method.
+func TestResToYAML(t *testing.T) {
+	res := Body{Str: `{"name":"a","vlan":10,"rate":1.5,"enabled":true,"mode":"on","tags":["x","y"],"dns":null,"empty":{}}`}.Res()
+	out, err := res.ToYAML()
+	assert.NoError(t, err)
+	assert.Equal(t, `name: a
+vlan: 10
+rate: 1.5
+enabled: true
+mode: "on"
+tags:
+  - x
+  - "y"
+dns: null
+empty: {}
+`, out)
+}